@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// doctorMinGoMinor is the oldest Go 1.x release generated projects build with
+	doctorMinGoMinor = 21
+	// defaultOllamaHost is where Ollama listens unless OLLAMA_HOST says otherwise
+	defaultOllamaHost = "http://localhost:11434"
+)
+
+// doctorStatus is the outcome of a single doctor check
+type doctorStatus int
+
+const (
+	doctorPass doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorResult describes a check outcome and how to fix it
+type doctorResult struct {
+	Status doctorStatus
+	Detail string
+	Hint   string // Remediation shown for warnings and failures
+}
+
+// doctorCheck is a named environment check
+type doctorCheck struct {
+	Name string
+	Run  func(ctx context.Context) doctorResult
+}
+
+// doctorCmd diagnoses common environment problems
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check your environment for common setup problems",
+	Long: `Check that the tools and credentials AgenticGoKit projects need are available.
+
+Checks the Go toolchain, LLM provider API keys, Ollama reachability, write
+access to ~/.agk and git. Warnings are optional features; failures will break
+'agk init' or generated projects. Exits non-zero when any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor(cmd.Context())
+	},
+}
+
+// doctorChecks lists the checks in the order they are printed
+var doctorChecks = []doctorCheck{
+	{"Go toolchain", checkGoToolchain},
+	{"LLM provider API keys", checkProviderKeys},
+	{"Ollama", checkOllama},
+	{"~/.agk writable", checkAgkHome},
+	{"git", checkGit},
+}
+
+func runDoctor(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	color.Cyan("\n🩺 AGK Doctor\n\n")
+
+	failures := 0
+	for _, check := range doctorChecks {
+		result := check.Run(ctx)
+		switch result.Status {
+		case doctorPass:
+			color.Green("✓ %s: %s", check.Name, result.Detail)
+		case doctorWarn:
+			color.Yellow("⚠ %s: %s", check.Name, result.Detail)
+		case doctorFail:
+			failures++
+			color.Red("✗ %s: %s", check.Name, result.Detail)
+		}
+		if result.Status != doctorPass && result.Hint != "" {
+			fmt.Printf("    %s\n", color.HiBlackString("→ %s", result.Hint))
+		}
+	}
+	fmt.Println()
+
+	if failures > 0 {
+		return fmt.Errorf("%d check(s) failed", failures)
+	}
+	color.Green("All required checks passed")
+	return nil
+}
+
+var goVersionPattern = regexp.MustCompile(`go1\.(\d+)`)
+
+// checkGoToolchain verifies go is on PATH and recent enough
+func checkGoToolchain(ctx context.Context) doctorResult {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return doctorResult{
+			Status: doctorFail,
+			Detail: "go not found on PATH",
+			Hint:   "Install Go from https://go.dev/dl/ and make sure it is on your PATH",
+		}
+	}
+
+	output, err := exec.CommandContext(ctx, goBin, "version").Output()
+	if err != nil {
+		return doctorResult{Status: doctorFail, Detail: fmt.Sprintf("'go version' failed: %v", err), Hint: "Reinstall Go from https://go.dev/dl/"}
+	}
+
+	version := strings.TrimSpace(string(output))
+	if m := goVersionPattern.FindStringSubmatch(version); m != nil {
+		if minor, _ := strconv.Atoi(m[1]); minor < doctorMinGoMinor {
+			return doctorResult{
+				Status: doctorFail,
+				Detail: version,
+				Hint:   fmt.Sprintf("Generated projects need Go 1.%d or newer; upgrade from https://go.dev/dl/", doctorMinGoMinor),
+			}
+		}
+	}
+
+	return doctorResult{Status: doctorPass, Detail: version}
+}
+
+// checkProviderKeys reports which hosted LLM providers have credentials
+func checkProviderKeys(_ context.Context) doctorResult {
+	keys := []string{"OPENAI_API_KEY", "ANTHROPIC_API_KEY", "AZURE_OPENAI_API_KEY"}
+
+	var set []string
+	for _, key := range keys {
+		if os.Getenv(key) != "" {
+			set = append(set, key)
+		}
+	}
+
+	if len(set) == 0 {
+		return doctorResult{
+			Status: doctorWarn,
+			Detail: "none of " + strings.Join(keys, ", ") + " are set",
+			Hint:   "Export the key for your provider (e.g. export OPENAI_API_KEY=...), or use --llm ollama for local models",
+		}
+	}
+	return doctorResult{Status: doctorPass, Detail: strings.Join(set, ", ") + " set"}
+}
+
+// checkOllama verifies the Ollama server answers
+func checkOllama(ctx context.Context) doctorResult {
+	host := os.Getenv("OLLAMA_HOST")
+	if host == "" {
+		host = defaultOllamaHost
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	host = strings.TrimSuffix(host, "/")
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host+"/api/tags", nil)
+	if err != nil {
+		return doctorResult{Status: doctorWarn, Detail: fmt.Sprintf("invalid OLLAMA_HOST %q", host), Hint: "Set OLLAMA_HOST to host:port, e.g. localhost:11434"}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return doctorResult{
+			Status: doctorWarn,
+			Detail: fmt.Sprintf("not reachable at %s", host),
+			Hint:   "Only needed for local models: install from https://ollama.com and run 'ollama serve'",
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return doctorResult{Status: doctorWarn, Detail: fmt.Sprintf("%s returned HTTP %d", host, resp.StatusCode), Hint: "Check that OLLAMA_HOST points at an Ollama server"}
+	}
+	return doctorResult{Status: doctorPass, Detail: "reachable at " + host}
+}
+
+// checkAgkHome verifies the template cache directory can be written
+func checkAgkHome(_ context.Context) doctorResult {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return doctorResult{Status: doctorFail, Detail: fmt.Sprintf("cannot find home directory: %v", err), Hint: "Set the HOME environment variable"}
+	}
+
+	dir := filepath.Join(home, ".agk")
+	hint := fmt.Sprintf("Make sure you own %s (e.g. chown -R $USER %s)", dir, dir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return doctorResult{Status: doctorFail, Detail: err.Error(), Hint: hint}
+	}
+
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return doctorResult{Status: doctorFail, Detail: err.Error(), Hint: hint}
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+
+	return doctorResult{Status: doctorPass, Detail: dir}
+}
+
+// checkGit verifies git is available for working with template repositories
+func checkGit(ctx context.Context) doctorResult {
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		return doctorResult{
+			Status: doctorWarn,
+			Detail: "git not found on PATH",
+			Hint:   "Install git to version generated projects and publish your own templates",
+		}
+	}
+
+	output, err := exec.CommandContext(ctx, gitBin, "--version").Output()
+	if err != nil {
+		return doctorResult{Status: doctorWarn, Detail: fmt.Sprintf("'git --version' failed: %v", err)}
+	}
+	return doctorResult{Status: doctorPass, Detail: strings.TrimSpace(string(output))}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
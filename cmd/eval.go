@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/agenticgokit/agk/internal/eval"
@@ -27,37 +32,127 @@ Examples:
   agk eval tests.yaml --verbose
   
   # Validate test file without running
-  agk eval tests.yaml --validate-only`,
+  agk eval tests.yaml --validate-only
+
+  # Re-judge everything even if the suite sets semantic.cache
+  agk eval tests.yaml --no-cache
+
+  # Run the suite 10 times and report each test's pass rate
+  agk eval tests.yaml --repeat 10`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEval,
 }
 
+var evalInitCmd = &cobra.Command{
+	Use:   "init <test-file>",
+	Short: "Generate a starter evaluation test suite",
+	Long: `Write a commented example test suite covering each expectation type
+(exact, contains, regex and semantic with each strategy) to get started quickly.
+
+Examples:
+  # Create a starter suite
+  agk eval init tests.yaml
+
+  # Point it at a running agent
+  agk eval init tests.yaml --target http://localhost:9000`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEvalInit,
+}
+
+var (
+	evalInitTarget string
+	evalInitForce  bool
+)
+
 var (
 	evalTimeout      int
 	evalVerbose      bool
 	evalValidateOnly bool
+	evalDryRun       bool
 	evalOutputFormat string
 	evalFailFast     bool
 	evalReportFile   string
+	evalStrict       bool
+	evalNoReportFile bool
+	evalReportDir    string
+	evalNoCache      bool
+	evalCacheTTL     time.Duration
+
+	evalJudgeTemperature float64
+	evalJudgeMaxTokens   int
+
+	evalSample  int
+	evalShuffle bool
+	evalSeed    int64
+	evalRepeat  int
+
+	evalFailOnWarning bool
+)
+
+// Exit codes of agk eval, so CI can tell failing tests from a run that
+// couldn't even start
+const (
+	exitTestsFailed       = 1 // Tests failed, or warned with --fail-on-warning
+	exitConfigError       = 2 // Invalid flags, test file or model defaults
+	exitTargetUnreachable = 3 // The target failed its health check
+
+	// exitInterrupted is the exit code of a run cancelled with Ctrl+C,
+	// following the shell convention of 128 + SIGINT
+	exitInterrupted = 130
 )
 
+// defaultReportDir is where markdown reports are saved unless overridden
+// with --report-dir or AGK_REPORT_DIR
+const defaultReportDir = ".agk/reports"
+
 func init() {
 	rootCmd.AddCommand(evalCmd)
+	evalCmd.AddCommand(evalInitCmd)
+	// Flag errors are configuration errors, like an invalid test file
+	evalCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return withExitCode(exitConfigError, err)
+	})
+
+	evalInitCmd.Flags().StringVar(&evalInitTarget, "target", eval.DefaultStarterTargetURL, "Target URL of the agent under test")
+	evalInitCmd.Flags().BoolVarP(&evalInitForce, "force", "f", false, "Overwrite the file if it exists")
 
 	evalCmd.Flags().IntVar(&evalTimeout, "timeout", 300, "Timeout in seconds for each test")
 	evalCmd.Flags().BoolVarP(&evalVerbose, "verbose", "v", false, "Verbose output")
 	evalCmd.Flags().BoolVar(&evalValidateOnly, "validate-only", false, "Only validate test file, don't run tests")
+	evalCmd.Flags().BoolVar(&evalDryRun, "dry-run", false, "Show the matcher, strategy, model and threshold each test would use without running it")
 	evalCmd.Flags().StringVarP(&evalOutputFormat, "format", "f", "console", "Output format (console, json, junit, markdown)")
 	evalCmd.Flags().BoolVar(&evalFailFast, "fail-fast", false, "Stop on first test failure")
+	evalCmd.Flags().BoolVar(&evalStrict, "strict", false, "Fail tests whose expectations would match any output")
 	evalCmd.Flags().StringVarP(&evalReportFile, "report", "r", "", "Save detailed report to file (auto-generated if not specified)")
+	evalCmd.Flags().BoolVar(&evalNoReportFile, "no-report-file", false, "Don't save a markdown report to disk")
+	evalCmd.Flags().BoolVar(&evalNoCache, "no-cache", false, "Don't use cached semantic match results (enabled per suite with semantic.cache)")
+	evalCmd.Flags().DurationVar(&evalCacheTTL, "cache-ttl", eval.DefaultMatchCacheTTL, "Ignore cached semantic match results older than this (0 = never expire)")
+	evalCmd.Flags().Float64Var(&evalJudgeTemperature, "judge-temperature", 0, "Override the judge LLM's temperature")
+	evalCmd.Flags().IntVar(&evalJudgeMaxTokens, "judge-max-tokens", 0, "Override the judge LLM's max tokens")
+	evalCmd.Flags().IntVar(&evalSample, "sample", 0, "Run only N randomly chosen tests (session groups stay together)")
+	evalCmd.Flags().BoolVar(&evalShuffle, "shuffle", false, "Run tests in random order")
+	evalCmd.Flags().IntVar(&evalRepeat, "repeat", 1, "Run the suite N times and report each test's pass rate and confidence spread")
+	evalCmd.Flags().BoolVar(&evalFailOnWarning, "fail-on-warning", false, "Exit with code 1 when tests pass with warnings (marginal confidence, vacuous expectations)")
+	evalCmd.Flags().Int64Var(&evalSeed, "seed", 0, "Seed for --sample and --shuffle (default: random, printed with the results)")
+	evalCmd.Flags().StringVar(&evalReportDir, "report-dir", "", "Directory for auto-generated reports (default: $AGK_REPORT_DIR or .agk/reports)")
 }
 
 func runEval(cmd *cobra.Command, args []string) error {
 	testFile := args[0]
 
+	if evalJudgeTemperature < 0 || evalJudgeMaxTokens < 0 {
+		return withExitCode(exitConfigError, fmt.Errorf("--judge-temperature and --judge-max-tokens must not be negative"))
+	}
+	if evalSample < 0 {
+		return withExitCode(exitConfigError, fmt.Errorf("--sample must not be negative"))
+	}
+	if evalRepeat < 1 {
+		return withExitCode(exitConfigError, fmt.Errorf("--repeat must be at least 1"))
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(testFile); os.IsNotExist(err) {
-		return fmt.Errorf("test file not found: %s", testFile)
+		return withExitCode(exitConfigError, fmt.Errorf("test file not found: %s", testFile))
 	}
 
 	// Get absolute path
@@ -70,29 +165,67 @@ func runEval(cmd *cobra.Command, args []string) error {
 		fmt.Printf("📋 Loading test file: %s\n", absPath)
 	}
 
+	// Judge and embedding defaults must be in place before the suite is validated
+	if err := loadEvalDefaults(); err != nil {
+		return withExitCode(exitConfigError, err)
+	}
+
 	// Parse test file
 	suite, err := eval.ParseTestFile(absPath)
 	if err != nil {
-		return fmt.Errorf("failed to parse test file: %w", err)
+		return withExitCode(exitConfigError, fmt.Errorf("failed to parse test file: %w", err))
 	}
 
 	if evalVerbose {
 		fmt.Printf("✓ Loaded %d test(s) from suite: %s\n", len(suite.Tests), suite.Name)
 	}
 
+	// Warn about expectations that would pass vacuously
+	lintWarnings := eval.LintSuite(suite)
+	for _, warning := range lintWarnings {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", warning)
+	}
+
 	// Validate only mode
 	if evalValidateOnly {
 		fmt.Println("✓ Test file is valid")
 		return nil
 	}
 
+	// Failures from here on aren't usage mistakes
+	cmd.SilenceUsage = true
+
 	// Create test runner
-	runner := eval.NewRunner(&eval.RunnerConfig{
+	runnerConfig := &eval.RunnerConfig{
 		Timeout:      time.Duration(evalTimeout) * time.Second,
 		Verbose:      evalVerbose,
 		FailFast:     evalFailFast,
+		Strict:       evalStrict,
 		OutputFormat: evalOutputFormat,
-	})
+		NoCache:      evalNoCache,
+		CacheTTL:     evalCacheTTL,
+
+		JudgeMaxTokens: evalJudgeMaxTokens,
+
+		Sample:  evalSample,
+		Shuffle: evalShuffle,
+		Seed:    evalSeed,
+
+		Quiet: quiet,
+	}
+	if cmd.Flags().Changed("judge-temperature") {
+		runnerConfig.JudgeTemperature = &evalJudgeTemperature
+	}
+
+	// Dry run: resolve matchers without invoking the target
+	if evalDryRun {
+		if !printEvalPlan(suite, runnerConfig) {
+			return withExitCode(exitConfigError, fmt.Errorf("dry run found matchers that can't be created"))
+		}
+		return nil
+	}
+
+	runner := eval.NewRunner(runnerConfig)
 
 	// Run tests
 	if evalVerbose {
@@ -100,23 +233,29 @@ func runEval(cmd *cobra.Command, args []string) error {
 		fmt.Println("==================")
 	}
 
-	results, err := runner.Run(suite)
+	ctx, stop := interruptContext(cmd.Context())
+	results, err := runner.RunRepeated(ctx, suite, evalRepeat)
+	stop()
 	if err != nil {
-		return fmt.Errorf("test execution failed: %w", err)
+		err = fmt.Errorf("test execution failed: %w", err)
+		if errors.Is(err, eval.ErrTargetUnavailable) {
+			return withExitCode(exitTargetUnreachable, err)
+		}
+		return withExitCode(exitConfigError, err)
 	}
 
 	// Generate report
-	reporter := eval.NewReporter(evalOutputFormat)
+	reporter := eval.NewReporter(evalOutputFormat).Quiet(quiet).Color(!color.NoColor)
 	if err := reporter.Generate(results, os.Stdout); err != nil {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
 
 	// Save detailed markdown report to file (by default)
 	reportPath := evalReportFile
-	if reportPath == "" {
+	if reportPath == "" && !evalNoReportFile {
 		// Auto-generate report filename
 		timestamp := time.Now().Format("20060102-150405")
-		reportDir := ".agk/reports"
+		reportDir := resolveReportDir()
 		if err := os.MkdirAll(reportDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to create report directory: %v\n", err)
 		} else {
@@ -139,10 +278,131 @@ func runEval(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Exit with error code if tests failed
+	// Exit with error code if the run was cut short or tests failed
+	if results.Interrupted {
+		return withExitCode(exitInterrupted, fmt.Errorf("interrupted after %d of %d tests", results.TotalTests, results.Planned))
+	}
 	if !results.AllPassed() {
-		os.Exit(1)
+		return withExitCode(exitTestsFailed, fmt.Errorf("%d of %d tests failed", results.FailedTests, results.TotalTests))
 	}
+	if evalFailOnWarning {
+		// Lint warnings were already printed before the run
+		runWarnings := results.Warnings()
+		for _, warning := range runWarnings {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", warning)
+		}
+		if total := len(lintWarnings) + len(runWarnings); total > 0 {
+			return withExitCode(exitTestsFailed, fmt.Errorf("failing on %d warning(s) (--fail-on-warning)", total))
+		}
+	}
+
+	return nil
+}
+
+// interruptContext returns a context cancelled by the first Ctrl+C or
+// SIGTERM, so the run can stop and still report what completed. After that
+// signals get their default behavior again: a second Ctrl+C kills agk.
+// Call stop once the run is over.
+func interruptContext(parent context.Context) (ctx context.Context, stop func()) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+			fmt.Fprintln(os.Stderr, "\n⚠ Interrupted: stopping and reporting completed tests (Ctrl+C again to quit now)")
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
+
+// loadEvalDefaults installs the judge and embedding models semantic tests
+// inherit, from ~/.agk/eval.toml and the AGK_EVAL_* variables
+func loadEvalDefaults() error {
+	path := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		path = filepath.Join(home, ".agk", eval.DefaultsFileName)
+	}
+	defaults, err := eval.LoadSemanticDefaults(path)
+	if err != nil {
+		return err
+	}
+	eval.SetSemanticDefaults(defaults)
+	return nil
+}
+
+// resolveReportDir returns the directory for auto-generated reports
+func resolveReportDir() string {
+	if evalReportDir != "" {
+		return evalReportDir
+	}
+	if dir := os.Getenv("AGK_REPORT_DIR"); dir != "" {
+		return dir
+	}
+	return defaultReportDir
+}
 
+func runEvalInit(cmd *cobra.Command, args []string) error {
+	testFile := args[0]
+
+	if _, err := os.Stat(testFile); err == nil && !evalInitForce {
+		return fmt.Errorf("file already exists: %s (use --force to overwrite)", testFile)
+	}
+
+	content, err := eval.GenerateStarterSuite(evalInitTarget)
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(testFile); dir != "." {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(testFile, content, 0600); err != nil {
+		return fmt.Errorf("failed to write test file: %w", err)
+	}
+
+	fmt.Printf("✓ Created starter test suite: %s\n", testFile)
+	fmt.Printf("  Edit the tests, then run: agk eval %s\n", testFile)
 	return nil
 }
+
+// printEvalPlan prints the target and the resolved matcher of every test.
+// It reports false when any matcher can't be created.
+func printEvalPlan(suite *eval.TestSuite, config *eval.RunnerConfig) bool {
+	fmt.Printf("Suite:  %s\n", suite.Name)
+	fmt.Printf("Target: %s %s\n\n", suite.Target.Type, suite.Target.URL)
+
+	plans := eval.PlanSuite(suite, config)
+	width := 0
+	for _, plan := range plans {
+		width = max(width, len(plan.TestName))
+	}
+
+	failed := 0
+	for _, plan := range plans {
+		mark := "✓"
+		if plan.Err != nil {
+			mark = "✗"
+			failed++
+		}
+		fmt.Printf("%s %-*s  %s\n", mark, width, plan.TestName, plan.Summary())
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		fmt.Printf("✗ %d of %d test(s) have matchers that can't be created\n", failed, len(plans))
+		return false
+	}
+	fmt.Printf("✓ %d test(s) ready; target was not called\n", len(plans))
+	return true
+}
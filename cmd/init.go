@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -14,6 +17,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
+	"github.com/agenticgokit/agk/internal/config"
+	"github.com/agenticgokit/agk/internal/utils"
 	"github.com/agenticgokit/agk/pkg/registry"
 	"github.com/agenticgokit/agk/pkg/scaffold"
 )
@@ -25,9 +30,15 @@ var (
 	initInteractive   bool
 	initForce         bool
 	initLLMProvider   string
+	initLLMModel      string
 	initAgentType     string
 	initDescription   string
 	initListTemplates bool
+	initVerify        bool
+	initHere          bool
+	initForceUnsafe   bool
+	initFromConfig    string
+	initJSON          bool
 )
 
 // initCmd represents the init command
@@ -67,11 +78,21 @@ Examples:
   # Initialize in specific directory
   agk init my-project --output ./projects
 
+  # Initialize in the current (empty) directory, named after it
+  agk init .
+
+  # Regenerate a project from its agk.toml (flags override the file)
+  agk init --from-config agk.toml --force
+
+  # Report the result as JSON for editor integrations
+  agk init my-project --json
+
 	# List available templates
   agk init --list`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		// Allow zero args only when listing templates
-		if initListTemplates {
+		// Allow zero args only when listing templates, generating in place or
+		// taking the project name from --from-config
+		if initListTemplates || ((initHere || initFromConfig != "") && len(args) == 0) {
 			return nil
 		}
 		return cobra.ExactArgs(1)(cmd, args)
@@ -79,8 +100,50 @@ Examples:
 	RunE: runInitCommand,
 }
 
+// initResult is the machine-readable outcome of init, printed with --json
+type initResult struct {
+	Success     bool     `json:"success"`
+	ProjectName string   `json:"project_name,omitempty"`
+	ProjectPath string   `json:"project_path,omitempty"`
+	Template    string   `json:"template,omitempty"`
+	Files       []string `json:"files"`
+	BackedUp    []string `json:"backed_up,omitempty"`
+	Verified    *bool    `json:"verified,omitempty"` // Only set with --verify
+	NextSteps   []string `json:"next_steps,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
 // runInitCommand executes the init command
 func runInitCommand(cmd *cobra.Command, args []string) error {
+	if !initJSON {
+		return initProject(cmd, args, nil)
+	}
+	if initListTemplates {
+		return fmt.Errorf("--json cannot be combined with --list")
+	}
+
+	// Keep stdout for the JSON object alone
+	previousOutput := color.Output
+	color.Output = io.Discard
+	result := &initResult{Files: []string{}}
+	err := initProject(cmd, args, result)
+	color.Output = previousOutput
+
+	result.Success = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if encErr := encoder.Encode(result); encErr != nil {
+		return fmt.Errorf("failed to write JSON result: %w", encErr)
+	}
+	return err
+}
+
+// initProject generates the project. When result is non-nil it is filled in
+// for --json and the human-readable next steps are skipped.
+func initProject(cmd *cobra.Command, args []string, result *initResult) error {
 	// Create observability span for command execution
 	tracer := observability.GetTracer("agk-cli")
 	ctx, span := tracer.Start(cmd.Context(), "agk.init")
@@ -94,25 +157,84 @@ func runInitCommand(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	projectName := args[0]
+	projectName := ""
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+
+	// --from-config fills in everything not given on the command line
+	if initFromConfig != "" {
+		cfg, err := config.LoadConfig(initFromConfig)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid config")
+			color.Red("✗ %v", err)
+			return err
+		}
+		if projectName == "" {
+			projectName = cfg.Name
+		}
+		applyProjectConfig(cmd, cfg)
+		span.SetAttributes(attribute.String("from_config", initFromConfig))
+	}
+
+	// --force-unsafe is --force without the agk project check
+	if initForceUnsafe {
+		initForce = true
+	}
+
+	// "agk init ." or --here generates into the output directory itself
+	inPlace := initHere || projectName == "."
+	projectPath := filepath.Join(initOutputDir, projectName)
+	if inPlace {
+		absPath, err := filepath.Abs(initOutputDir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve current directory: %w", err)
+		}
+		projectPath = initOutputDir
+		projectName = filepath.Base(absPath)
+	}
+
 	span.SetAttributes(
 		attribute.String("project_name", projectName),
 		attribute.String("template", initTemplate),
 		attribute.Bool("force", initForce),
 	)
+	if result != nil {
+		result.ProjectName = projectName
+		result.Template = initTemplate
+		if absPath, err := filepath.Abs(projectPath); err == nil {
+			result.ProjectPath = absPath
+		}
+	}
 
 	// Validate project name
 	if err := validateProjectName(projectName); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid project name")
 		color.Red("✗ Invalid project name: %v", err)
+		if inPlace {
+			color.Yellow("The project name is taken from the directory name; rename the directory or pass a name")
+		}
 		return err
 	}
 
-	projectPath := filepath.Join(initOutputDir, projectName)
-
-	// Check if path already exists
-	if _, err := os.Stat(projectPath); err == nil && !initForce {
+	// Check the target directory is usable
+	if inPlace {
+		if empty, err := utils.IsEmptyDir(projectPath); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "cannot read directory")
+			color.Red("✗ Cannot read directory %s: %v", projectPath, err)
+			return err
+		} else if !empty && !initForce {
+			err := fmt.Errorf("directory is not empty")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "directory not empty")
+			color.Red("✗ Directory is not empty: %s", projectPath)
+			color.Yellow("Use --force to generate into it anyway")
+			return err
+		}
+	} else if _, err := os.Stat(projectPath); err == nil && !initForce {
 		err := fmt.Errorf("project directory already exists")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "directory exists")
@@ -121,6 +243,18 @@ func runInitCommand(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Only let --force overwrite directories that look like agk projects
+	if initForce && !initForceUnsafe {
+		if empty, err := utils.IsEmptyDir(projectPath); err == nil && !empty && !looksLikeAgkProject(projectPath) {
+			err := fmt.Errorf("refusing to overwrite a directory that is not an agk project")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "not an agk project")
+			color.Red("✗ %s has no go.mod or agk.toml; it doesn't look like an agk project", projectPath)
+			color.Yellow("Check the path, or use --force-unsafe to generate into it anyway (overwritten files are kept as *%s)", scaffold.BackupSuffix)
+			return err
+		}
+	}
+
 	// Try to get generator (built-in or external)
 	var generator scaffold.TemplateGenerator
 	var metadata scaffold.TemplateMetadata
@@ -150,9 +284,20 @@ func runInitCommand(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to init cache manager: %w", err)
 		}
-		resolver := registry.NewResolver(cm)
+		timeout, err := resolveFetchTimeout(cmd)
+		if err != nil {
+			return err
+		}
+		resolver := registry.NewResolver(cm).FetchTimeout(timeout).Progress(fetchProgress(quiet || initJSON))
 
 		cached, err := resolver.Resolve(ctx, initTemplate)
+		if errors.Is(err, registry.ErrFetchTimeout) {
+			err = fetchTimeoutHint(err)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "template fetch timed out")
+			color.Red("✗ %v", err)
+			return err
+		}
 		if err != nil {
 			// Failed both built-in and external
 			err = fmt.Errorf("template '%s' not found (neither built-in nor registry): %w", initTemplate, err)
@@ -183,14 +328,21 @@ func runInitCommand(cmd *cobra.Command, args []string) error {
 		Force:       initForce,
 		Description: initDescription,
 		LLMProvider: initLLMProvider,
+		LLMModel:    initLLMModel,
 		AgentType:   initAgentType,
 	}
+	var generated scaffold.GeneratedFiles
+	if result != nil {
+		opts.Record = &generated
+	}
 
 	// Print header with template info
 	metadata = generator.GetMetadata()
-	color.Cyan("\n📦 Creating new AgenticGoKit project: %s\n", projectName)
-	color.Cyan("   Template: %s (%s) - %s\n", metadata.Name, metadata.Complexity, metadata.Description)
-	color.Cyan("   Files: %d | Features: %v\n", metadata.FileCount, metadata.Features)
+	if !quiet {
+		color.Cyan("\n📦 Creating new AgenticGoKit project: %s\n", projectName)
+		color.Cyan("   Template: %s (%s) - %s\n", metadata.Name, metadata.Complexity, metadata.Description)
+		color.Cyan("   Files: %d | Features: %v\n", metadata.FileCount, metadata.Features)
+	}
 
 	// Generate project using the template generator
 	if err := generator.Generate(ctx, opts); err != nil {
@@ -206,9 +358,43 @@ func runInitCommand(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
+	if result != nil {
+		generated.Sort()
+		result.Files = append(result.Files, generated.Created...)
+		result.BackedUp = generated.BackedUp
+	}
 
 	// Print success message
-	color.Green("\n✅ Project initialized successfully!\n")
+	if quiet {
+		color.Green("✅ Project initialized: %s", projectPath)
+	} else {
+		color.Green("\n✅ Project initialized successfully!\n")
+	}
+
+	// Optionally check that the generated project compiles
+	if initVerify {
+		if !quiet {
+			color.Cyan("🔍 Verifying generated project builds...")
+		}
+		err := scaffold.VerifyProject(ctx, projectPath)
+		if result != nil && !errors.Is(err, scaffold.ErrGoNotFound) {
+			verified := err == nil
+			result.Verified = &verified
+		}
+		if err != nil {
+			if errors.Is(err, scaffold.ErrGoNotFound) {
+				color.Yellow("⚠ Skipping verification: %v", err)
+			} else {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "verification failed")
+				color.Red("✗ Generated project does not build: %v", err)
+				color.Yellow("This usually means the '%s' template is broken; please report it to the template author.", initTemplate)
+				return err
+			}
+		} else {
+			color.Green("✓ Generated project builds")
+		}
+	}
 
 	// Record success metrics
 	span.SetAttributes(
@@ -218,6 +404,13 @@ func runInitCommand(cmd *cobra.Command, args []string) error {
 	span.SetStatus(codes.Ok, "project initialized")
 
 	// Print next steps
+	if result != nil {
+		result.NextSteps = nextSteps(projectPath)
+		return nil
+	}
+	if quiet {
+		return nil
+	}
 	printNextSteps(projectName, projectPath, templateType, metadata)
 
 	return nil
@@ -296,13 +489,10 @@ func validateProjectName(name string) error {
 
 // printNextSteps prints the next steps after project initialization
 func printNextSteps(_ string, projectPath string, templateType scaffold.TemplateType, _ scaffold.TemplateMetadata) {
-	relPath, _ := filepath.Rel(".", projectPath)
-
 	fmt.Println(color.BlueString("📖 Next Steps:"))
-	fmt.Printf("  1. %s\n", color.CyanString("cd %s", relPath))
-	fmt.Printf("  2. %s\n", color.CyanString("go mod tidy"))
-	fmt.Printf("  3. %s\n", color.CyanString("export OPENAI_API_KEY=your-key-here  # Set your LLM API key"))
-	fmt.Printf("  4. %s\n", color.CyanString("go run main.go                        # Run the project"))
+	for i, step := range nextSteps(projectPath) {
+		fmt.Printf("  %d. %s\n", i+1, color.CyanString(step))
+	}
 
 	fmt.Println()
 	fmt.Println(color.BlueString("📚 Project Structure:"))
@@ -312,10 +502,13 @@ func printNextSteps(_ string, projectPath string, templateType scaffold.Template
 	case scaffold.TemplateQuickstart:
 		fmt.Printf("  • %s\n", color.CyanString("main.go                    # Entry point with hardcoded agent config"))
 		fmt.Printf("  • %s\n", color.CyanString("go.mod                     # Go module definition"))
+		fmt.Printf("  • %s\n", color.CyanString("agk.toml                   # Project settings for the agk commands"))
 	case scaffold.TemplateWorkflow:
 		fmt.Printf("  • %s\n", color.CyanString("main.go                    # Multi-step workflow pipeline"))
+		fmt.Printf("  • %s\n", color.CyanString("workflow/main.yaml         # The same pipeline, for agk workflow run"))
 		fmt.Printf("  • %s\n", color.CyanString("README.md                  # Documentation for workflow"))
 		fmt.Printf("  • %s\n", color.CyanString("go.mod                     # Go module definition"))
+		fmt.Printf("  • %s\n", color.CyanString("agk.toml                   # Project settings for the agk commands"))
 	default:
 		// Generic structure for other templates
 		fmt.Printf("  • %s\n", color.CyanString("main.go                    # Entry point"))
@@ -345,6 +538,45 @@ func printNextSteps(_ string, projectPath string, templateType scaffold.Template
 	fmt.Println()
 }
 
+// nextSteps lists the commands to run a freshly generated project
+func nextSteps(projectPath string) []string {
+	steps := []string{
+		"go mod tidy",
+		"export OPENAI_API_KEY=your-key-here  # Set your LLM API key",
+		"go run main.go                        # Run the project",
+	}
+	if relPath, _ := filepath.Rel(".", projectPath); relPath != "." {
+		steps = append([]string{"cd " + relPath}, steps...)
+	}
+	return steps
+}
+
+// applyProjectConfig copies settings from an agk.toml into the init flags
+// the user didn't set explicitly
+func applyProjectConfig(cmd *cobra.Command, cfg *config.ProjectConfig) {
+	fromFile := []struct {
+		flag  string
+		value string
+		dest  *string
+	}{
+		{"template", cfg.Template, &initTemplate},
+		{"llm", cfg.LLMProvider, &initLLMProvider},
+		{"model", cfg.Model, &initLLMModel},
+		{"agent-type", cfg.AgentType, &initAgentType},
+		{"description", cfg.Description, &initDescription},
+	}
+	for _, f := range fromFile {
+		if f.value != "" && !cmd.Flags().Changed(f.flag) {
+			*f.dest = f.value
+		}
+	}
+}
+
+// looksLikeAgkProject reports whether dir contains a go.mod or agk.toml
+func looksLikeAgkProject(dir string) bool {
+	return utils.FileExists(filepath.Join(dir, "go.mod")) || utils.FileExists(filepath.Join(dir, "agk.toml"))
+}
+
 func init() {
 	rootCmd.AddCommand(initCmd)
 
@@ -354,8 +586,15 @@ func init() {
 		"Template name (built-in: quickstart, workflow; or a registry template)")
 	initCmd.Flags().StringVarP(&initOutputDir, "output", "o", ".", "Output directory for the project")
 	initCmd.Flags().BoolVarP(&initInteractive, "interactive", "i", false, "Enable interactive prompts")
-	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Force overwrite existing files")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite files in an existing agk project (originals are kept as *.bak)")
+	initCmd.Flags().BoolVar(&initForceUnsafe, "force-unsafe", false, "Like --force, but also allow directories that don't look like agk projects")
 	initCmd.Flags().StringVar(&initLLMProvider, "llm", "", "LLM provider (openai, anthropic, ollama)")
+	initCmd.Flags().StringVar(&initLLMModel, "model", "", "LLM model (defaults to the provider's recommended model)")
 	initCmd.Flags().StringVar(&initAgentType, "agent-type", "", "Agent type (single, multi, specialized)")
 	initCmd.Flags().StringVar(&initDescription, "description", "", "Project description")
+	initCmd.Flags().BoolVar(&initHere, "here", false, "Generate into the output directory itself, named after it (same as 'agk init .')")
+	initCmd.Flags().StringVar(&initFromConfig, "from-config", "", "Take the project name, template, LLM and agent type from an agk.toml")
+	initCmd.Flags().BoolVar(&initVerify, "verify", false, "Run 'go mod tidy' and 'go build' on the generated project")
+	initCmd.Flags().BoolVar(&initJSON, "json", false, "Print the result as a single JSON object instead of colored text")
+	addFetchTimeoutFlag(initCmd)
 }
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agenticgokit/agk/internal/mcp"
+	"github.com/agenticgokit/agk/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "List and check a project's MCP servers",
+	Long: `List the MCP servers configured in a project's agk.toml, check that they
+respond, and see the tools they expose. Servers are reached through
+AgenticGoKit's MCP manager, the same way agents reach them.
+
+Servers are configured as [[mcp.servers]] entries:
+
+  [mcp]
+  enabled = true
+
+  [[mcp.servers]]
+  name = "filesystem"
+  type = "stdio"                  # stdio, tcp, http_streaming, http_sse, websocket
+  command = "mcp-server-filesystem"
+
+  [[mcp.servers]]
+  name = "search"
+  type = "http_streaming"
+  address = "localhost"
+  port = 8811
+
+AgenticGoKit starts stdio servers without arguments, so args are ignored;
+point command at a wrapper script if the server needs them. A server with
+enabled = false is listed but not contacted. Stdio servers are started for
+the check and stopped afterwards.
+
+Examples:
+  agk mcp list
+  agk mcp check
+  agk mcp tools filesystem
+  agk mcp check --project ./my-agent --timeout 30s`,
+}
+
+var mcpListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured MCP servers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadMCPConfig(cmd)
+		if err != nil {
+			return err
+		}
+		if len(cfg.Servers) == 0 {
+			fmt.Println("No MCP servers configured. Add [[mcp.servers]] entries to agk.toml.")
+			return nil
+		}
+
+		infos, err := mcp.Describe(cfg.Servers)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tTYPE\tENDPOINT\tENABLED")
+		for i, info := range infos {
+			s := cfg.Servers[i]
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", info.Name, info.Type, mcpEndpoint(s, info), s.IsEnabled())
+		}
+		_ = w.Flush()
+		return nil
+	},
+}
+
+var mcpCheckCmd = &cobra.Command{
+	Use:     "check [server...]",
+	Aliases: []string{"ping"},
+	Short:   "Check that MCP servers are reachable and list tools",
+	Long: `Connect to each enabled MCP server, or the named ones, through
+AgenticGoKit's MCP manager and list its tools, as an agent does. A server
+that lists no tools fails, since agents would get nothing from it. Exits
+with status 1 when any server fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		servers, timeout, err := selectMCPServers(cmd, args)
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+
+		failed := 0
+		for _, s := range servers {
+			start := time.Now()
+			tools, err := listMCPTools(cmd.Context(), s, timeout)
+			if err != nil {
+				failed++
+				color.Red("✗ %s: %v", s.Name, err)
+				continue
+			}
+			color.Green("✓ %s: %d tools in %dms", s.Name, len(tools), time.Since(start).Milliseconds())
+		}
+
+		if failed > 0 {
+			fmt.Println()
+			return fmt.Errorf("%d of %d servers failed", failed, len(servers))
+		}
+		return nil
+	},
+}
+
+var mcpToolsCmd = &cobra.Command{
+	Use:   "tools [server...]",
+	Short: "List the tools MCP servers expose",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		servers, timeout, err := selectMCPServers(cmd, args)
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+
+		failed := 0
+		for i, s := range servers {
+			if i > 0 {
+				fmt.Println()
+			}
+			tools, err := listMCPTools(cmd.Context(), s, timeout)
+			if err != nil {
+				failed++
+				color.Red("✗ %s: %v", s.Name, err)
+				continue
+			}
+
+			color.Cyan("%s (%d tools)", s.Name, len(tools))
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, t := range tools {
+				_, _ = fmt.Fprintf(w, "  %s\t%s\n", t.Name, contentPreview(t.Description, 80))
+			}
+			_ = w.Flush()
+		}
+
+		if failed > 0 {
+			fmt.Println()
+			return fmt.Errorf("%d of %d servers failed", failed, len(servers))
+		}
+		return nil
+	},
+}
+
+// projectConfigPath returns the agk.toml of the directory given by the
+// command's --project flag
+func projectConfigPath(cmd *cobra.Command) (string, error) {
+	projectDir, _ := cmd.Flags().GetString("project")
+	configPath := filepath.Join(projectDir, "agk.toml")
+	if !utils.FileExists(configPath) {
+		return "", fmt.Errorf("no agk.toml in %s; run this from an agk project or pass --project", projectDir)
+	}
+	return configPath, nil
+}
+
+// loadMCPConfig reads the [mcp] table of the project's agk.toml, warning
+// when MCP is switched off for the project
+func loadMCPConfig(cmd *cobra.Command) (*mcp.Config, error) {
+	configPath, err := projectConfigPath(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := mcp.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if !quiet {
+		if !cfg.IsEnabled() && len(cfg.Servers) > 0 {
+			color.Yellow("⚠ MCP is not enabled in agk.toml (set [mcp] enabled = true); agents won't use these servers.")
+		}
+		for _, s := range cfg.Servers {
+			if len(s.Args) > 0 {
+				color.Yellow("⚠ MCP server %s: AgenticGoKit starts %q without its args", s.Name, s.Command)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// selectMCPServers returns the named servers, or every enabled one, and the
+// --timeout for each
+func selectMCPServers(cmd *cobra.Command, names []string) ([]mcp.Server, time.Duration, error) {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		return nil, 0, fmt.Errorf("--timeout must be positive")
+	}
+
+	cfg, err := loadMCPConfig(cmd)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var servers []mcp.Server
+	if len(names) == 0 {
+		for _, s := range cfg.Servers {
+			if s.IsEnabled() {
+				servers = append(servers, s)
+			}
+		}
+		if len(servers) == 0 {
+			return nil, 0, fmt.Errorf("no enabled MCP servers in agk.toml")
+		}
+		return servers, timeout, nil
+	}
+
+	for _, name := range names {
+		s, ok := cfg.Find(name)
+		if !ok {
+			return nil, 0, fmt.Errorf("no MCP server named %s (see 'agk mcp list')", name)
+		}
+		servers = append(servers, s)
+	}
+	return servers, timeout, nil
+}
+
+// listMCPTools lists a server's tools, giving up after timeout
+func listMCPTools(ctx context.Context, s mcp.Server, timeout time.Duration) ([]mcp.Tool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tools, err := mcp.ListTools(ctx, s)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("no response within %s", timeout)
+	}
+	return tools, err
+}
+
+// mcpEndpoint describes where the manager reaches a server: the command it
+// starts for stdio, or the host and port
+func mcpEndpoint(s mcp.Server, info mcp.ServerInfo) string {
+	if info.Type == mcp.TypeStdio {
+		return s.Command
+	}
+	return net.JoinHostPort(info.Address, strconv.Itoa(info.Port))
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.AddCommand(mcpListCmd)
+	mcpCmd.AddCommand(mcpCheckCmd)
+	mcpCmd.AddCommand(mcpToolsCmd)
+
+	mcpCmd.PersistentFlags().String("project", ".", "Project directory containing agk.toml")
+	mcpCheckCmd.Flags().Duration("timeout", 10*time.Second, "How long to wait for each server")
+	mcpToolsCmd.Flags().Duration("timeout", 10*time.Second, "How long to wait for each server")
+}
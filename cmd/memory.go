@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/agenticgokit/agk/internal/config"
+	"github.com/agenticgokit/agk/internal/memory"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var memoryCmd = &cobra.Command{
+	Use:   "memory",
+	Short: "Inspect and clear a project's memory store",
+	Long: `Inspect and clear the memory and knowledge base of an agk project.
+
+The store is read from the [agents] table of the project's agk.toml:
+
+  [agents]
+  memory_type = "chromem"         # in-memory (default) or chromem
+  memory_path = "./.agk/memory"   # chromem database directory
+
+The in-memory store only lives inside a running agent, so there is nothing
+to list or clear. Other providers, such as pgvector or weaviate, are
+managed with their own tools.
+
+Examples:
+  agk memory list
+  agk memory show mem_1739876543210
+  agk memory clear --yes
+  agk memory list --project ./my-agent`,
+}
+
+var memoryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the entries in the memory store",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openProjectMemory(cmd)
+		if store == nil {
+			return err
+		}
+
+		entries, err := store.List()
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No entries in %s\n", store.Location())
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tCOLLECTION\tTYPE\tSESSION\tCREATED\tCONTENT")
+		for _, e := range entries {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.ID, e.Collection, valueOrDash(e.Kind()),
+				valueOrDash(e.Metadata["session_id"]), valueOrDash(e.Metadata["created_at"]), contentPreview(e.Content, 60))
+		}
+		_ = w.Flush()
+		if !quiet {
+			fmt.Printf("\n%d entries in %s\n", len(entries), store.Location())
+		}
+		return nil
+	},
+}
+
+var memoryShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show a memory entry with its metadata",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openProjectMemory(cmd)
+		if store == nil {
+			return err
+		}
+
+		entry, err := store.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("ID:          %s\n", entry.ID)
+		fmt.Printf("Collection:  %s\n", entry.Collection)
+		keys := make([]string, 0, len(entry.Metadata))
+		for k := range entry.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("%-12s %s\n", k+":", entry.Metadata[k])
+		}
+		fmt.Printf("\n%s\n", entry.Content)
+		return nil
+	},
+}
+
+var memoryClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete every entry in the memory store",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openProjectMemory(cmd)
+		if store == nil {
+			return err
+		}
+
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			if !isInteractiveStdin() {
+				return fmt.Errorf("refusing to clear %s without --yes", store.Location())
+			}
+			fmt.Printf("Delete all entries in %s? [y/N]: ", store.Location())
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		removed, err := store.Clear()
+		if err != nil {
+			return err
+		}
+		color.Green("Cleared %d entries from %s", removed, store.Location())
+		return nil
+	},
+}
+
+// openProjectMemory opens the memory store configured in the project's
+// agk.toml. For stores that aren't persisted it prints a warning and returns
+// a nil store and nil error, so commands have nothing to do.
+func openProjectMemory(cmd *cobra.Command) (memory.Store, error) {
+	configPath, err := projectConfigPath(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := memory.Open(cfg.MemoryType, cfg.MemoryPath, filepath.Dir(configPath))
+	if errors.Is(err, memory.ErrNotPersistent) {
+		memoryType := cfg.MemoryType
+		if memoryType == "" {
+			memoryType = memory.TypeInMemory
+		}
+		color.Yellow("⚠ The %s store only lives inside the running agent; there is nothing stored to inspect.", memoryType)
+		if !quiet {
+			fmt.Println("Set memory_type = \"chromem\" and memory_path in agk.toml's [agents] table to persist memory.")
+		}
+		return nil, nil
+	}
+	return store, err
+}
+
+// contentPreview returns the first line of content, cut to max runes
+func contentPreview(content string, max int) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	if runes := []rune(line); len(runes) > max {
+		return string(runes[:max-1]) + "…"
+	}
+	return line
+}
+
+// valueOrDash returns value, or "-" when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(memoryCmd)
+	memoryCmd.AddCommand(memoryListCmd)
+	memoryCmd.AddCommand(memoryShowCmd)
+	memoryCmd.AddCommand(memoryClearCmd)
+
+	memoryCmd.PersistentFlags().String("project", ".", "Project directory containing agk.toml")
+	memoryClearCmd.Flags().BoolP("yes", "y", false, "Clear without asking for confirmation")
+}
@@ -3,12 +3,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/agenticgokit/agenticgokit/observability"
 	"github.com/agenticgokit/agk/internal/utils"
+	"github.com/fatih/color"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -17,11 +19,14 @@ import (
 var (
 	cfgFile        string
 	verbose        bool
+	quiet          bool
 	debug          bool
 	trace          bool
 	traceExporter  string
 	traceEndpoint  string
 	traceSample    float64
+	traceRunID     string // Run the command's spans are recorded under
+	traceFilePath  string // Trace file, for the file exporter
 	storePrompts   bool
 	tracerShutdown func(context.Context) error
 	logger         *zerolog.Logger
@@ -43,7 +48,7 @@ Features:
   • Memory and knowledge base management
 
 Get started with: agk init my-project`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize zerolog
 		var err error
 		logger, err = utils.NewLogger(debug)
@@ -57,6 +62,14 @@ Get started with: agk init my-project`,
 		} else {
 			zerolog.SetGlobalLevel(zerolog.InfoLevel)
 		}
+		// Decorative output (banners, tips, spinners) is left out with --quiet.
+		// Colors are already dropped when NO_COLOR is set or stdout isn't a
+		// terminal; quiet output drops them too so logs stay plain.
+		quiet = viper.GetBool("quiet")
+		if quiet {
+			color.NoColor = true
+		}
+
 		// Ensure timestamps are enabled
 		*logger = logger.With().Timestamp().Logger()
 		// Use RFC3339 time format consistently
@@ -72,6 +85,9 @@ Get started with: agk init my-project`,
 		traceExporter = viper.GetString("trace_exporter")
 		traceEndpoint = viper.GetString("trace_endpoint")
 		traceSample = viper.GetFloat64("trace_sample")
+		if err := validateTraceSample(traceSample); err != nil {
+			return err
+		}
 
 		if trace {
 			ctx := cmd.Context()
@@ -79,18 +95,18 @@ Get started with: agk init my-project`,
 				ctx = context.Background()
 			}
 
-			runID := generateRunID()
-			ctx = observability.WithRunID(ctx, runID)
+			traceRunID = generateRunID()
+			ctx = observability.WithRunID(ctx, traceRunID)
 			ctx = observability.WithLogger(ctx, logger)
 			cmd.SetContext(ctx)
 
 			// For file exporter, create runs directory and trace file path
-			filePath := traceEndpoint
 			if traceExporter == "" || traceExporter == "file" {
-				if filePath == "" {
-					runDir := fmt.Sprintf(".agk/runs/%s", runID)
+				traceFilePath = traceEndpoint
+				if traceFilePath == "" {
+					runDir := fmt.Sprintf(".agk/runs/%s", traceRunID)
 					_ = os.MkdirAll(runDir, 0750)
-					filePath = fmt.Sprintf("%s/trace.jsonl", runDir)
+					traceFilePath = fmt.Sprintf("%s/trace.jsonl", runDir)
 				}
 				traceExporter = "file"
 			}
@@ -103,7 +119,7 @@ Get started with: agk init my-project`,
 				Exporter:       traceExporter,
 				SampleRate:     traceSample,
 				Debug:          debug,
-				FilePath:       filePath,
+				FilePath:       traceFilePath,
 			}
 
 			tracerShutdown, err = observability.SetupTracer(ctx, cfg)
@@ -111,15 +127,7 @@ Get started with: agk init my-project`,
 				logger.Error().Err(err).Msg("failed to set up tracer")
 			}
 		}
-	},
-	PersistentPostRun: func(cmd *cobra.Command, args []string) {
-		if tracerShutdown != nil {
-			ctx := cmd.Context()
-			if ctx == nil {
-				ctx = context.Background()
-			}
-			_ = tracerShutdown(ctx)
-		}
+		return nil
 	},
 }
 
@@ -128,21 +136,56 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// exitError is a command error that ends agk with a specific exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode makes err end agk with the given exit code
+func withExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the exit code for an error returned by Execute: the one a
+// command chose with withExitCode, or 1
+func ExitCode(err error) int {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
+
+// shutdownTracer flushes the trace. It runs as a cobra finalizer, which
+// unlike PersistentPostRun also runs when a command returns an error.
+func shutdownTracer() {
+	if tracerShutdown != nil {
+		_ = tracerShutdown(context.Background())
+	}
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
+	cobra.OnFinalize(shutdownTracer)
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.agk.toml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress banners and tips, printing only results and errors (also $AGK_QUIET)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "debug mode")
 	rootCmd.PersistentFlags().BoolVar(&trace, "trace", false, "enable tracing")
 	rootCmd.PersistentFlags().StringVar(&traceExporter, "trace-exporter", "console", "trace exporter: console|otlp|file")
 	rootCmd.PersistentFlags().StringVar(&traceEndpoint, "trace-endpoint", "", "OTLP endpoint URL or file path (for file exporter)")
-	rootCmd.PersistentFlags().Float64Var(&traceSample, "trace-sample", 1.0, "trace sample rate (0.0-1.0)")
+	rootCmd.PersistentFlags().Float64Var(&traceSample, "trace-sample", 1.0, "trace sample rate (0.0-1.0); below 1.0 some spans are dropped and their children show up as roots")
 	rootCmd.PersistentFlags().BoolVar(&storePrompts, "store-prompts", false, "store prompts for debugging (if supported by commands)")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	_ = viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet"))
 	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 	_ = viper.BindPFlag("trace", rootCmd.PersistentFlags().Lookup("trace"))
 	_ = viper.BindPFlag("trace_exporter", rootCmd.PersistentFlags().Lookup("trace-exporter"))
@@ -189,6 +232,16 @@ func GetLogger() *zerolog.Logger {
 	return logger
 }
 
+// validateTraceSample checks the sampler ratio, which may also come from
+// AGK_TRACE_SAMPLE or the config file
+func validateTraceSample(sample float64) error {
+	// Written this way round so NaN fails too
+	if !(sample >= 0 && sample <= 1) {
+		return fmt.Errorf("invalid trace sample rate %v: --trace-sample must be between 0.0 and 1.0", sample)
+	}
+	return nil
+}
+
 func generateRunID() string {
 	return fmt.Sprintf("run-%d", time.Now().UnixNano())
 }
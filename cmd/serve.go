@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+	"github.com/agenticgokit/agk/internal/config"
+	"github.com/agenticgokit/agk/internal/mcp"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// defaultServePort is used when neither --port nor agk.toml's [server] port
+// is set; it is the EvalServer's own default
+const defaultServePort = 8787
+
+// serveShutdownTimeout bounds how long in-flight requests get to finish
+// after an interrupt
+const serveShutdownTimeout = 10 * time.Second
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a project's agent for 'agk eval'",
+	Long: `Build an agent from a project's agk.toml and serve it over the HTTP
+contract 'agk eval' and 'agk trace replay' speak:
+
+  GET  /health    {"status": "ok", ...}
+  POST /invoke    {"input": "..."} -> {"output", "trace_id", "tools_called", ...}
+
+The agent uses the [llm] provider, model, api_key and base_url, the
+optional [agents] system_prompt, and, when [mcp] enabled = true, the enabled
+[[mcp.servers]] as tools. It is registered under the project name, so
+/invoke/<name> works too.
+
+Only a single chat agent is served; [workflow] default_workflow is not.
+To evaluate a workflow, serve it from your own code with agk.NewEvalServer
+(see docs/EVAL.md).
+The port comes from --port, then [server] port, then 8787.
+
+The server has no authentication, so it listens on 127.0.0.1 only. To
+serve other machines, pass --host (e.g. 0.0.0.0) together with
+--allow-remote.
+
+Examples:
+  agk serve
+  agk serve --port 8787 &
+  agk eval tests.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, err := projectConfigPath(cmd)
+		if err != nil {
+			return err
+		}
+		cfg, err := config.LoadConfig(configPath)
+		if err != nil {
+			return err
+		}
+		mcpCfg, err := mcp.LoadConfig(configPath)
+		if err != nil {
+			return err
+		}
+
+		host, _ := cmd.Flags().GetString("host")
+		allowRemote, _ := cmd.Flags().GetBool("allow-remote")
+		if !isLoopbackHost(host) && !allowRemote {
+			return fmt.Errorf("refusing to serve on %s: /invoke has no authentication and runs the agent with your API key and tools; pass --allow-remote to expose it beyond this machine", host)
+		}
+
+		port, _ := cmd.Flags().GetInt("port")
+		if !cmd.Flags().Changed("port") && cfg.ServerPort > 0 {
+			port = cfg.ServerPort
+		}
+
+		name := cfg.Name
+		if name == "" {
+			name = filepath.Base(filepath.Dir(configPath))
+		}
+		agent, err := buildServeAgent(name, cfg, mcpCfg)
+		if err != nil {
+			return err
+		}
+
+		logger := log.New(os.Stdout, "[agk serve] ", log.LstdFlags)
+		if quiet {
+			logger.SetOutput(io.Discard)
+		}
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           newServeHandler(name, agent, logger),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		if !isLoopbackHost(host) {
+			color.Red("⚠ Serving on %s: anyone who can reach this address can run the agent with your API key and MCP tools; there is no authentication", addr)
+		}
+		if !quiet {
+			color.Green("Serving %s (%s %s) on http://%s", name, cfg.LLMProvider, cfg.Model, addr)
+			fmt.Printf("Point your eval suite's target url at it, then run: agk eval <suite.yaml>\n")
+		}
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// buildServeAgent creates a chat agent from the project configuration,
+// with the enabled MCP servers as its tools
+func buildServeAgent(name string, cfg *config.ProjectConfig, mcpCfg *mcp.Config) (agk.Agent, error) {
+	if cfg.LLMProvider == "" {
+		return nil, fmt.Errorf("agk.toml has no [llm] provider to serve an agent with")
+	}
+	model := cfg.Model
+	if model == "" {
+		model = config.DefaultModel(cfg.LLMProvider)
+	}
+
+	var timeout time.Duration
+	if cfg.LLMTimeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.LLMTimeout); err != nil {
+			return nil, fmt.Errorf("invalid [llm] timeout %q: %w", cfg.LLMTimeout, err)
+		}
+	}
+
+	opts := []agk.Option{
+		agk.WithLLM(cfg.LLMProvider, model),
+		func(c *agk.Config) {
+			// Empty values fall back to the provider's environment variables
+			c.LLM.APIKey = os.ExpandEnv(cfg.LLMAPIKey)
+			c.LLM.BaseURL = os.ExpandEnv(cfg.LLMBaseURL)
+			c.LLM.HTTPTimeout = timeout
+		},
+	}
+	if cfg.SystemPrompt != "" {
+		opts = append(opts, agk.WithSystemPrompt(cfg.SystemPrompt))
+	}
+
+	var servers []agk.MCPServer
+	for _, s := range mcpCfg.Servers {
+		if s.IsEnabled() {
+			// AgenticGoKit's MCPServer has no arguments to pass on
+			if len(s.Args) > 0 && !quiet {
+				color.Yellow("⚠ MCP server %s: AgenticGoKit starts %q without its args", s.Name, s.Command)
+			}
+			servers = append(servers, agk.MCPServer{
+				Name:    s.Name,
+				Type:    s.Type,
+				Address: s.Address,
+				Port:    s.Port,
+				Command: s.Command,
+				Enabled: true,
+			})
+		}
+	}
+	if len(servers) > 0 && mcpCfg.IsEnabled() {
+		opts = append(opts, func(c *agk.Config) {
+			if c.Tools == nil {
+				c.Tools = &agk.ToolsConfig{}
+			}
+			agk.WithMCP(servers...)(c.Tools)
+		})
+	}
+
+	agent, err := agk.NewChatAgent(name, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+	return agent, nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("host", "127.0.0.1", "Address to listen on")
+	serveCmd.Flags().Bool("allow-remote", false, "Allow a --host other machines can reach; /invoke has no authentication")
+	serveCmd.Flags().Int("port", defaultServePort, "Port to listen on; overrides agk.toml's [server] port")
+	serveCmd.Flags().String("project", ".", "Project directory containing agk.toml")
+}
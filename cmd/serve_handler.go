@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+	"github.com/google/uuid"
+)
+
+// serveHandler answers the EvalServer contract for one agent. agk serve
+// uses it instead of agk.EvalServer, whose ListenAndServe always listens on
+// every interface, so the agent can be bound to --host.
+type serveHandler struct {
+	name   string
+	agent  agk.Agent
+	logger *log.Logger
+}
+
+// newServeHandler routes /health, /invoke and /invoke/<name> to agent
+func newServeHandler(name string, agent agk.Agent, logger *log.Logger) http.Handler {
+	h := &serveHandler{name: name, agent: agent, logger: logger}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", h.handleHealth)
+	mux.HandleFunc("/invoke", h.handleInvoke)
+	mux.HandleFunc("/invoke/", h.handleInvoke)
+	return mux
+}
+
+// handleHealth reports the served agent
+func (h *serveHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeServeJSON(w, map[string]interface{}{
+		"status":    "ok",
+		"agents":    []string{h.name},
+		"workflows": []string{},
+	})
+}
+
+// handleInvoke runs the agent on the request's input, as EvalServer does:
+// streamed, with the optional timeout option in seconds
+func (h *serveHandler) handleInvoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if name := strings.TrimPrefix(r.URL.Path, "/invoke/"); r.URL.Path != "/invoke" && name != h.name {
+		http.Error(w, fmt.Sprintf("Agent or workflow '%s' not found", name), http.StatusNotFound)
+		return
+	}
+
+	var req agk.InvokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp := agk.InvokeResponse{
+		TraceID:   fmt.Sprintf("run-%s-%s", time.Now().Format("20060102-150405"), uuid.NewString()[:8]),
+		SessionID: req.SessionID,
+	}
+	if resp.SessionID == "" {
+		resp.SessionID = uuid.NewString()
+	}
+
+	ctx := r.Context()
+	if timeout, ok := req.Options["timeout"].(float64); ok && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout*float64(time.Second)))
+		defer cancel()
+	}
+
+	start := time.Now()
+	stream, err := h.agent.RunStream(ctx, req.Input)
+	if err != nil {
+		h.logger.Printf("Agent %s stream creation error: %v", h.name, err)
+		resp.Error = err.Error()
+		writeServeJSON(w, resp)
+		return
+	}
+
+	var content strings.Builder
+	for chunk := range stream.Chunks() {
+		if chunk.Type == "text" && chunk.Content != "" {
+			content.WriteString(chunk.Content)
+		}
+	}
+	result, err := stream.Wait()
+	resp.DurationMs = time.Since(start).Milliseconds()
+
+	switch {
+	case err != nil:
+		h.logger.Printf("Agent %s error: %v", h.name, err)
+		resp.Error = err.Error()
+	case result != nil:
+		resp.Output = content.String()
+		if resp.Output == "" {
+			resp.Output = result.Content
+		}
+		resp.Success = result.Success
+		resp.ToolsCalled = result.ToolsCalled
+	default:
+		resp.Output = content.String()
+		resp.Success = true
+	}
+	writeServeJSON(w, resp)
+}
+
+// writeServeJSON writes v as a JSON response
+func writeServeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// isLoopbackHost reports whether host only accepts local connections
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+)
+
+// echoAgent streams its input back as the response
+type echoAgent struct{ agk.Agent }
+
+func (echoAgent) RunStream(_ context.Context, input string, _ ...agk.StreamOption) (agk.Stream, error) {
+	chunks := make(chan *agk.StreamChunk, 1)
+	chunks <- &agk.StreamChunk{Type: "text", Content: "echo: " + input}
+	close(chunks)
+	return echoStream{chunks: chunks}, nil
+}
+
+type echoStream struct {
+	agk.Stream
+	chunks chan *agk.StreamChunk
+}
+
+func (s echoStream) Chunks() <-chan *agk.StreamChunk { return s.chunks }
+func (s echoStream) Wait() (*agk.Result, error) {
+	return &agk.Result{Success: true, ToolsCalled: []string{"search"}}, nil
+}
+
+func TestServeHandler(t *testing.T) {
+	server := httptest.NewServer(newServeHandler("helper", echoAgent{}, log.New(io.Discard, "", 0)))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantOutput string
+	}{
+		{name: "invoke", path: "/invoke", wantStatus: http.StatusOK, wantOutput: "echo: hi"},
+		{name: "invoke by name", path: "/invoke/helper", wantStatus: http.StatusOK, wantOutput: "echo: hi"},
+		{name: "unknown name", path: "/invoke/other", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(server.URL+tt.path, "application/json", strings.NewReader(`{"input":"hi","session_id":"s-1"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("POST %s status = %d, want %d", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got agk.InvokeResponse
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Output != tt.wantOutput || !got.Success || got.SessionID != "s-1" || got.TraceID == "" || len(got.ToolsCalled) != 1 {
+				t.Errorf("POST %s = %+v, want output %q with the session, a trace ID and the tool call", tt.path, got, tt.wantOutput)
+			}
+		})
+	}
+
+	resp, err := http.Get(server.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /health status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"127.0.0.1": true,
+		"localhost": true,
+		"::1":       true,
+		"0.0.0.0":   false,
+		"":          false,
+		"10.0.0.5":  false,
+	} {
+		if got := isLoopbackHost(host); got != want {
+			t.Errorf("isLoopbackHost(%q) = %t, want %t", host, got, want)
+		}
+	}
+}
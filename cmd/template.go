@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"text/tabwriter"
+	"time"
 
 	"github.com/agenticgokit/agk/pkg/registry"
+	"github.com/agenticgokit/agk/pkg/scaffold"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -59,12 +64,15 @@ var templateAddCmd = &cobra.Command{
 			return err
 		}
 
-		resolver := registry.NewResolver(cm)
+		timeout, err := resolveFetchTimeout(cmd)
+		if err != nil {
+			return err
+		}
+		resolver := registry.NewResolver(cm).FetchTimeout(timeout).Progress(fetchProgress(quiet))
 
-		// Use context.Background for now
 		tmpl, err := resolver.Resolve(cmd.Context(), source)
 		if err != nil {
-			return err
+			return fetchTimeoutHint(err)
 		}
 
 		color.Green("Successfully added template: %s (%s)", tmpl.Name, tmpl.Version)
@@ -99,9 +107,91 @@ var templateRemoveCmd = &cobra.Command{
 	},
 }
 
+var templateNewCmd = &cobra.Command{
+	Use:   "new [name]",
+	Short: "Scaffold a new template for authoring",
+	Long: `Create a starter template directory containing a valid agk-template.toml,
+sample .tmpl files, and a README describing the available template variables.
+
+Examples:
+  agk template new my-template
+  agk template new my-template --output ./templates`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := validateProjectName(name); err != nil {
+			return fmt.Errorf("invalid template name: %w", err)
+		}
+
+		outputDir, _ := cmd.Flags().GetString("output")
+		force, _ := cmd.Flags().GetBool("force")
+		dir := filepath.Join(outputDir, name)
+
+		if err := scaffold.GenerateTemplateSkeleton(dir, name, force); err != nil {
+			return err
+		}
+
+		// Local template sources must look like paths to the resolver
+		source := dir
+		if !filepath.IsAbs(source) {
+			source = "./" + filepath.ToSlash(source)
+		}
+
+		color.Green("Created template skeleton: %s", dir)
+		fmt.Printf("Try it with: agk init my-project --template %s\n", source)
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(templateCmd)
 	templateCmd.AddCommand(templateListCmd)
 	templateCmd.AddCommand(templateAddCmd)
 	templateCmd.AddCommand(templateRemoveCmd)
+	templateCmd.AddCommand(templateNewCmd)
+
+	addFetchTimeoutFlag(templateAddCmd)
+
+	templateNewCmd.Flags().StringP("output", "o", ".", "Directory to create the template in")
+	templateNewCmd.Flags().BoolP("force", "f", false, "Overwrite files in an existing directory")
+}
+
+// addFetchTimeoutFlag adds --fetch-timeout to a command that may fetch a
+// remote template
+func addFetchTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration("fetch-timeout", registry.DefaultFetchTimeout,
+		"Give up fetching a remote template after this long, e.g. 30s or 5m; 0 waits forever (also $AGK_FETCH_TIMEOUT)")
+}
+
+// resolveFetchTimeout returns the limit for fetching a remote template: the
+// --fetch-timeout flag, then AGK_FETCH_TIMEOUT, then the registry default
+func resolveFetchTimeout(cmd *cobra.Command) (time.Duration, error) {
+	if cmd.Flags().Changed("fetch-timeout") {
+		return cmd.Flags().GetDuration("fetch-timeout")
+	}
+	if value := os.Getenv("AGK_FETCH_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid AGK_FETCH_TIMEOUT %q: use a duration such as 30s or 5m", value)
+		}
+		return timeout, nil
+	}
+	return registry.DefaultFetchTimeout, nil
+}
+
+// fetchProgress is where git clone progress goes: stdout, unless output
+// should stay free of it
+func fetchProgress(silent bool) io.Writer {
+	if silent {
+		return nil
+	}
+	return os.Stdout
+}
+
+// fetchTimeoutHint points out how to allow more time when a fetch timed out
+func fetchTimeoutHint(err error) error {
+	if errors.Is(err, registry.ErrFetchTimeout) {
+		return fmt.Errorf("%w (check the repository is reachable, or raise --fetch-timeout)", err)
+	}
+	return err
 }
@@ -3,22 +3,31 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/agenticgokit/agk/internal/audit"
+	"github.com/agenticgokit/agk/internal/eval"
 	"github.com/agenticgokit/agk/internal/tui"
+	"github.com/agenticgokit/agk/internal/utils"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
-const runsDirName = ".agk/runs"
+const (
+	runsDirName   = ".agk/runs"
+	stateFilePath = ".agk/state.json"
+)
 
 // traceCmd represents the trace command
 var traceCmd = &cobra.Command{
@@ -36,6 +45,9 @@ Examples:
   agk trace export <run-id>   # Export trace for external tools
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadSpanNameRules(); err != nil {
+			return err
+		}
 		return launchTraceExplorer()
 	},
 }
@@ -44,8 +56,17 @@ Examples:
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all stored traces",
+	Long: `List all stored traces, newest first.
+
+Use --columns to choose and order the table's columns from:
+  ` + strings.Join(listColumnNames(), ", ") + `
+
+Runs can be annotated with a note and #tags from the viewer (press t).
+Use --tag to list only the runs carrying every given tag.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return listTraces()
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		return listTraces(columns, tags)
 	},
 }
 
@@ -59,7 +80,53 @@ var showCmd = &cobra.Command{
 		if len(args) > 0 {
 			runID = args[0]
 		}
-		return showTrace(runID)
+		last, _ := cmd.Flags().GetBool("last")
+		opts := showOptions{}
+		opts.MaxContent, _ = cmd.Flags().GetInt("max-content")
+		opts.Thresholds.WarnMs, _ = cmd.Flags().GetInt64("warn-ms")
+		opts.Thresholds.SlowMs, _ = cmd.Flags().GetInt64("slow-ms")
+		opts.Budget.MaxSpans, _ = cmd.Flags().GetInt("warn-spans")
+		opts.Budget.MaxDepth, _ = cmd.Flags().GetInt("warn-depth")
+		opts.SpanID, _ = cmd.Flags().GetString("span")
+		opts.CollectorURL, _ = cmd.Flags().GetString("collector")
+		opts.OpenDetail, _ = cmd.Flags().GetBool("detail")
+		compact, _ := cmd.Flags().GetBool("compact")
+		singlePane, _ := cmd.Flags().GetBool("single-pane")
+		switch {
+		case singlePane:
+			opts.Layout = tui.LayoutSingle
+		case compact:
+			opts.Layout = tui.LayoutCompact
+		}
+		opts.IncludeScopes, _ = cmd.Flags().GetStringSlice("scope")
+		opts.ExcludeScopes, _ = cmd.Flags().GetStringSlice("exclude-scope")
+		opts.FollowLatest, _ = cmd.Flags().GetBool("follow-latest")
+		opts.CollapseRepeats, _ = cmd.Flags().GetBool("collapse-repeats")
+		opts.GroupOrphans, _ = cmd.Flags().GetBool("group-orphans")
+		opts.ShowDepth, _ = cmd.Flags().GetInt("max-depth")
+		if opts.ShowDepth < 0 {
+			return fmt.Errorf("--max-depth must not be negative")
+		}
+		opts.Refresh, _ = cmd.Flags().GetDuration("refresh")
+		if opts.Refresh <= 0 {
+			return fmt.Errorf("--refresh must be positive, e.g. 500ms or 2s")
+		}
+		if opts.FollowLatest && (runID != "" || last) {
+			return fmt.Errorf("--follow-latest starts from the newest run and cannot be combined with a run ID or --last")
+		}
+		if opts.OpenDetail && opts.SpanID == "" {
+			return fmt.Errorf("--detail requires --span")
+		}
+		if opts.Thresholds.WarnMs > opts.Thresholds.SlowMs {
+			return fmt.Errorf("--warn-ms (%d) must not exceed --slow-ms (%d)", opts.Thresholds.WarnMs, opts.Thresholds.SlowMs)
+		}
+		if err := loadSpanNameRules(); err != nil {
+			return err
+		}
+		if runID == "" && !opts.FollowLatest {
+			runID = pickRunToShow(last)
+		}
+		return showTrace(runID, opts)
 	},
 }
 
@@ -91,7 +158,18 @@ var exportCmd = &cobra.Command{
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
 
-		return exportTraceInternal(runID, format, output)
+		var redactor *audit.Redactor
+		if redact, _ := cmd.Flags().GetBool("redact"); redact {
+			keys, _ := cmd.Flags().GetStringSlice("redact-keys")
+			patterns, _ := cmd.Flags().GetStringSlice("redact-pattern")
+			var err error
+			if redactor, err = audit.NewRedactor(keys, patterns); err != nil {
+				return err
+			}
+		}
+
+		spanID, _ := cmd.Flags().GetString("span")
+		return exportTraceInternal(runID, format, output, spanID, redactor)
 	},
 }
 
@@ -108,14 +186,47 @@ Outputs a TraceObject with events categorized as:
   - llm_call: LLM API calls
 
 Use AGK_TRACE_LEVEL=detailed when running your agent to capture
-full content (prompts, responses, tool args/outputs).`,
+full content (prompts, responses, tool args/outputs).
+
+Event content comes from the agk.prompt.user, agk.llm.response,
+agk.tool.arguments and agk.tool.result attributes. For custom
+instrumentation, add attribute patterns with --content-keys and drop
+them with --exclude-content-keys, or set them for every run in the
+[audit] table of ~/.agk.toml:
+
+  [audit]
+  content_keys = ["myapp.*.input", "myapp.*.output"]
+  exclude_content_keys = ["agk.prompt.user"]
+
+Formats:
+  json     The full TraceObject (default)
+  mermaid  Markdown with a Mermaid flowchart (same as 'agk trace mermaid')
+  dot      Graphviz digraph, e.g. | dot -Tsvg > trace.svg
+  summary  Event counts, duration and token usage as plain text`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		runID := ""
 		if len(args) > 0 {
 			runID = args[0]
 		}
-		return auditTrace(runID)
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		includeEvents, _ := cmd.Flags().GetBool("include-events")
+
+		var expectPath []audit.EventType
+		if pattern, _ := cmd.Flags().GetString("expect-path"); pattern != "" {
+			var err error
+			if expectPath, err = audit.ParsePathPattern(pattern); err != nil {
+				return err
+			}
+		}
+		contentKeys, err := contentKeysFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		// A missing run or path mismatch isn't a usage mistake
+		cmd.SilenceUsage = true
+		return auditTrace(runID, format, output, includeEvents, contentKeys, expectPath, mermaidOptionsFromFlags(cmd))
 	},
 }
 
@@ -126,7 +237,20 @@ var mermaidCmd = &cobra.Command{
 	Long: `Generate a Mermaid flowchart visualizing the agent's execution path.
 
 The diagram shows the sequence of thoughts, tool calls, and decisions
-made by the agent. Output is Markdown with embedded Mermaid code.`,
+made by the agent. Output is Markdown with embedded Mermaid code.
+Use --critical-path to draw the longest-duration root-to-leaf path in
+red, pointing at the best optimization target. Use --collapse-repeats to
+fold loops that repeat the same step into one node with a count.
+
+Use --runs a,b,c to overlay several runs in one diagram: steps are merged
+by name and annotated with how many runs reached them, and rarely-taken
+branches are drawn dashed.
+
+Use --format fenced to print only the fenced mermaid block, for pasting
+into other documents, or --raw (same as --format raw) to print the bare
+diagram, for piping to a renderer such as mmdc.
+
+Equivalent to 'agk trace audit --format mermaid'.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		runID := ""
@@ -134,7 +258,65 @@ made by the agent. Output is Markdown with embedded Mermaid code.`,
 			runID = args[0]
 		}
 		output, _ := cmd.Flags().GetString("output")
-		return generateMermaid(runID, output)
+		includeEvents, _ := cmd.Flags().GetBool("include-events")
+		layout, err := mermaidLayoutFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		contentKeys, err := contentKeysFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if runs, _ := cmd.Flags().GetStringSlice("runs"); len(runs) > 0 {
+			if runID != "" {
+				return fmt.Errorf("pass either a run ID or --runs, not both")
+			}
+			if cmd.Flags().Changed("max-depth") {
+				return fmt.Errorf("--max-depth applies to single runs, not --runs")
+			}
+			return aggregateMermaid(runs, output, includeEvents, contentKeys, layout)
+		}
+		opts := mermaidOptionsFromFlags(cmd)
+		opts.Layout = layout
+		return auditTrace(runID, "mermaid", output, includeEvents, contentKeys, nil, opts)
+	},
+}
+
+// replayCmd re-runs the user prompts from a stored trace against a target
+var replayCmd = &cobra.Command{
+	Use:   "replay [run-id]",
+	Short: "Re-run a stored trace's inputs against an eval target",
+	Long: `Extract the user prompts recorded in a trace and send them to an
+eval-compatible HTTP target, then compare the new outputs with the
+responses stored in the original trace.
+
+Requires a trace captured with AGK_TRACE_LEVEL=detailed so that
+prompts and responses are available.
+
+Examples:
+  agk trace replay --target http://localhost:8787
+  agk trace replay run-1234 --target http://localhost:8787 --timeout 60`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runID := ""
+		if len(args) > 0 {
+			runID = args[0]
+		}
+		target, _ := cmd.Flags().GetString("target")
+		timeout, _ := cmd.Flags().GetInt("timeout")
+		return replayTrace(runID, target, timeout)
+	},
+}
+
+// reindexCmd regenerates manifests for all runs
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Regenerate manifest.json for all trace runs",
+	Long: `Re-parse every run in .agk/runs and write a canonical manifest.json for each,
+so later commands don't need to re-derive run statistics from trace.jsonl.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reindexTraces()
 	},
 }
 
@@ -146,10 +328,66 @@ func init() {
 	traceCmd.AddCommand(exportCmd)
 	traceCmd.AddCommand(auditCmd)
 	traceCmd.AddCommand(mermaidCmd)
+	traceCmd.AddCommand(replayCmd)
+	traceCmd.AddCommand(reindexCmd)
+
+	// List flags
+	listCmd.Flags().StringSlice("columns", defaultListColumns, "Columns to show, in order: "+strings.Join(listColumnNames(), ", "))
+	listCmd.Flags().StringSlice("tag", nil, "Only list runs tagged with all of these tags")
+
+	// Show flags
+	showCmd.Flags().Bool("last", false, "Open the most recently viewed run instead of the newest")
+	showCmd.Flags().Int("max-content", tui.DefaultMaxContentLen, "Characters of prompt/response content shown before truncating")
+	showCmd.Flags().Int64("warn-ms", tui.DefaultDurationThresholds.WarnMs, "Span duration (ms) from which durations are shown in amber")
+	showCmd.Flags().Int64("slow-ms", tui.DefaultDurationThresholds.SlowMs, "Span duration (ms) from which durations are shown in red")
+	showCmd.Flags().Int("warn-spans", tui.DefaultSpanBudget.MaxSpans, "Warn in the run summary when a trace has more spans than this")
+	showCmd.Flags().Int("warn-depth", tui.DefaultSpanBudget.MaxDepth, "Warn in the run summary when the span tree is deeper than this")
+	showCmd.Flags().String("span", "", "Open the viewer with this span ID selected")
+	showCmd.Flags().Bool("detail", false, "With --span, open the span's detail view")
+	showCmd.Flags().String("collector", tui.DefaultCollectorURL, "OTLP/HTTP traces endpoint used by the copy-as-curl action (Y in the detail view)")
+	showCmd.Flags().Bool("compact", false, "Hide the metadata panel to give the tree and details more room")
+	showCmd.Flags().Bool("single-pane", false, "Show only the span tree; press d for full-screen details")
+	showCmd.Flags().StringSlice("scope", nil, "Only show spans whose instrumentation scope contains one of these names (e.g. agenticgokit)")
+	showCmd.Flags().StringSlice("exclude-scope", nil, "Hide spans whose instrumentation scope contains one of these names")
+	showCmd.Flags().Bool("follow-latest", false, "Switch to each new run as it appears and tail its trace")
+	showCmd.Flags().Bool("collapse-repeats", false, "Fold consecutive identical sibling spans into one line (x expands a group, X toggles)")
+	showCmd.Flags().Duration("refresh", tui.DefaultRefreshInterval, "How often a live trace is polled; slows down to 8x while idle and speeds up when spans arrive")
+	showCmd.Flags().Bool("group-orphans", false, "Group spans whose parent is missing from the trace (e.g. dropped by sampling) under one node (o toggles)")
+	showCmd.Flags().Int("max-depth", 0, "Open the span tree showing only this many levels, folding deeper spans behind a '+k more levels' marker (0 = all)")
 
 	// Export flags
-	exportCmd.Flags().String("format", "json", "Export format: json, jaeger, otel")
+	exportCmd.Flags().String("format", "json", "Export format: json, jaeger, otel, speedscope")
 	exportCmd.Flags().String("output", "", "Output file (default: stdout)")
+	exportCmd.Flags().String("span", "", "Export only this span ID and its descendants")
+	exportCmd.Flags().Bool("redact", false, "Redact prompts, responses and secret-looking values before exporting")
+	exportCmd.Flags().StringSlice("redact-keys", nil, "Additional attribute key patterns to redact (glob, e.g. 'myapp.user.*')")
+	exportCmd.Flags().StringSlice("redact-pattern", nil, "Additional value regexes to redact")
+
+	// Audit flags
+	auditCmd.Flags().String("format", "json", "Output format: "+strings.Join(auditFormats, ", "))
+	auditCmd.Flags().String("output", "", "Output file (default: stdout)")
+	auditCmd.Flags().Bool("critical-path", false, "Highlight the longest-duration path (mermaid format)")
+	auditCmd.Flags().Bool("collapse-repeats", false, "Fold consecutive identical sibling steps into one node (mermaid format)")
+	auditCmd.Flags().Int("max-depth", 0, "Summarize spans below this many levels into one node per branch (mermaid format; 0 = no limit)")
+	auditCmd.Flags().Bool("include-events", false, "Merge the run's events.jsonl into the trace, classified by event_type")
+	auditCmd.Flags().StringSlice("content-keys", nil, "Additional attribute key patterns holding event content (glob, e.g. 'myapp.*.input')")
+	auditCmd.Flags().StringSlice("exclude-content-keys", nil, "Attribute key patterns never used as event content, including defaults")
+	auditCmd.Flags().String("expect-path", "", "Fail unless the reasoning path matches a pattern, e.g. \"thought, tool_call+, observation, llm_call\"")
+	mermaidCmd.Flags().String("output", "", "Output file (default: stdout)")
+	mermaidCmd.Flags().Bool("critical-path", false, "Highlight the longest-duration root-to-leaf path")
+	mermaidCmd.Flags().Bool("collapse-repeats", false, "Fold consecutive identical sibling steps into one node with a count and total duration")
+	mermaidCmd.Flags().Int("max-depth", 0, "Summarize spans below this many levels into one node per branch (0 = no limit)")
+	mermaidCmd.Flags().StringSlice("runs", nil, "Overlay several runs (comma-separated IDs) in one diagram")
+	mermaidCmd.Flags().Bool("include-events", false, "Merge the run's events.jsonl into the diagram, classified by event_type")
+	mermaidCmd.Flags().StringSlice("content-keys", nil, "Additional attribute key patterns holding event content (glob, e.g. 'myapp.*.input')")
+	mermaidCmd.Flags().StringSlice("exclude-content-keys", nil, "Attribute key patterns never used as event content, including defaults")
+	mermaidCmd.Flags().String("format", mermaidLayoutMarkdown, "Output layout: markdown (document with headings), fenced (mermaid block only), raw (diagram only)")
+	mermaidCmd.Flags().Bool("raw", false, "Print only the diagram, without the Markdown fence and headings (same as --format raw)")
+
+	// Replay flags
+	replayCmd.Flags().String("target", "", "Eval target base URL (e.g. http://localhost:8787)")
+	replayCmd.Flags().Int("timeout", 300, "Timeout in seconds for each replayed input")
+	_ = replayCmd.MarkFlagRequired("target")
 }
 
 // TraceRun represents a stored trace run
@@ -164,6 +402,8 @@ type TraceRun struct {
 	LLMCalls      int       `json:"llm_calls"`
 	TotalTokens   int       `json:"total_tokens"`
 	EstimatedCost float64   `json:"estimated_cost"`
+
+	Notes tui.RunNotes `json:"-"` // Kept in notes.json so reindexing never drops them
 }
 
 // launchTraceExplorer launches the unified trace explorer TUI
@@ -186,63 +426,164 @@ func launchTraceExplorer() error {
 		return nil
 	}
 
-	// Load all runs with their spans
-	var runDataList []tui.RunData
+	var runDirs []string
 	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
+		if entry.IsDir() {
+			runDirs = append(runDirs, entry.Name())
 		}
+	}
 
-		runPath := filepath.Join(runsDir, entry.Name())
+	// Runs are read behind a loading screen and their spans parsed only when
+	// opened. Malformed lines and bad timestamps are reported once the TUI
+	// has exited.
+	var (
+		warningsMu        sync.Mutex
+		malformed         = make(map[string][]int)
+		timestampWarnings = make(map[string]string)
+	)
+	loadRun := func(i int) (tui.RunData, bool) {
+		runID := runDirs[i]
+		runPath := filepath.Join(runsDir, runID)
 		manifest, err := readManifest(runPath)
 		if err != nil {
-			continue
+			return tui.RunData{}, false
 		}
-
-		// Read spans
-		tracePath := filepath.Join(runPath, "trace.jsonl")
-		data, err := os.ReadFile(tracePath)
+		data, err := audit.ReadTraceFile(runPath)
 		if err != nil {
-			continue
+			return tui.RunData{}, false
 		}
-		spans := tui.ParseSpans(string(data))
+		return tui.LazyRunData(toTUIManifest(manifest), string(data), func() ([]tui.Span, error) {
+			data, err := audit.ReadTraceFile(runPath)
+			if err != nil {
+				return nil, err
+			}
+			spans, lines := tui.ParseSpansWithReport(string(data))
+			warning := badTimestampsWarning(runID, spans)
+			warningsMu.Lock()
+			malformed[runID] = lines
+			timestampWarnings[runID] = warning
+			warningsMu.Unlock()
+			return spans, nil
+		}), true
+	}
 
-		runDataList = append(runDataList, tui.RunData{
-			Manifest: tui.TraceRun{
-				RunID:         manifest.RunID,
-				Command:       manifest.Command,
-				Status:        manifest.Status,
-				Duration:      manifest.Duration,
-				SpanCount:     manifest.SpanCount,
-				LLMCalls:      manifest.LLMCalls,
-				TotalTokens:   manifest.TotalTokens,
-				EstimatedCost: manifest.EstimatedCost,
-			},
-			Spans: spans,
-		})
+	// Create and run TUI explorer
+	loader := tui.NewExplorerLoader(len(runDirs), loadRun, func(m tui.Model) tui.Model {
+		return m.SpanExport(exportSpanOTLP, tui.DefaultCollectorURL).
+			EditableNotes(saveRunNotes)
+	})
+	p := tea.NewProgram(loader, tea.WithAltScreen())
+	finalModel, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
 	}
 
-	if len(runDataList) == 0 {
+	for _, runID := range runDirs {
+		warnMalformedLines(runID, malformed[runID])
+		if warning := timestampWarnings[runID]; warning != "" {
+			fmt.Fprintln(os.Stderr, warning)
+		}
+	}
+	if l, ok := finalModel.(tui.ExplorerLoader); ok && l.NoRuns() {
 		fmt.Println("No valid traces found.")
 		return nil
 	}
 
-	// Sort by newest first (assuming run IDs contain timestamps)
-	sort.Slice(runDataList, func(i, j int) bool {
-		return runDataList[i].Manifest.RunID > runDataList[j].Manifest.RunID
-	})
-
-	// Create and run TUI explorer
-	model := tui.NewTraceExplorer(runDataList)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("failed to run TUI: %w", err)
+	// Remember the run the user was looking at
+	if m, ok := finalModel.(tui.Model); ok && m.RunID() != "" {
+		saveLastRunID(m.RunID())
 	}
 
 	return nil
 }
 
-func listTraces() error {
+// listColumn is a column of the 'agk trace list' table
+type listColumn struct {
+	Name   string
+	Header string
+	Width  int
+	Value  func(run TraceRun) string
+}
+
+// listColumns are the columns 'agk trace list --columns' can show
+var listColumns = []listColumn{
+	{"run_id", "Run ID", 40, func(run TraceRun) string { return run.RunID }},
+	{"command", "Command", 12, func(run TraceRun) string { return run.Command }},
+	{"status", "Status", 8, func(run TraceRun) string {
+		if run.Status != "completed" && run.Status != "ok" {
+			return "❌ ERROR"
+		}
+		return "✅ OK"
+	}},
+	{"duration", "Duration", 10, func(run TraceRun) string { return fmt.Sprintf("%.2fs", run.Duration) }},
+	{"llm_calls", "LLM Calls", 10, func(run TraceRun) string { return fmt.Sprintf("%d", run.LLMCalls) }},
+	{"tokens", "Tokens", 12, func(run TraceRun) string { return fmt.Sprintf("%d", run.TotalTokens) }},
+	{"cost", "Cost", 10, func(run TraceRun) string { return fmt.Sprintf("$%.4f", run.EstimatedCost) }},
+	{"start_time", "Started", 20, func(run TraceRun) string {
+		if run.StartTime.IsZero() {
+			return "-"
+		}
+		return run.StartTime.Local().Format("2006-01-02 15:04:05")
+	}},
+	{"notes", "Notes", 40, func(run TraceRun) string {
+		if run.Notes.IsEmpty() {
+			return "-"
+		}
+		return truncateString(run.Notes.String(), 40)
+	}},
+}
+
+// defaultListColumns are shown when --columns isn't given
+var defaultListColumns = []string{"run_id", "command", "status", "duration", "llm_calls", "tokens"}
+
+// listColumnNames returns the names accepted by --columns
+func listColumnNames() []string {
+	names := make([]string, len(listColumns))
+	for i, column := range listColumns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// resolveListColumns looks up the named columns, keeping their order
+func resolveListColumns(names []string) ([]listColumn, error) {
+	if len(names) == 0 {
+		names = defaultListColumns
+	}
+
+	columns := make([]listColumn, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, column := range listColumns {
+			if column.Name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column: %s (supported: %s)", name, strings.Join(listColumnNames(), ", "))
+		}
+	}
+	return columns, nil
+}
+
+// formatListRow pads values into the table's columns
+func formatListRow(columns []listColumn, values []string) string {
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = fmt.Sprintf("%-*s", column.Width, values[i])
+	}
+	return strings.TrimRight(strings.Join(cells, " "), " ")
+}
+
+func listTraces(columnNames, tags []string) error {
+	columns, err := resolveListColumns(columnNames)
+	if err != nil {
+		return err
+	}
+
 	runsDir := runsDirName
 
 	// Create directory if it doesn't exist
@@ -272,10 +613,17 @@ func listTraces() error {
 		if err != nil {
 			continue // Skip runs without valid manifest
 		}
+		if !hasAllTags(manifest.Notes, tags) {
+			continue
+		}
 		runs = append(runs, manifest)
 	}
 
 	if len(runs) == 0 {
+		if len(tags) > 0 {
+			fmt.Printf("No runs tagged %s.\n", strings.Join(tags, ", "))
+			return nil
+		}
 		fmt.Println("No valid traces found.")
 		return nil
 	}
@@ -286,79 +634,196 @@ func listTraces() error {
 	})
 
 	// Print table
+	headers := make([]string, len(columns))
+	width := len(columns) - 1
+	for i, column := range columns {
+		headers[i] = column.Header
+		width += column.Width
+	}
+
 	fmt.Println()
-	fmt.Printf("%-40s %-12s %-8s %-10s %-10s %-12s\n",
-		"Run ID", "Command", "Status", "Duration", "LLM Calls", "Tokens")
-	fmt.Println(strings.Repeat("-", 92))
+	fmt.Println(formatListRow(columns, headers))
+	fmt.Println(strings.Repeat("-", width))
 
+	values := make([]string, len(columns))
 	for _, run := range runs {
-		status := "✅ OK"
-		if run.Status != "completed" && run.Status != "ok" {
-			status = "❌ ERROR"
+		for i, column := range columns {
+			values[i] = column.Value(run)
 		}
-
-		duration := fmt.Sprintf("%.2fs", run.Duration)
-		llmCalls := fmt.Sprintf("%d", run.LLMCalls)
-		tokens := fmt.Sprintf("%d", run.TotalTokens)
-
-		fmt.Printf("%-40s %-12s %-8s %-10s %-10s %-12s\n",
-			run.RunID, run.Command, status, duration, llmCalls, tokens)
+		fmt.Println(formatListRow(columns, values))
 	}
 	fmt.Println()
 
 	return nil
 }
 
-func showTrace(runID string) error {
-	runsDir := runsDirName
+// warnMalformedLines tells the user a trace is incomplete because some lines
+// of trace.jsonl could not be parsed
+func warnMalformedLines(runID string, lines []int) {
+	if len(lines) == 0 {
+		return
+	}
+
+	const maxListed = 5
+	listed := make([]string, 0, maxListed)
+	for i, line := range lines {
+		if i == maxListed {
+			listed = append(listed, "...")
+			break
+		}
+		listed = append(listed, strconv.Itoa(line))
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  %s: %d malformed span line(s) skipped (line %s); the trace may be incomplete\n",
+		runID, len(lines), strings.Join(listed, ", "))
+}
+
+// badTimestampsWarning explains that spans show as taking 0ms because their
+// timestamps are in an unrecognized format, or returns "" when all parse
+func badTimestampsWarning(runID string, spans []tui.Span) string {
+	count, example := tui.BadTimestamps(spans)
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("⚠️  %s: %d of %d span(s) have timestamps in an unrecognized format (e.g. %q), so their durations show as 0ms; traces should use RFC 3339",
+		runID, count, len(spans), example)
+}
+
+// warnBadTimestamps prints badTimestampsWarning, if any
+func warnBadTimestamps(runID string, spans []tui.Span) {
+	if warning := badTimestampsWarning(runID, spans); warning != "" {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+}
+
+// showOptions configures the interactive trace viewer
+type showOptions struct {
+	MaxContent   int                    // Content truncation length
+	Thresholds   tui.DurationThresholds // Duration color thresholds
+	Budget       tui.SpanBudget         // Span count/depth warning thresholds
+	SpanID       string                 // Span to select initially
+	OpenDetail   bool                   // Open the selected span's detail view
+	CollectorURL string                 // OTLP endpoint for copied curl commands
+	Layout       tui.LayoutMode         // Panel layout
+	// Instrumentation scope filters (substring match)
+	IncludeScopes []string
+	ExcludeScopes []string
+	FollowLatest  bool // Switch to newer runs as they appear
+	// Fold repeated sibling spans into one line
+	CollapseRepeats bool
+	// Gather spans whose parent is missing under one node
+	GroupOrphans bool
+	// Levels of the span tree shown when the viewer opens (0 = all)
+	ShowDepth int
+	// How often a live trace file is polled
+	Refresh time.Duration
+}
 
+func showTrace(runID string, opts showOptions) error {
 	// If no run ID provided, use latest
 	if runID == "" {
 		runID = getLatestRunID()
-		if runID == "" {
+		if runID == "" && !opts.FollowLatest {
 			fmt.Println("No traces found. Run with AGK_TRACE=true to generate traces.")
 			return nil
 		}
 	}
 
-	runPath := filepath.Join(runsDir, runID)
+	// With --follow-latest and no runs yet, start empty and wait for the first one
+	model := tui.NewTraceViewer("", tui.TraceRun{}, nil)
+	if runID != "" {
+		var err error
+		if model, err = newRunViewer(runID); err != nil {
+			return err
+		}
+	}
+
+	model = model.
+		MaxContentLen(opts.MaxContent).
+		DurationThresholds(opts.Thresholds).
+		SpanBudget(opts.Budget).
+		SpanExport(exportSpanOTLP, opts.CollectorURL).
+		EditableNotes(saveRunNotes).
+		ScopeFilter(opts.IncludeScopes, opts.ExcludeScopes).
+		GroupOrphanedSpans(opts.GroupOrphans).
+		ShowDepth(opts.ShowDepth).
+		RefreshInterval(opts.Refresh).
+		CollapseRepeatedSpans(opts.CollapseRepeats).
+		Layout(opts.Layout)
+	if opts.FollowLatest {
+		model = model.FollowLatest(followLatestRun)
+	}
+	if opts.SpanID != "" {
+		var err error
+		if model, err = model.FocusSpan(opts.SpanID, opts.OpenDetail); err != nil {
+			return err
+		}
+	}
+
+	if runID != "" {
+		saveLastRunID(runID)
+	}
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
+	}
+
+	return nil
+}
+
+// newRunViewer loads a run into a trace viewer that tails its trace file
+func newRunViewer(runID string) (tui.Model, error) {
+	runPath := filepath.Join(runsDirName, runID)
 
 	// Check if run exists
 	if _, err := os.Stat(runPath); os.IsNotExist(err) {
-		return fmt.Errorf("trace not found: %s", runID)
+		return tui.Model{}, fmt.Errorf("trace not found: %s", runID)
 	}
 
 	// Read trace file
-	tracePath := filepath.Join(runPath, "trace.jsonl")
-	data, err := os.ReadFile(tracePath)
+	tracePath := audit.TracePath(runPath)
+	data, err := audit.ReadTraceData(tracePath)
 	if err != nil {
-		return fmt.Errorf("failed to read trace: %w", err)
+		return tui.Model{}, fmt.Errorf("failed to read trace: %w", err)
+	}
+	// Compressed traces are finished runs, so there is nothing to watch. A
+	// line still being written is left for the viewer to read once complete.
+	if audit.IsCompressedTrace(tracePath) {
+		tracePath = ""
+	} else {
+		data = tui.CompleteLines(data)
 	}
 
 	// Parse spans using TUI package
-	spans := tui.ParseSpans(string(data))
+	spans, malformed := tui.ParseSpansWithReport(string(data))
+	warnMalformedLines(runID, malformed)
+	warnBadTimestamps(runID, spans)
 	manifest, _ := readManifest(runPath)
 
-	// Convert manifest to TUI format
-	tuiManifest := tui.TraceRun{
-		RunID:         manifest.RunID,
-		Command:       manifest.Command,
-		Status:        manifest.Status,
-		Duration:      manifest.Duration,
-		SpanCount:     manifest.SpanCount,
-		LLMCalls:      manifest.LLMCalls,
-		TotalTokens:   manifest.TotalTokens,
-		EstimatedCost: manifest.EstimatedCost,
+	// Create TUI with hot reload support
+	return tui.NewTraceViewerWithPath(runID, toTUIManifest(manifest), spans, tracePath, int64(len(data))), nil
+}
+
+// followLatestRun returns the newest run for --follow-latest when it is not
+// the run being shown. Compressed runs are finished and archived, so they are
+// never followed.
+func followLatestRun(currentRunID string) (*tui.LatestRun, error) {
+	runID := getLatestRunID()
+	if runID == "" || runID == currentRunID {
+		return nil, nil
 	}
 
-	// Create and run TUI with hot reload support
-	model := tui.NewTraceViewerWithPath(runID, tuiManifest, spans, tracePath)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("failed to run TUI: %w", err)
+	runPath := filepath.Join(runsDirName, runID)
+	tracePath := audit.TracePath(runPath)
+	if audit.IsCompressedTrace(tracePath) {
+		return nil, nil
 	}
 
-	return nil
+	manifest, _ := readManifest(runPath)
+	tuiManifest := toTUIManifest(manifest)
+	if tuiManifest.RunID == "" {
+		tuiManifest.RunID = runID
+	}
+	return &tui.LatestRun{RunID: runID, Manifest: tuiManifest, TracePath: tracePath}, nil
 }
 
 func viewRun(runID string) error {
@@ -405,7 +870,7 @@ func viewRun(runID string) error {
 	fmt.Println()
 	fmt.Printf("Files\n")
 	fmt.Println(strings.Repeat("─", 60))
-	fmt.Printf("Trace:               %s/trace.jsonl\n", runPath)
+	fmt.Printf("Trace:               %s\n", audit.TracePath(runPath))
 	fmt.Printf("Events:              %s/events.jsonl\n", runPath)
 	fmt.Printf("Manifest:            %s/manifest.json\n", runPath)
 	fmt.Println()
@@ -413,7 +878,7 @@ func viewRun(runID string) error {
 	return nil
 }
 
-func exportTraceInternal(runID, format, output string) error {
+func exportTraceInternal(runID, format, output, spanID string, redactor *audit.Redactor) error {
 	runsDir := runsDirName
 
 	// If no run ID provided, use latest
@@ -426,10 +891,9 @@ func exportTraceInternal(runID, format, output string) error {
 	}
 
 	runPath := filepath.Join(runsDir, runID)
-	tracePath := filepath.Join(runPath, "trace.jsonl")
 
 	// Read trace data
-	data, err := os.ReadFile(tracePath)
+	data, err := audit.ReadTraceFile(runPath)
 	if err != nil {
 		return fmt.Errorf("failed to read trace: %w", err)
 	}
@@ -441,13 +905,25 @@ func exportTraceInternal(runID, format, output string) error {
 		if line == "" {
 			continue
 		}
-		var span map[string]interface{}
-		if err := json.Unmarshal([]byte(line), &span); err != nil {
+		span, err := decodeRawSpan([]byte(line))
+		if err != nil {
 			continue
 		}
 		spans = append(spans, span)
 	}
 
+	// Narrow the export to one span's subtree
+	if spanID != "" {
+		if spans, err = spanSubtree(spans, spanID); err != nil {
+			return fmt.Errorf("%w in run %s", err, runID)
+		}
+	}
+
+	// Strip sensitive values before any format conversion
+	if redactor != nil {
+		redactor.RedactSpans(spans)
+	}
+
 	// Format and export based on format flag
 	var exportData interface{}
 
@@ -462,10 +938,19 @@ func exportTraceInternal(runID, format, output string) error {
 
 	case "otel", "otlp":
 		// Convert to OpenTelemetry format
-		exportData = convertToOTLPFormat(spans, runID)
+		export, skipped := convertToOTLPFormat(spans)
+		if len(skipped) > 0 {
+			fmt.Fprintf(os.Stderr, "⚠️  %s: %d span(s) left out of the OTLP export because of unrecognized timestamps (%v); traces should use RFC 3339\n",
+				runID, len(skipped), skipped[0])
+		}
+		exportData = export
+
+	case "speedscope":
+		// Convert to a speedscope evented profile
+		exportData = convertToSpeedscopeFormat(spans, runID)
 
 	default:
-		return fmt.Errorf("unknown format: %s (supported: json, jaeger, otel)", format)
+		return fmt.Errorf("unknown format: %s (supported: json, jaeger, otel, speedscope)", format)
 	}
 
 	// Marshal data
@@ -535,38 +1020,82 @@ func convertToJaegerFormat(spans []map[string]interface{}, _ string) map[string]
 	}
 }
 
-// convertToOTLPFormat converts to OpenTelemetry Protocol format
-func convertToOTLPFormat(spans []map[string]interface{}, _ string) map[string]interface{} {
-	return map[string]interface{}{
-		"resourceSpans": []map[string]interface{}{
-			{
-				"resource": map[string]interface{}{
-					"attributes": []map[string]interface{}{
-						{
-							"key": "service.name",
-							"value": map[string]interface{}{
-								"stringValue": "agenticgokit",
-							},
-						},
-						{
-							"key": "service.version",
-							"value": map[string]interface{}{
-								"stringValue": "0.6.0",
-							},
-						},
-					},
-				},
-				"scopeSpans": []map[string]interface{}{
-					{
-						"scope": map[string]interface{}{
-							"name": "agenticgokit",
-						},
-						"spans": spans,
-					},
-				},
-			},
-		},
+// spanSubtree returns the span with rootID and all of its descendants, in
+// trace order. The subtree root's parent lies outside the export, so it is
+// rewritten to mark the root as having none.
+func spanSubtree(spans []map[string]interface{}, rootID string) ([]map[string]interface{}, error) {
+	spanID := func(span map[string]interface{}) string {
+		spanCtx, _ := span["SpanContext"].(map[string]interface{})
+		return stringField(spanCtx, "SpanID")
 	}
+	childrenBySpan := make(map[string][]string)
+	found := false
+	for _, span := range spans {
+		parent, _ := span["Parent"].(map[string]interface{})
+		parentID := stringField(parent, "SpanID")
+		childrenBySpan[parentID] = append(childrenBySpan[parentID], spanID(span))
+		found = found || spanID(span) == rootID
+	}
+	if !found {
+		return nil, fmt.Errorf("span %s not found", rootID)
+	}
+
+	// Walk down from the root; visited guards against parent cycles
+	inSubtree := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range childrenBySpan[current] {
+			if !inSubtree[child] {
+				inSubtree[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	var subtree []map[string]interface{}
+	for _, span := range spans {
+		if !inSubtree[spanID(span)] {
+			continue
+		}
+		if spanID(span) == rootID {
+			parent, _ := span["Parent"].(map[string]interface{})
+			detached := make(map[string]interface{}, len(parent)+1)
+			for k, v := range parent {
+				detached[k] = v
+			}
+			detached["SpanID"] = otlpNoParent
+			span["Parent"] = detached
+		}
+		subtree = append(subtree, span)
+	}
+	return subtree, nil
+}
+
+// exportSpanOTLP reads one span of a run and wraps it in the OTLP export format
+func exportSpanOTLP(runID, spanID string) ([]byte, error) {
+	data, err := audit.ReadTraceFile(filepath.Join(runsDirName, runID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		span, err := decodeRawSpan([]byte(line))
+		if err != nil {
+			continue
+		}
+		spanCtx, _ := span["SpanContext"].(map[string]interface{})
+		if id, _ := spanCtx["SpanID"].(string); id == spanID {
+			export, skipped := convertToOTLPFormat([]map[string]interface{}{span})
+			if len(skipped) > 0 {
+				return nil, skipped[0]
+			}
+			return json.MarshalIndent(export, "", "  ")
+		}
+	}
+
+	return nil, fmt.Errorf("span %s not found in run %s", spanID, runID)
 }
 
 // getTraceID extracts the trace ID from spans
@@ -583,26 +1112,113 @@ func getTraceID(spans []map[string]interface{}) string {
 
 // Helper functions
 
+// toTUIManifest converts a run manifest to the viewer's format
+func toTUIManifest(manifest TraceRun) tui.TraceRun {
+	return tui.TraceRun{
+		RunID:         manifest.RunID,
+		Command:       manifest.Command,
+		StartTime:     manifest.StartTime,
+		Status:        manifest.Status,
+		Duration:      manifest.Duration,
+		SpanCount:     manifest.SpanCount,
+		LLMCalls:      manifest.LLMCalls,
+		TotalTokens:   manifest.TotalTokens,
+		EstimatedCost: manifest.EstimatedCost,
+		Notes:         manifest.Notes,
+	}
+}
+
 func readManifest(runPath string) (TraceRun, error) {
-	// First try to read manifest.json if it exists
+	// First try to read manifest.json if it exists and is up to date
 	manifestPath := filepath.Join(runPath, "manifest.json")
 	data, err := os.ReadFile(manifestPath)
-	if err == nil {
+	if err == nil && !traceNewerThanManifest(runPath) {
 		var manifest TraceRun
 		if err := json.Unmarshal(data, &manifest); err == nil {
+			manifest.Notes = readRunNotes(runPath)
 			return manifest, nil
 		}
 	}
 
-	// Fallback: parse trace.jsonl and create synthetic manifest
-	return parseTraceFile(runPath)
+	// Fallback: parse trace.jsonl and persist the result for next time
+	manifest, err := parseTraceFile(runPath)
+	if err != nil {
+		return manifest, err
+	}
+	if err := writeManifest(runPath, manifest); err != nil {
+		GetLogger().Debug().Err(err).Str("run", runPath).Msg("failed to write manifest")
+	}
+	manifest.Notes = readRunNotes(runPath)
+	return manifest, nil
+}
+
+// writeManifest persists a run's manifest.json
+func writeManifest(runPath string, manifest TraceRun) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(runPath, "manifest.json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// traceNewerThanManifest reports whether the trace file was modified after
+// manifest.json was written, e.g. because the run was still in progress
+func traceNewerThanManifest(runPath string) bool {
+	manifestInfo, err := os.Stat(filepath.Join(runPath, "manifest.json"))
+	if err != nil {
+		return true
+	}
+	traceInfo, err := os.Stat(audit.TracePath(runPath))
+	if err != nil {
+		return false
+	}
+	return traceInfo.ModTime().After(manifestInfo.ModTime())
+}
+
+// reindexTraces regenerates manifest.json for every stored run
+func reindexTraces() error {
+	entries, err := os.ReadDir(runsDirName)
+	if os.IsNotExist(err) {
+		fmt.Println("No traces found. Run with AGK_TRACE=true to generate traces.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read runs directory: %w", err)
+	}
+
+	indexed, skipped := 0, 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		runPath := filepath.Join(runsDirName, entry.Name())
+		manifest, err := parseTraceFile(runPath)
+		if err != nil {
+			fmt.Printf("⚠️  Skipped %s: %v\n", entry.Name(), err)
+			skipped++
+			continue
+		}
+		if err := writeManifest(runPath, manifest); err != nil {
+			return fmt.Errorf("failed to reindex %s: %w", entry.Name(), err)
+		}
+		indexed++
+	}
+
+	fmt.Printf("✅ Reindexed %d run(s)", indexed)
+	if skipped > 0 {
+		fmt.Printf(", skipped %d", skipped)
+	}
+	fmt.Println()
+	return nil
 }
 
-// parseTraceFile reads trace.jsonl and creates a TraceRun from the trace data
-// parseTraceFile reads trace.jsonl and creates a TraceRun from the trace data
+// parseTraceFile reads trace.jsonl (or trace.jsonl.gz) and creates a TraceRun
+// from the trace data
 func parseTraceFile(runPath string) (TraceRun, error) {
-	tracePath := filepath.Join(runPath, "trace.jsonl")
-	data, err := os.ReadFile(tracePath)
+	data, err := audit.ReadTraceFile(runPath)
 	if err != nil {
 		return TraceRun{}, fmt.Errorf("no trace file found: %w", err)
 	}
@@ -704,7 +1320,7 @@ func (s *RunStats) updateTimes(span map[string]interface{}) {
 	// Format: "2026-01-19T18:36:38.897+09:00"
 	if st, ok := span["StartTime"].(string); ok {
 		// Try to parse with timezone
-		if t, err := time.Parse(time.RFC3339, st); err == nil {
+		if t, err := utils.ParseTimestamp(st); err == nil {
 			if s.FirstSpan.IsZero() || t.Before(s.FirstSpan) {
 				s.FirstSpan = t
 			}
@@ -716,7 +1332,7 @@ func (s *RunStats) updateTimes(span map[string]interface{}) {
 
 	// Also check EndTime to get the latest time
 	if et, ok := span["EndTime"].(string); ok {
-		if t, err := time.Parse(time.RFC3339, et); err == nil {
+		if t, err := utils.ParseTimestamp(et); err == nil {
 			if t.After(s.LastSpan) {
 				s.LastSpan = t
 			}
@@ -766,6 +1382,82 @@ func getLatestRunID() string {
 	return ""
 }
 
+// viewerState is persisted between invocations of the trace viewer
+type viewerState struct {
+	LastRunID string `json:"last_run_id"`
+}
+
+// readLastRunID returns the last run opened in the viewer, if it still exists
+func readLastRunID() string {
+	data, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return ""
+	}
+	var state viewerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(runsDirName, state.LastRunID)); err != nil {
+		return ""
+	}
+	return state.LastRunID
+}
+
+// saveLastRunID records the run being viewed; failures are not fatal
+func saveLastRunID(runID string) {
+	data, err := json.Marshal(viewerState{LastRunID: runID})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(stateFilePath), 0750); err != nil {
+		return
+	}
+	_ = os.WriteFile(stateFilePath, data, 0600)
+}
+
+// loadSpanNameRules installs the custom span labels from the project's
+// .agk/span_names.json and then ~/.agk/span_names.json; project rules win
+func loadSpanNameRules() error {
+	paths := []string{filepath.Join(filepath.Dir(runsDirName), tui.FriendlyNamesFileName)}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".agk", tui.FriendlyNamesFileName))
+	}
+	return tui.LoadFriendlyNameRules(paths...)
+}
+
+// pickRunToShow chooses a run when none was given on the command line.
+// With useLast it opens the last viewed run; otherwise, on an interactive
+// terminal, it offers to resume the last viewed run when that isn't the newest.
+func pickRunToShow(useLast bool) string {
+	last := readLastRunID()
+	if useLast {
+		return last
+	}
+
+	latest := getLatestRunID()
+	if last == "" || last == latest || !isInteractiveStdin() {
+		return latest
+	}
+
+	fmt.Printf("Resume last viewed run %s? [Y/n] (n opens newest %s): ", last, latest)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "n" || answer == "no" {
+		return latest
+	}
+	return last
+}
+
+// isInteractiveStdin reports whether stdin is a terminal
+func isInteractiveStdin() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 type Span struct {
 	Name                 string                   `json:"Name"`
 	StartTime            string                   `json:"StartTime"`
@@ -779,8 +1471,90 @@ type Span struct {
 	InstrumentationScope map[string]interface{}   `json:"InstrumentationScope"`
 }
 
-// auditTrace analyzes a trace and outputs a TraceObject for evaluation
-func auditTrace(runID string) error {
+// auditFormats are the renderers supported by 'agk trace audit --format'
+var auditFormats = []string{"json", "mermaid", "dot", "summary"}
+
+// Layouts of Mermaid output: a Markdown document with headings, only the
+// fenced diagram, or the bare diagram for renderers such as mmdc
+const (
+	mermaidLayoutMarkdown = "markdown"
+	mermaidLayoutFenced   = "fenced"
+	mermaidLayoutRaw      = "raw"
+)
+
+var mermaidLayouts = []string{mermaidLayoutMarkdown, mermaidLayoutFenced, mermaidLayoutRaw}
+
+// mermaidRenderOptions are the Mermaid diagram options plus the layout the
+// diagram is written in
+type mermaidRenderOptions struct {
+	audit.MermaidOptions
+	Layout string
+}
+
+// mermaidOptionsFromFlags reads the Mermaid rendering flags shared by audit and mermaid
+func mermaidOptionsFromFlags(cmd *cobra.Command) mermaidRenderOptions {
+	opts := mermaidRenderOptions{Layout: mermaidLayoutMarkdown}
+	opts.HighlightCriticalPath, _ = cmd.Flags().GetBool("critical-path")
+	opts.CollapseRepeats, _ = cmd.Flags().GetBool("collapse-repeats")
+	opts.MaxDepth, _ = cmd.Flags().GetInt("max-depth")
+	return opts
+}
+
+// contentKeysFromFlags reads --content-keys and --exclude-content-keys,
+// falling back to content_keys and exclude_content_keys in the [audit]
+// table of the agk config file
+func contentKeysFromFlags(cmd *cobra.Command) (*audit.ContentKeys, error) {
+	include, _ := cmd.Flags().GetStringSlice("content-keys")
+	if !cmd.Flags().Changed("content-keys") {
+		include = viper.GetStringSlice("audit.content_keys")
+	}
+	exclude, _ := cmd.Flags().GetStringSlice("exclude-content-keys")
+	if !cmd.Flags().Changed("exclude-content-keys") {
+		exclude = viper.GetStringSlice("audit.exclude_content_keys")
+	}
+	return audit.NewContentKeys(include, exclude)
+}
+
+// mermaidLayoutFromFlags reads the mermaid command's --format and --raw flags
+func mermaidLayoutFromFlags(cmd *cobra.Command) (string, error) {
+	layout, _ := cmd.Flags().GetString("format")
+	if raw, _ := cmd.Flags().GetBool("raw"); raw {
+		if cmd.Flags().Changed("format") && layout != mermaidLayoutRaw {
+			return "", fmt.Errorf("--raw conflicts with --format %s", layout)
+		}
+		layout = mermaidLayoutRaw
+	}
+	for _, l := range mermaidLayouts {
+		if layout == l {
+			return layout, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format: %s (supported: %s)", layout, strings.Join(mermaidLayouts, ", "))
+}
+
+// layoutMermaid lays out a fenced diagram: heading is written above it for
+// the markdown layout, and the raw layout drops the fence
+func layoutMermaid(diagram, heading, layout string) string {
+	switch layout {
+	case mermaidLayoutRaw:
+		body := strings.TrimSpace(diagram)
+		body = strings.TrimPrefix(body, "```mermaid")
+		body = strings.TrimSuffix(body, "```")
+		return strings.Trim(body, "\n")
+	case mermaidLayoutFenced:
+		return diagram
+	default:
+		return heading + diagram
+	}
+}
+
+// auditTrace collects a run's TraceObject and renders it in the given
+// format (see auditFormats) to output, or stdout when output is empty.
+// includeEvents merges the run's events.jsonl and contentKeys picks the
+// attributes events take their content from; mermaidOpts only applies to
+// the mermaid format. When expectPath is set the reasoning path is checked
+// against it after rendering, returning an error that exits 1 on a mismatch.
+func auditTrace(runID, format, output string, includeEvents bool, contentKeys *audit.ContentKeys, expectPath []audit.EventType, mermaidOpts mermaidRenderOptions) error {
 	runsDir := runsDirName
 
 	// If no run ID provided, use latest
@@ -792,36 +1566,155 @@ func auditTrace(runID string) error {
 		}
 	}
 
+	traceObj, err := collectTraceObject(runsDir, runID, includeEvents, contentKeys)
+	if err != nil {
+		return err
+	}
+
+	var content string
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(traceObj, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal trace object: %w", err)
+		}
+		content = string(data)
+	case "mermaid":
+		content = renderMermaidMarkdown(runID, traceObj, mermaidOpts)
+	case "dot":
+		content = audit.GenerateDOT(traceObj)
+	case "summary":
+		content = renderAuditSummary(runID, traceObj)
+	default:
+		return fmt.Errorf("unknown format: %s (supported: %s)", format, strings.Join(auditFormats, ", "))
+	}
+
+	if err := writeAuditOutput(content, format, output); err != nil {
+		return err
+	}
+
+	// Report on stderr so JSON and other output on stdout stays parseable
+	if len(expectPath) > 0 {
+		if ok, msg := audit.ValidatePath(traceObj, expectPath); !ok {
+			return withExitCode(1, errors.New(msg))
+		}
+		fmt.Fprintln(os.Stderr, "✓ Reasoning path matches the expected pattern")
+	}
+	return nil
+}
+
+// aggregateMermaid renders one Mermaid flowchart overlaying several runs
+func aggregateMermaid(runIDs []string, output string, includeEvents bool, contentKeys *audit.ContentKeys, layout string) error {
+	traceObjs := make([]*audit.TraceObject, 0, len(runIDs))
+	for _, runID := range runIDs {
+		traceObj, err := collectTraceObject(runsDirName, runID, includeEvents, contentKeys)
+		if err != nil {
+			return err
+		}
+		traceObjs = append(traceObjs, traceObj)
+	}
+
+	var heading strings.Builder
+	heading.WriteString(fmt.Sprintf("# Agent Traces: %d runs\n\n", len(runIDs)))
+	heading.WriteString(fmt.Sprintf("**Runs:** %s\n\n", strings.Join(runIDs, ", ")))
+	heading.WriteString("## Execution Flow\n\n")
+	heading.WriteString("Nodes show how many runs reached them; dashed nodes and dotted links were taken by fewer than half of the runs.\n\n")
+
+	content := layoutMermaid(audit.GenerateAggregateMermaid(traceObjs), heading.String(), layout)
+	return writeAuditOutput(content, "mermaid", output)
+}
+
+// collectTraceObject builds the TraceObject of a stored run, merging its
+// events.jsonl when includeEvents is set. A nil contentKeys uses the
+// default content attributes.
+func collectTraceObject(runsDir, runID string, includeEvents bool, contentKeys *audit.ContentKeys) (*audit.TraceObject, error) {
 	runPath := filepath.Join(runsDir, runID)
 
 	// Check if run exists
 	if _, err := os.Stat(runPath); os.IsNotExist(err) {
-		return fmt.Errorf("trace not found: %s", runID)
+		return nil, fmt.Errorf("trace not found: %s", runID)
 	}
 
 	// Use the audit package to collect events
 	collector, err := audit.NewCollector(runPath)
 	if err != nil {
-		return fmt.Errorf("failed to create collector: %w", err)
+		return nil, fmt.Errorf("failed to create collector: %w", err)
+	}
+	collector.SetContentKeys(contentKeys)
+	if includeEvents {
+		if err := collector.LoadEvents(); err != nil {
+			return nil, err
+		}
 	}
 
 	traceObj, err := collector.Collect()
 	if err != nil {
-		return fmt.Errorf("failed to collect trace: %w", err)
+		return nil, fmt.Errorf("failed to collect trace: %w", err)
+	}
+	if count, example := collector.BadTimestamps(); count > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  %s: %d span(s) and event(s) have timestamps in an unrecognized format (e.g. %q), so they're left out of the run's time range; traces should use RFC 3339\n",
+			runID, count, example)
+	}
+	if dropped := collector.DroppedEvents(); dropped > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  %s: left out %d event(s) with no timestamp and no parent span to place them after\n", runID, dropped)
 	}
+	return traceObj, nil
+}
 
-	// Output as JSON
-	output, err := json.MarshalIndent(traceObj, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal trace object: %w", err)
+// writeAuditOutput writes rendered audit content to output, or stdout when
+// output is empty
+func writeAuditOutput(content, format, output string) error {
+	if output != "" {
+		if err := os.WriteFile(output, []byte(content), 0600); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		fmt.Printf("✅ Wrote %s audit: %s\n", format, output)
+	} else {
+		fmt.Println(content)
 	}
 
-	fmt.Println(string(output))
 	return nil
 }
 
-// generateMermaid creates a Mermaid flowchart from trace data
-func generateMermaid(runID, output string) error {
+// renderMermaidMarkdown renders a run's Mermaid flowchart in opts.Layout,
+// by default wrapped in a Markdown document
+func renderMermaidMarkdown(runID string, traceObj *audit.TraceObject, opts mermaidRenderOptions) string {
+	var heading strings.Builder
+	heading.WriteString(fmt.Sprintf("# Agent Trace: %s\n\n", runID))
+	heading.WriteString(fmt.Sprintf("**Events:** %d | **Duration:** %dms\n\n",
+		traceObj.Summary.TotalEvents, traceObj.Summary.TotalDurationMs))
+	heading.WriteString("## Execution Flow\n\n")
+	return layoutMermaid(audit.GenerateMermaidWithHierarchy(traceObj, opts.MermaidOptions), heading.String(), opts.Layout)
+}
+
+// renderAuditSummary renders a run's TraceSummary as plain text
+func renderAuditSummary(runID string, traceObj *audit.TraceObject) string {
+	summary := traceObj.Summary
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Run:          %s\n", runID))
+	if traceObj.Command != "" {
+		b.WriteString(fmt.Sprintf("Command:      %s\n", traceObj.Command))
+	}
+	b.WriteString(fmt.Sprintf("Duration:     %dms\n", summary.TotalDurationMs))
+	b.WriteString(fmt.Sprintf("Events:       %d (%d thoughts, %d tool calls, %d LLM calls)\n",
+		summary.TotalEvents, summary.ThoughtCount, summary.ToolCallCount, summary.LLMCallCount))
+	if summary.TokensUsed > 0 {
+		b.WriteString(fmt.Sprintf("Tokens:       %d (~$%.4f)\n", summary.TokensUsed, summary.EstimatedCost))
+	}
+	if summary.HasDetailedData {
+		b.WriteString("Content:      captured\n")
+	} else {
+		b.WriteString("Content:      not captured (run with AGK_TRACE_LEVEL=detailed)\n")
+	}
+	if traceObj.FinalOutput != "" {
+		b.WriteString(fmt.Sprintf("Final output: %s\n", truncateString(traceObj.FinalOutput, 200)))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// replayTrace re-sends a run's user prompts to a target and reports changed outputs
+func replayTrace(runID, targetURL string, timeoutSec int) error {
 	runsDir := runsDirName
 
 	// If no run ID provided, use latest
@@ -840,7 +1733,6 @@ func generateMermaid(runID, output string) error {
 		return fmt.Errorf("trace not found: %s", runID)
 	}
 
-	// Use the audit package to collect events
 	collector, err := audit.NewCollector(runPath)
 	if err != nil {
 		return fmt.Errorf("failed to create collector: %w", err)
@@ -851,26 +1743,68 @@ func generateMermaid(runID, output string) error {
 		return fmt.Errorf("failed to collect trace: %w", err)
 	}
 
-	// Generate Mermaid diagram
-	mermaid := audit.GenerateMermaidWithHierarchy(traceObj)
+	inputs := audit.ExtractReplayInputs(traceObj)
+	if len(inputs) == 0 {
+		fmt.Println("No user prompts found in trace. Re-run with AGK_TRACE_LEVEL=detailed to capture inputs.")
+		return nil
+	}
 
-	// Build output content
-	var content strings.Builder
-	content.WriteString(fmt.Sprintf("# Agent Trace: %s\n\n", runID))
-	content.WriteString(fmt.Sprintf("**Events:** %d | **Duration:** %dms\n\n",
-		traceObj.Summary.TotalEvents, traceObj.Summary.TotalDurationMs))
-	content.WriteString("## Execution Flow\n\n")
-	content.WriteString(mermaid)
+	target := eval.NewHTTPTarget(targetURL, time.Duration(timeoutSec)*time.Second)
+	if err := target.Health(); err != nil {
+		return fmt.Errorf("target health check failed: %w", err)
+	}
 
-	// Write to file or stdout
-	if output != "" {
-		if err := os.WriteFile(output, []byte(content.String()), 0600); err != nil {
-			return fmt.Errorf("failed to write file: %w", err)
+	fmt.Printf("\nReplaying %d input(s) from %s against %s\n", len(inputs), runID, targetURL)
+	fmt.Println(strings.Repeat("─", 60))
+
+	changed := 0
+	for i, input := range inputs {
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(inputs), truncateString(input.Prompt, 80))
+		for _, args := range input.ToolArguments {
+			fmt.Printf("  Original tool args: %s\n", truncateString(args, 80))
+		}
+
+		resp, err := target.Invoke(context.Background(), eval.Invocation{Input: input.Prompt, Timeout: timeoutSec})
+		if err != nil {
+			changed++
+			fmt.Printf("  ❌ Invocation failed: %v\n", err)
+			continue
+		}
+		if !resp.Success {
+			changed++
+			fmt.Printf("  ❌ Execution failed: %s\n", resp.Error)
+			continue
+		}
+
+		switch {
+		case input.OriginalResponse == "":
+			fmt.Printf("  ⚠️  No stored response to compare against\n")
+			fmt.Printf("  New:      %s\n", truncateString(resp.Output, 200))
+		case strings.TrimSpace(resp.Output) == strings.TrimSpace(input.OriginalResponse):
+			fmt.Printf("  ✅ Unchanged\n")
+		default:
+			changed++
+			fmt.Printf("  🔄 Changed\n")
+			fmt.Printf("  Original: %s\n", truncateString(input.OriginalResponse, 200))
+			fmt.Printf("  New:      %s\n", truncateString(resp.Output, 200))
+		}
+		if resp.TraceID != "" {
+			fmt.Printf("  Trace:    agk trace show %s\n", resp.TraceID)
 		}
-		fmt.Printf("✅ Generated Mermaid diagram: %s\n", output)
-	} else {
-		fmt.Println(content.String())
 	}
 
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("Replayed: %d  |  Changed or failed: %d\n\n", len(inputs), changed)
+
 	return nil
 }
+
+// truncateString shortens s to maxLen runes, flattening newlines
+func truncateString(s string, maxLen int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if runes := []rune(s); len(runes) > maxLen {
+		return string(runes[:maxLen-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agenticgokit/agk/internal/audit"
+	"github.com/agenticgokit/agk/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// aggregateCmd groups a run's spans by an attribute value
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate [run-id]",
+	Short: "Group a run's spans by an attribute and total each group",
+	Long: `Group the spans of a run by the value of an attribute and report, for each
+value, how many spans carry it, their total duration and the tokens they used.
+Spans without the attribute are grouped under (unset). A span nested inside
+another with the same value adds no duration, so a value's duration is the
+time its outermost spans cover.
+
+With --inherit, spans without the attribute count toward the nearest ancestor
+that has it, so the LLM calls an agent makes add to that agent's tokens.
+Durations then sum each span's self time, so nested spans aren't counted twice.
+
+Examples:
+  # Spans and tokens per model in the latest run
+  agk trace aggregate --by agk.llm.model
+
+  # Tokens per agent, including the agents' LLM calls
+  agk trace aggregate run-20260207-150034-71394771 --by agk.agent.name --inherit
+
+  # JSON for scripts
+  agk trace aggregate --by agk.llm.model --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runID := ""
+		if len(args) > 0 {
+			runID = args[0]
+		}
+		by, _ := cmd.Flags().GetString("by")
+		format, _ := cmd.Flags().GetString("format")
+		inherit, _ := cmd.Flags().GetBool("inherit")
+		return aggregateTrace(runID, by, format, inherit)
+	},
+}
+
+func init() {
+	traceCmd.AddCommand(aggregateCmd)
+	aggregateCmd.Flags().String("by", "", "Attribute key to group spans by, e.g. agk.llm.model (required)")
+	aggregateCmd.Flags().String("format", "table", "Output format: table, json")
+	aggregateCmd.Flags().Bool("inherit", false, "Count spans without the attribute toward their nearest ancestor that has it")
+	_ = aggregateCmd.MarkFlagRequired("by")
+}
+
+// unsetGroupValue names the group of spans that lack the attribute
+const unsetGroupValue = "(unset)"
+
+// spanGroup totals the spans sharing one attribute value
+type spanGroup struct {
+	Value      string `json:"value"`
+	Spans      int    `json:"spans"`
+	DurationMs int64  `json:"duration_ms"`
+	Tokens     int64  `json:"tokens"`
+}
+
+func aggregateTrace(runID, by, format string, inherit bool) error {
+	if format != "table" && format != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", format)
+	}
+
+	if runID == "" {
+		runID = getLatestRunID()
+		if runID == "" {
+			fmt.Println("No traces found. Run with AGK_TRACE=true to generate traces.")
+			return nil
+		}
+	}
+
+	runPath := filepath.Join(runsDirName, runID)
+	if _, err := os.Stat(runPath); os.IsNotExist(err) {
+		return fmt.Errorf("trace not found: %s", runID)
+	}
+
+	data, err := audit.ReadTraceFile(runPath)
+	if err != nil {
+		return fmt.Errorf("failed to read trace: %w", err)
+	}
+	spans, malformed := tui.ParseSpansWithReport(string(data))
+	warnMalformedLines(runID, malformed)
+	warnBadTimestamps(runID, spans)
+
+	groups := groupSpans(tui.BuildSpanTree(spans), by, inherit)
+
+	if format == "json" {
+		out, err := json.MarshalIndent(map[string]interface{}{
+			"run_id":  runID,
+			"by":      by,
+			"inherit": inherit,
+			"groups":  groups,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode groups: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("Spans by %s: %s\n", by, runID)
+	fmt.Println(strings.Repeat("─", 60))
+
+	width := len("Value")
+	for _, group := range groups {
+		width = max(width, utf8.RuneCountInString(group.Value))
+	}
+	width = min(width, 40)
+	fmt.Printf("%-*s %8s %12s %10s\n", width, "Value", "Spans", "Duration", "Tokens")
+	for _, group := range groups {
+		fmt.Printf("%-*s %8d %12s %10d\n", width, truncateString(group.Value, width),
+			group.Spans, fmt.Sprintf("%.2fs", float64(group.DurationMs)/1000), group.Tokens)
+	}
+	fmt.Println()
+	return nil
+}
+
+// groupSpans totals the spans of the tree per value of the attribute key,
+// ordered by span count. Durations sum the outermost span of each value, or
+// with inherit, where spans without the attribute take their nearest
+// ancestor's value, every span's self time.
+func groupSpans(roots []*tui.SpanNode, key string, inherit bool) []spanGroup {
+	byValue := make(map[string]*spanGroup)
+	open := make(map[string]int) // Values of the spans enclosing the walk
+
+	var walk func(nodes []*tui.SpanNode, inherited string)
+	walk = func(nodes []*tui.SpanNode, inherited string) {
+		for _, node := range nodes {
+			value := unsetGroupValue
+			if v, ok := node.Span.GetAttribute(key); ok {
+				value = fmt.Sprint(v)
+			} else if inherit {
+				value = inherited
+			}
+
+			group, ok := byValue[value]
+			if !ok {
+				group = &spanGroup{Value: value}
+				byValue[value] = group
+			}
+			group.Spans++
+			if inherit {
+				group.DurationMs += node.SelfTimeMs
+			} else if open[value] == 0 {
+				group.DurationMs += node.DurationMs
+			}
+			group.Tokens += spanTokens(node.Span)
+
+			open[value]++
+			walk(node.Children, value)
+			open[value]--
+		}
+	}
+	walk(roots, unsetGroupValue)
+
+	groups := make([]spanGroup, 0, len(byValue))
+	for _, group := range byValue {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		// Keep (unset) last so the attribute's values lead the table
+		if (groups[i].Value == unsetGroupValue) != (groups[j].Value == unsetGroupValue) {
+			return groups[j].Value == unsetGroupValue
+		}
+		if groups[i].Spans != groups[j].Spans {
+			return groups[i].Spans > groups[j].Spans
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
+
+// spanTokens returns the tokens a span used: its total token count, or the
+// sum of its prompt and completion tokens when no total was recorded
+func spanTokens(span tui.Span) int64 {
+	if v, ok := span.GetAttribute("llm.usage.total_tokens"); ok {
+		if total, err := toInt64(v); err == nil {
+			return total
+		}
+	}
+	var tokens int64
+	for _, key := range []string{"llm.usage.prompt_tokens", "llm.usage.completion_tokens"} {
+		if v, ok := span.GetAttribute(key); ok {
+			if n, err := toInt64(v); err == nil {
+				tokens += n
+			}
+		}
+	}
+	return tokens
+}
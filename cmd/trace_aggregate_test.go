@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agenticgokit/agk/internal/tui"
+)
+
+// aggregateSpan returns a span from startMs to endMs with the given
+// attributes
+func aggregateSpan(id, parent string, startMs, endMs int, attrs map[string]interface{}) tui.Span {
+	at := func(ms int) string {
+		return time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC).Add(time.Duration(ms) * time.Millisecond).Format(time.RFC3339Nano)
+	}
+	span := tui.Span{
+		Name:        id,
+		StartTime:   at(startMs),
+		EndTime:     at(endMs),
+		SpanContext: tui.SpanContext{SpanID: id},
+		Parent:      tui.ParentSpan{SpanID: parent},
+	}
+	for key, value := range attrs {
+		span.Attributes = append(span.Attributes, map[string]interface{}{
+			"Key": key, "Value": map[string]interface{}{"Value": value},
+		})
+	}
+	return span
+}
+
+func TestGroupSpans(t *testing.T) {
+	planner := map[string]interface{}{"agk.agent.name": "planner"}
+	roots := tui.BuildSpanTree([]tui.Span{
+		aggregateSpan("run", "", 0, 1000, planner),
+		aggregateSpan("llm-1", "run", 0, 400, map[string]interface{}{"llm.usage.total_tokens": float64(100)}),
+		// The planner hands off to itself, nested inside its first run
+		aggregateSpan("replan", "run", 500, 900, planner),
+		aggregateSpan("llm-2", "replan", 550, 850, map[string]interface{}{"llm.usage.total_tokens": float64(50)}),
+		aggregateSpan("write", "run", 900, 1000, map[string]interface{}{"agk.agent.name": "writer"}),
+	})
+
+	tests := []struct {
+		name    string
+		inherit bool
+		want    []spanGroup
+	}{
+		{
+			name: "outermost spans",
+			want: []spanGroup{
+				{Value: "planner", Spans: 2, DurationMs: 1000},
+				{Value: "writer", Spans: 1, DurationMs: 100},
+				{Value: unsetGroupValue, Spans: 2, DurationMs: 700, Tokens: 150},
+			},
+		},
+		{
+			name:    "inherit",
+			inherit: true,
+			want: []spanGroup{
+				{Value: "planner", Spans: 4, DurationMs: 900, Tokens: 150},
+				{Value: "writer", Spans: 1, DurationMs: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupSpans(roots, "agk.agent.name", tt.inherit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupSpans() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		maxLen int
+		want   string
+	}{
+		{name: "short", s: "planner", maxLen: 10, want: "planner"},
+		{name: "newlines", s: "one\ntwo", maxLen: 10, want: "one two"},
+		{name: "ascii", s: "researcher", maxLen: 6, want: "resea…"},
+		{name: "multibyte", s: "研究者のエージェント", maxLen: 4, want: "研究者…"},
+		{name: "exact", s: "日本語", maxLen: 3, want: "日本語"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateString(tt.s, tt.maxLen); got != tt.want {
+				t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agenticgokit/agk/internal/audit"
+	"github.com/agenticgokit/agk/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// lintCmd checks span attributes against the expected schema
+var lintCmd = &cobra.Command{
+	Use:   "lint [run-id]",
+	Short: "Check span attributes against the expected schema",
+	Long: `Check that spans carry the attributes the trace tools rely on and report spans
+that are missing expected attributes or use deprecated keys.
+
+LLM spans should record their model, provider and token usage; tool spans
+should record the tool name and arguments. The command exits with an error
+when any issue is found, so it can guard instrumentation changes in CI.
+
+Examples:
+  # Lint the latest run
+  agk trace lint
+
+  # Lint a specific run
+  agk trace lint run-20260207-150034-71394771`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runID := ""
+		if len(args) > 0 {
+			runID = args[0]
+		}
+		return lintTrace(runID)
+	},
+}
+
+func init() {
+	traceCmd.AddCommand(lintCmd)
+}
+
+// attributeRequirement is an attribute a span type is expected to carry.
+// Any one of Keys satisfies it.
+type attributeRequirement struct {
+	Label string
+	Keys  []string
+}
+
+// spanAttributeSchema lists the attributes expected per span type
+var spanAttributeSchema = map[string][]attributeRequirement{
+	"llm": {
+		{"model", []string{"agk.llm.model"}},
+		{"provider", []string{"agk.llm.provider"}},
+		{"token usage", []string{"llm.usage.total_tokens", "llm.usage.prompt_tokens", "llm.usage.completion_tokens"}},
+	},
+	"tool": {
+		{"name", []string{"agk.tool.name"}},
+		{"arguments", []string{"agk.tool.arguments"}},
+	},
+}
+
+// deprecatedAttributeKeys maps attribute keys that are no longer emitted to
+// their replacements
+var deprecatedAttributeKeys = map[string]string{
+	"llm.model":             "agk.llm.model",
+	"llm.provider":          "agk.llm.provider",
+	"llm.prompt_tokens":     "llm.usage.prompt_tokens",
+	"llm.completion_tokens": "llm.usage.completion_tokens",
+	"llm.total_tokens":      "llm.usage.total_tokens",
+	"tool.name":             "agk.tool.name",
+	"tool.arguments":        "agk.tool.arguments",
+}
+
+// lintIssue groups the spans affected by one schema problem
+type lintIssue struct {
+	Message string
+	Spans   []string
+}
+
+func lintTrace(runID string) error {
+	if runID == "" {
+		runID = getLatestRunID()
+		if runID == "" {
+			fmt.Println("No traces found. Run with AGK_TRACE=true to generate traces.")
+			return nil
+		}
+	}
+
+	runPath := filepath.Join(runsDirName, runID)
+	if _, err := os.Stat(runPath); os.IsNotExist(err) {
+		return fmt.Errorf("trace not found: %s", runID)
+	}
+
+	data, err := audit.ReadTraceFile(runPath)
+	if err != nil {
+		return fmt.Errorf("failed to read trace: %w", err)
+	}
+	spans, malformed := tui.ParseSpansWithReport(string(data))
+	warnMalformedLines(runID, malformed)
+	warnBadTimestamps(runID, spans)
+
+	issues, checked := lintSpans(spans)
+
+	fmt.Println()
+	fmt.Printf("Attribute lint: %s\n", runID)
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("Spans: %d (%d LLM, %d tool)\n", len(spans), checked["llm"], checked["tool"])
+	fmt.Println()
+
+	if len(issues) == 0 {
+		fmt.Println("✅ No attribute issues found")
+		return nil
+	}
+
+	const maxExamples = 3
+	total := 0
+	for _, issue := range issues {
+		total += len(issue.Spans)
+		examples := issue.Spans
+		more := ""
+		if len(examples) > maxExamples {
+			more = fmt.Sprintf(", +%d more", len(examples)-maxExamples)
+			examples = examples[:maxExamples]
+		}
+		fmt.Printf("%4d  %s\n", len(issue.Spans), issue.Message)
+		fmt.Printf("      %s%s\n", strings.Join(examples, ", "), more)
+	}
+	fmt.Printf("❌ %d attribute issue(s) of %d kind(s) found\n", total, len(issues))
+
+	// Exit with error code so CI can catch instrumentation regressions
+	os.Exit(1)
+	return nil
+}
+
+// lintSpans checks spans against spanAttributeSchema and deprecatedAttributeKeys.
+// It returns the issues, most frequent first, and the number of spans of
+// each type that have a schema.
+func lintSpans(spans []tui.Span) ([]lintIssue, map[string]int) {
+	byMessage := make(map[string]*lintIssue)
+	report := func(message string, span tui.Span) {
+		issue, ok := byMessage[message]
+		if !ok {
+			issue = &lintIssue{Message: message}
+			byMessage[message] = issue
+		}
+		issue.Spans = append(issue.Spans, fmt.Sprintf("%s (%s)", span.Name, span.SpanContext.SpanID))
+	}
+
+	checked := make(map[string]int)
+	for _, span := range spans {
+		attrs := span.GetAllAttributes()
+
+		for key := range attrs {
+			if replacement, ok := deprecatedAttributeKeys[key]; ok {
+				report(fmt.Sprintf("deprecated key %s (use %s)", key, replacement), span)
+			}
+		}
+
+		spanType := span.GetSpanType()
+		requirements, ok := spanAttributeSchema[spanType]
+		if !ok {
+			continue
+		}
+		checked[spanType]++
+		for _, req := range requirements {
+			if !hasAnyAttribute(attrs, req.Keys) {
+				report(fmt.Sprintf("%s span missing %s (%s)", spanType, req.Label, strings.Join(req.Keys, " or ")), span)
+			}
+		}
+	}
+
+	issues := make([]lintIssue, 0, len(byMessage))
+	for _, issue := range byMessage {
+		issues = append(issues, *issue)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if len(issues[i].Spans) != len(issues[j].Spans) {
+			return len(issues[i].Spans) > len(issues[j].Spans)
+		}
+		return issues[i].Message < issues[j].Message
+	})
+	return issues, checked
+}
+
+// hasAnyAttribute reports whether attrs has one of keys, counting a
+// deprecated spelling of a key as present since it is reported separately
+func hasAnyAttribute(attrs map[string]interface{}, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := attrs[key]; ok {
+			return true
+		}
+	}
+	for old, replacement := range deprecatedAttributeKeys {
+		if _, ok := attrs[old]; !ok {
+			continue
+		}
+		for _, key := range keys {
+			if replacement == key {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// metricsCmd exports run metrics in Prometheus text format
+var metricsCmd = &cobra.Command{
+	Use:   "metrics [run-id...]",
+	Short: "Export run metrics in Prometheus text format",
+	Long: `Export per-run metrics in the Prometheus text exposition format, suitable for
+the node_exporter textfile collector or a Pushgateway.
+
+Examples:
+  # Metrics for all runs
+  agk trace metrics
+
+  # Write to a textfile collector directory
+  agk trace metrics --output /var/lib/node_exporter/agk.prom`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		return exportRunMetrics(args, output)
+	},
+}
+
+func init() {
+	traceCmd.AddCommand(metricsCmd)
+	metricsCmd.Flags().String("output", "", "Output file (default: stdout)")
+}
+
+// runMetric describes one exported per-run metric
+type runMetric struct {
+	Name  string
+	Help  string
+	Type  string
+	Value func(TraceRun) float64
+}
+
+var runMetrics = []runMetric{
+	{"agk_run_duration_seconds", "Duration of the run in seconds.", "gauge",
+		func(r TraceRun) float64 { return r.Duration }},
+	{"agk_run_tokens_total", "Tokens used by the run.", "counter",
+		func(r TraceRun) float64 { return float64(r.TotalTokens) }},
+	{"agk_run_cost_estimate", "Estimated cost of the run in USD.", "gauge",
+		func(r TraceRun) float64 { return r.EstimatedCost }},
+	{"agk_run_llm_calls", "LLM calls made by the run.", "counter",
+		func(r TraceRun) float64 { return float64(r.LLMCalls) }},
+}
+
+// exportRunMetrics writes Prometheus metrics for the given runs (all runs if none)
+func exportRunMetrics(runIDs []string, output string) error {
+	if len(runIDs) == 0 {
+		entries, err := os.ReadDir(runsDirName)
+		if os.IsNotExist(err) {
+			fmt.Fprintln(os.Stderr, "No traces found. Run with AGK_TRACE=true to generate traces.")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read runs directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				runIDs = append(runIDs, entry.Name())
+			}
+		}
+	}
+
+	var runs []TraceRun
+	for _, runID := range runIDs {
+		runPath := filepath.Join(runsDirName, runID)
+		if _, err := os.Stat(runPath); os.IsNotExist(err) {
+			return fmt.Errorf("trace not found: %s", runID)
+		}
+		manifest, err := readManifest(runPath)
+		if err != nil {
+			continue // Skip runs without valid manifest
+		}
+		runs = append(runs, manifest)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].RunID < runs[j].RunID
+	})
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writePrometheusMetrics(w, runs); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if output != "" {
+		fmt.Printf("✅ Exported metrics for %d run(s) to %s\n", len(runs), output)
+	}
+	return nil
+}
+
+// writePrometheusMetrics renders runs in the Prometheus text exposition format
+func writePrometheusMetrics(w io.Writer, runs []TraceRun) error {
+	var b strings.Builder
+	for _, metric := range runMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n", metric.Name, metric.Help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", metric.Name, metric.Type)
+		for _, run := range runs {
+			fmt.Fprintf(&b, "%s{run_id=\"%s\",command=\"%s\"} %g\n",
+				metric.Name, escapeLabelValue(run.RunID), escapeLabelValue(run.Command), metric.Value(run))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabelValue escapes a Prometheus label value
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
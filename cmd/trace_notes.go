@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agenticgokit/agk/internal/tui"
+)
+
+// notesFileName holds the notes and tags attached to a run, next to its trace
+const notesFileName = "notes.json"
+
+// readRunNotes loads a run's notes. Runs without notes, or with an unreadable
+// notes file, have none.
+func readRunNotes(runPath string) tui.RunNotes {
+	var notes tui.RunNotes
+	data, err := os.ReadFile(filepath.Join(runPath, notesFileName))
+	if err != nil {
+		return notes
+	}
+	if err := json.Unmarshal(data, &notes); err != nil {
+		GetLogger().Debug().Err(err).Str("run", runPath).Msg("failed to parse run notes")
+		return tui.RunNotes{}
+	}
+	return notes
+}
+
+// saveRunNotes writes a run's notes, removing the file when they are empty
+func saveRunNotes(runID string, notes tui.RunNotes) error {
+	runPath := filepath.Join(runsDirName, runID)
+	if _, err := os.Stat(runPath); os.IsNotExist(err) {
+		return fmt.Errorf("trace not found: %s", runID)
+	}
+
+	notesPath := filepath.Join(runPath, notesFileName)
+	if notes.IsEmpty() {
+		if err := os.Remove(notesPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove notes: %w", err)
+		}
+		return nil
+	}
+
+	notes.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal notes: %w", err)
+	}
+	if err := os.WriteFile(notesPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write notes: %w", err)
+	}
+	return nil
+}
+
+// hasAllTags reports whether notes carry every tag in tags
+func hasAllTags(notes tui.RunNotes, tags []string) bool {
+	for _, tag := range tags {
+		if !notes.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/agenticgokit/agk/internal/utils"
+)
+
+// otlpExport is an OTLP/JSON ExportTraceServiceRequest
+type otlpExport struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope     otlpScope  `json:"scope"`
+	Spans     []otlpSpan `json:"spans"`
+	SchemaURL string     `json:"schemaUrl,omitempty"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	TraceState        string         `json:"traceState,omitempty"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Links             []otlpLink     `json:"links,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes"`
+}
+
+type otlpLink struct {
+	TraceID    string         `json:"traceId"`
+	SpanID     string         `json:"spanId"`
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"` // 0 unset, 1 ok, 2 error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue holds exactly one of its fields. 64-bit integers are strings
+// in OTLP/JSON.
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+// otlpNoParent is the parent span ID the stdout exporter writes for roots
+const otlpNoParent = "0000000000000000"
+
+// convertToOTLPFormat converts spans written by the OpenTelemetry stdout
+// exporter to OTLP/JSON. Spans are grouped by their resource and then by
+// instrumentation scope, so each library keeps its own scopeSpans entry.
+// Spans with a timestamp that doesn't parse are left out, since OTLP has no
+// way to mark a time as unknown; skipped says why for each.
+func convertToOTLPFormat(spans []map[string]interface{}) (export *otlpExport, skipped []error) {
+	export = &otlpExport{ResourceSpans: []*otlpResourceSpans{}}
+	resources := make(map[string]*otlpResourceSpans)
+	scopes := make(map[string]*otlpScopeSpans)
+
+	for _, span := range spans {
+		otlp, err := toOTLPSpan(span)
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+
+		resourceAttrs, _ := span["Resource"].([]interface{})
+		resourceKey := fmt.Sprint(resourceAttrs)
+		resource, ok := resources[resourceKey]
+		if !ok {
+			resource = &otlpResourceSpans{Resource: otlpResource{Attributes: otlpResourceAttributes(resourceAttrs)}}
+			resources[resourceKey] = resource
+			export.ResourceSpans = append(export.ResourceSpans, resource)
+		}
+
+		scope, schemaURL := otlpSpanScope(span)
+		scopeKey := resourceKey + "\x00" + scope.Name + "\x00" + scope.Version + "\x00" + schemaURL
+		scopeSpans, ok := scopes[scopeKey]
+		if !ok {
+			scopeSpans = &otlpScopeSpans{Scope: scope, Spans: []otlpSpan{}, SchemaURL: schemaURL}
+			scopes[scopeKey] = scopeSpans
+			resource.ScopeSpans = append(resource.ScopeSpans, scopeSpans)
+		}
+		scopeSpans.Spans = append(scopeSpans.Spans, otlp)
+	}
+
+	return export, skipped
+}
+
+// otlpResourceAttributes converts a span's resource, filling in the service
+// name and version when the application didn't set them
+func otlpResourceAttributes(attrs []interface{}) []otlpKeyValue {
+	keyValues := toOTLPAttributes(attrs)
+	defaults := []struct{ key, value string }{
+		{"service.name", "agenticgokit"},
+		{"service.version", Version},
+	}
+	for _, d := range defaults {
+		found := false
+		for _, kv := range keyValues {
+			if kv.Key == d.key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			value := d.value
+			keyValues = append(keyValues, otlpKeyValue{Key: d.key, Value: otlpAnyValue{StringValue: &value}})
+		}
+	}
+	return keyValues
+}
+
+// otlpSpanScope reads a span's instrumentation scope, falling back to the
+// InstrumentationLibrary field written by older SDKs
+func otlpSpanScope(span map[string]interface{}) (otlpScope, string) {
+	raw, ok := span["InstrumentationScope"].(map[string]interface{})
+	if !ok {
+		raw, _ = span["InstrumentationLibrary"].(map[string]interface{})
+	}
+	name, _ := raw["Name"].(string)
+	version, _ := raw["Version"].(string)
+	schemaURL, _ := raw["SchemaURL"].(string)
+	return otlpScope{Name: name, Version: version}, schemaURL
+}
+
+// toOTLPSpan converts one stdout-exporter span, failing when one of its
+// timestamps doesn't parse
+func toOTLPSpan(span map[string]interface{}) (otlpSpan, error) {
+	spanCtx, _ := span["SpanContext"].(map[string]interface{})
+	parent, _ := span["Parent"].(map[string]interface{})
+	status, _ := span["Status"].(map[string]interface{})
+	attrs, _ := span["Attributes"].([]interface{})
+
+	out := otlpSpan{
+		TraceID:    stringField(spanCtx, "TraceID"),
+		SpanID:     stringField(spanCtx, "SpanID"),
+		TraceState: stringField(spanCtx, "TraceState"),
+		Name:       stringField(span, "Name"),
+		Kind:       intField(span, "SpanKind"),
+		Attributes: toOTLPAttributes(attrs),
+		Status:     toOTLPStatus(status),
+	}
+	var err error
+	if out.StartTimeUnixNano, err = otlpTime(stringField(span, "StartTime")); err != nil {
+		return otlpSpan{}, fmt.Errorf("span %s: %w", out.SpanID, err)
+	}
+	if out.EndTimeUnixNano, err = otlpTime(stringField(span, "EndTime")); err != nil {
+		return otlpSpan{}, fmt.Errorf("span %s: %w", out.SpanID, err)
+	}
+	out.TraceState = stringField(spanCtx, "TraceState")
+	if parentID := stringField(parent, "SpanID"); parentID != otlpNoParent {
+		out.ParentSpanID = parentID
+	}
+
+	events, _ := span["Events"].([]interface{})
+	for _, e := range events {
+		event, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		eventAttrs, _ := event["Attributes"].([]interface{})
+		eventTime, err := otlpTime(stringField(event, "Time"))
+		if err != nil {
+			return otlpSpan{}, fmt.Errorf("span %s event %s: %w", out.SpanID, stringField(event, "Name"), err)
+		}
+		out.Events = append(out.Events, otlpEvent{
+			TimeUnixNano: eventTime,
+			Name:         stringField(event, "Name"),
+			Attributes:   toOTLPAttributes(eventAttrs),
+		})
+	}
+
+	links, _ := span["Links"].([]interface{})
+	for _, l := range links {
+		link, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		linkCtx, _ := link["SpanContext"].(map[string]interface{})
+		linkAttrs, _ := link["Attributes"].([]interface{})
+		out.Links = append(out.Links, otlpLink{
+			TraceID:    stringField(linkCtx, "TraceID"),
+			SpanID:     stringField(linkCtx, "SpanID"),
+			Attributes: toOTLPAttributes(linkAttrs),
+		})
+	}
+
+	return out, nil
+}
+
+// toOTLPStatus maps the SDK's status code names to OTLP status codes
+func toOTLPStatus(status map[string]interface{}) otlpStatus {
+	out := otlpStatus{Message: stringField(status, "Description")}
+	switch stringField(status, "Code") {
+	case "Ok":
+		out.Code = 1
+	case "Error":
+		out.Code = 2
+	}
+	return out
+}
+
+// toOTLPAttributes converts attributes in the SDK's {Key, Value: {Type, Value}}
+// form to OTLP key/values
+func toOTLPAttributes(attrs []interface{}) []otlpKeyValue {
+	keyValues := make([]otlpKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		attr, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := stringField(attr, "Key")
+		if key == "" {
+			continue
+		}
+		typed, _ := attr["Value"].(map[string]interface{})
+		keyValues = append(keyValues, otlpKeyValue{
+			Key:   key,
+			Value: toOTLPValue(stringField(typed, "Type"), typed["Value"]),
+		})
+	}
+	return keyValues
+}
+
+// toOTLPValue converts a value of one of the SDK's attribute types
+func toOTLPValue(valueType string, value interface{}) otlpAnyValue {
+	switch valueType {
+	case "BOOL":
+		if b, ok := value.(bool); ok {
+			return otlpAnyValue{BoolValue: &b}
+		}
+	case "INT64":
+		// Spans are decoded with json.Number, so large values keep every digit
+		if n, ok := value.(json.Number); ok {
+			if _, err := n.Int64(); err == nil {
+				s := n.String()
+				return otlpAnyValue{IntValue: &s}
+			}
+		}
+	case "FLOAT64":
+		if n, ok := value.(json.Number); ok {
+			if f, err := n.Float64(); err == nil {
+				return otlpAnyValue{DoubleValue: &f}
+			}
+		}
+	case "BOOLSLICE", "INT64SLICE", "FLOAT64SLICE", "STRINGSLICE":
+		items, _ := value.([]interface{})
+		elemType := valueType[:len(valueType)-len("SLICE")]
+		array := &otlpArrayValue{Values: make([]otlpAnyValue, 0, len(items))}
+		for _, item := range items {
+			array.Values = append(array.Values, toOTLPValue(elemType, item))
+		}
+		return otlpAnyValue{ArrayValue: array}
+	}
+
+	// STRING, and anything unexpected, is sent as a string
+	s, ok := value.(string)
+	if !ok {
+		data, _ := json.Marshal(value)
+		s = string(data)
+	}
+	return otlpAnyValue{StringValue: &s}
+}
+
+// otlpTime converts a span timestamp to Unix nanoseconds as a string
+func otlpTime(value string) (string, error) {
+	t, err := utils.ParseTimestamp(value)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(t.UnixNano(), 10), nil
+}
+
+// stringField reads a string field of a decoded JSON object
+func stringField(obj map[string]interface{}, key string) string {
+	s, _ := obj[key].(string)
+	return s
+}
+
+// intField reads a numeric field of a span decoded by decodeRawSpan
+func intField(obj map[string]interface{}, key string) int {
+	n, _ := obj[key].(json.Number)
+	i, _ := n.Int64()
+	return int(i)
+}
+
+// decodeRawSpan decodes one trace.jsonl line, keeping numbers as
+// json.Number so 64-bit attribute values survive conversion
+func decodeRawSpan(line []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var span map[string]interface{}
+	if err := decoder.Decode(&span); err != nil {
+		return nil, err
+	}
+	return span, nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestConvertToOTLPFormat(t *testing.T) {
+	good, err := decodeRawSpan([]byte(`{"Name":"llm.call","SpanContext":{"SpanID":"aaaaaaaaaaaaaaaa"},"SpanKind":3,` +
+		`"StartTime":"2026-01-01T10:00:00Z","EndTime":"2026-01-01T10:00:01Z",` +
+		`"Attributes":[{"Key":"big","Value":{"Type":"INT64","Value":9007199254740993}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad, err := decodeRawSpan([]byte(`{"Name":"bad","SpanContext":{"SpanID":"bbbbbbbbbbbbbbbb"},"StartTime":"yesterday","EndTime":"today"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export, skipped := convertToOTLPFormat([]map[string]interface{}{good, bad})
+	if len(skipped) != 1 {
+		t.Fatalf("skipped = %v, want the span with unparseable timestamps", skipped)
+	}
+	spans := export.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Kind != 3 || span.StartTimeUnixNano != "1767261600000000000" {
+		t.Errorf("kind = %d, start = %s; want 3, 1767261600000000000", span.Kind, span.StartTimeUnixNano)
+	}
+	if got := span.Attributes[0].Value.IntValue; got == nil || *got != "9007199254740993" {
+		t.Errorf("INT64 attribute = %v, want 9007199254740993 with every digit", got)
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agenticgokit/agk/internal/utils"
+)
+
+// speedscopeSchema identifies the speedscope file format
+const speedscopeSchema = "https://www.speedscope.app/file-format-schema.json"
+
+// speedscopeFile is a speedscope profile document
+type speedscopeFile struct {
+	Schema             string               `json:"$schema"`
+	Name               string               `json:"name"`
+	Exporter           string               `json:"exporter"`
+	ActiveProfileIndex int                  `json:"activeProfileIndex"`
+	Shared             speedscopeShared     `json:"shared"`
+	Profiles           []*speedscopeProfile `json:"profiles"`
+}
+
+type speedscopeShared struct {
+	Frames []speedscopeFrame `json:"frames"`
+}
+
+type speedscopeFrame struct {
+	Name string `json:"name"`
+}
+
+// speedscopeProfile is an "evented" profile: frames opened and closed in time order
+type speedscopeProfile struct {
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Unit       string            `json:"unit"`
+	StartValue float64           `json:"startValue"`
+	EndValue   float64           `json:"endValue"`
+	Events     []speedscopeEvent `json:"events"`
+}
+
+type speedscopeEvent struct {
+	Type  string  `json:"type"` // "O" opens a frame, "C" closes it
+	Frame int     `json:"frame"`
+	At    float64 `json:"at"`
+}
+
+// speedscopeSpan is a span reduced to what the profile needs
+type speedscopeSpan struct {
+	name       string
+	start, end time.Time
+	children   []*speedscopeSpan
+}
+
+// speedscopeBuilder collects frames and profiles while walking the span tree
+type speedscopeBuilder struct {
+	origin     time.Time
+	frames     []speedscopeFrame
+	frameIndex map[string]int
+	profiles   []*speedscopeProfile
+}
+
+// convertToSpeedscopeFormat converts spans to speedscope's evented profile
+// format. Each root span becomes a profile with nested spans as nested
+// frames. Evented profiles are a single stack, so a span that overlaps an
+// earlier sibling (a parallel step) gets a profile of its own.
+func convertToSpeedscopeFormat(spans []map[string]interface{}, runID string) *speedscopeFile {
+	roots, origin := buildSpeedscopeTree(spans)
+
+	b := &speedscopeBuilder{
+		origin:     origin,
+		frames:     []speedscopeFrame{},
+		frameIndex: make(map[string]int),
+		profiles:   []*speedscopeProfile{},
+	}
+	for _, root := range roots {
+		b.addProfile(root.name, root)
+	}
+
+	return &speedscopeFile{
+		Schema:   speedscopeSchema,
+		Name:     runID,
+		Exporter: "agk",
+		Shared:   speedscopeShared{Frames: b.frames},
+		Profiles: b.profiles,
+	}
+}
+
+// buildSpeedscopeTree links spans to their parents and returns the roots,
+// ordered by start time, along with the earliest start time
+func buildSpeedscopeTree(spans []map[string]interface{}) ([]*speedscopeSpan, time.Time) {
+	nodes := make(map[string]*speedscopeSpan, len(spans))
+	parents := make(map[string]string, len(spans))
+	var order []string
+	var origin time.Time
+
+	for _, span := range spans {
+		spanCtx, _ := span["SpanContext"].(map[string]interface{})
+		id, _ := spanCtx["SpanID"].(string)
+		if id == "" {
+			continue
+		}
+		name, _ := span["Name"].(string)
+		startStr, _ := span["StartTime"].(string)
+		endStr, _ := span["EndTime"].(string)
+		start, err := utils.ParseTimestamp(startStr)
+		if err != nil {
+			continue
+		}
+		end, err := utils.ParseTimestamp(endStr)
+		if err != nil || end.Before(start) {
+			end = start
+		}
+
+		nodes[id] = &speedscopeSpan{name: name, start: start, end: end}
+		parent, _ := span["Parent"].(map[string]interface{})
+		parents[id], _ = parent["SpanID"].(string)
+		order = append(order, id)
+		if origin.IsZero() || start.Before(origin) {
+			origin = start
+		}
+	}
+
+	var roots []*speedscopeSpan
+	for _, id := range order {
+		node := nodes[id]
+		if parent, ok := nodes[parents[id]]; ok && parents[id] != id {
+			parent.children = append(parent.children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, node := range nodes {
+		sortSpeedscopeSpans(node.children)
+	}
+	sortSpeedscopeSpans(roots)
+	return roots, origin
+}
+
+func sortSpeedscopeSpans(spans []*speedscopeSpan) {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+}
+
+// addProfile emits a profile rooted at span
+func (b *speedscopeBuilder) addProfile(name string, span *speedscopeSpan) {
+	profile := &speedscopeProfile{Type: "evented", Name: name, Unit: "milliseconds"}
+	b.profiles = append(b.profiles, profile)
+
+	b.walk(profile, span, span.start, span.end)
+	profile.StartValue = profile.Events[0].At
+	profile.EndValue = profile.Events[len(profile.Events)-1].At
+}
+
+// walk opens span's frame, emits its children and closes it. Times are
+// clamped to the parent's bounds so clock skew can't break the nesting.
+func (b *speedscopeBuilder) walk(profile *speedscopeProfile, span *speedscopeSpan, minStart, maxEnd time.Time) {
+	start, end := span.start, span.end
+	if start.Before(minStart) {
+		start = minStart
+	}
+	if start.After(maxEnd) {
+		start = maxEnd
+	}
+	if end.After(maxEnd) {
+		end = maxEnd
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	frame := b.frame(span.name)
+	profile.Events = append(profile.Events, speedscopeEvent{Type: "O", Frame: frame, At: b.offset(start)})
+
+	cursor := start
+	var prevEnd time.Time // End of the previous child on this stack
+	for _, child := range span.children {
+		if !prevEnd.IsZero() && child.start.Before(prevEnd) {
+			// Overlaps the previous sibling, so it can't share this stack
+			b.addProfile(fmt.Sprintf("%s (parallel)", child.name), child)
+			continue
+		}
+		b.walk(profile, child, cursor, end)
+		prevEnd = child.end
+		if prevEnd.After(cursor) {
+			cursor = prevEnd
+		}
+	}
+
+	profile.Events = append(profile.Events, speedscopeEvent{Type: "C", Frame: frame, At: b.offset(end)})
+}
+
+// frame returns the index of the shared frame for name, adding it if needed
+func (b *speedscopeBuilder) frame(name string) int {
+	if i, ok := b.frameIndex[name]; ok {
+		return i
+	}
+	b.frames = append(b.frames, speedscopeFrame{Name: name})
+	b.frameIndex[name] = len(b.frames) - 1
+	return len(b.frames) - 1
+}
+
+// offset converts t to milliseconds since the first span started
+func (b *speedscopeBuilder) offset(t time.Time) float64 {
+	return float64(t.Sub(b.origin).Microseconds()) / 1000
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agenticgokit/agk/internal/audit"
+	"github.com/agenticgokit/agk/internal/tui"
+	"github.com/agenticgokit/agk/internal/utils"
+)
+
+// tailCmd prints spans as one-line summaries, optionally following the trace
+var tailCmd = &cobra.Command{
+	Use:   "tail [run-id]",
+	Short: "Print trace spans as log lines",
+	Long: `Print each span of a trace as a one-line summary:
+
+  [15:04:05.000] span-name (123ms) OK
+
+With --follow, keep watching trace.jsonl and print spans as they are written.
+
+Examples:
+  # Follow the latest run
+  agk trace tail -f
+
+  # Only LLM calls of a specific run
+  agk trace tail run-20250101-120000 --level llm
+
+  # Only failed spans, piped to other tools
+  agk trace tail -f --level error | grep tool`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runID := ""
+		if len(args) > 0 {
+			runID = args[0]
+		}
+		follow, _ := cmd.Flags().GetBool("follow")
+		level, _ := cmd.Flags().GetString("level")
+		return tailTrace(cmd.Context(), runID, follow, level)
+	},
+}
+
+// tailLevels are the accepted --level values
+var tailLevels = []string{"all", "workflow", "agent", "llm", "tool", "error"}
+
+func init() {
+	traceCmd.AddCommand(tailCmd)
+	tailCmd.Flags().BoolP("follow", "f", false, "Keep printing new spans as they are written")
+	tailCmd.Flags().String("level", "all", "Only print spans of this kind: "+strings.Join(tailLevels, ", "))
+}
+
+// tailTrace prints a run's spans and, with follow, polls for new ones until interrupted
+func tailTrace(ctx context.Context, runID string, follow bool, level string) error {
+	valid := false
+	for _, l := range tailLevels {
+		valid = valid || l == level
+	}
+	if !valid {
+		return fmt.Errorf("unknown level: %s (supported: %s)", level, strings.Join(tailLevels, ", "))
+	}
+	if err := loadSpanNameRules(); err != nil {
+		return err
+	}
+
+	if runID == "" {
+		runID = getLatestRunID()
+		if runID == "" {
+			fmt.Println("No traces found. Run with AGK_TRACE=true to generate traces.")
+			return nil
+		}
+	}
+
+	runPath := filepath.Join(runsDirName, runID)
+	if _, err := os.Stat(runPath); os.IsNotExist(err) {
+		return fmt.Errorf("trace not found: %s", runID)
+	}
+	tracePath := audit.TracePath(runPath)
+
+	// Compressed traces are complete; print them once instead of following
+	if audit.IsCompressedTrace(tracePath) {
+		data, err := audit.ReadTraceData(tracePath)
+		if err != nil {
+			return fmt.Errorf("failed to read trace: %w", err)
+		}
+		for _, span := range tui.ParseSpans(string(data)) {
+			if spanMatchesLevel(span, level) {
+				fmt.Println(formatSpanLine(span))
+			}
+		}
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	var offset int64
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		var spans []tui.Span
+		spans, offset = tui.ReadNewSpans(tracePath, offset)
+		for _, span := range spans {
+			if spanMatchesLevel(span, level) {
+				fmt.Println(formatSpanLine(span))
+			}
+		}
+
+		if !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// spanMatchesLevel reports whether a span passes the --level filter
+func spanMatchesLevel(span tui.Span, level string) bool {
+	switch level {
+	case "all":
+		return true
+	case "error":
+		return spanFailed(span)
+	default:
+		return span.GetSpanType() == level
+	}
+}
+
+// spanFailed reports whether a span ended with an error status
+func spanFailed(span tui.Span) bool {
+	code := span.Status.Code
+	return code != "" && code != tui.StatusUnset && code != "Ok"
+}
+
+// formatSpanLine renders "[time] name (duration) status" for a span
+func formatSpanLine(span tui.Span) string {
+	timestamp := span.StartTime
+	duration := "?"
+	if start, err := utils.ParseTimestamp(span.StartTime); err == nil {
+		timestamp = start.Local().Format("15:04:05.000")
+		if end, err := utils.ParseTimestamp(span.EndTime); err == nil {
+			duration = fmt.Sprintf("%dms", end.Sub(start).Milliseconds())
+		}
+	}
+
+	status := "OK"
+	if spanFailed(span) {
+		status = "ERROR"
+		if span.Status.Description != "" {
+			status += ": " + span.Status.Description
+		}
+	}
+
+	return fmt.Sprintf("[%s] %s (%s) %s", timestamp, span.GetFriendlyName(), duration, status)
+}
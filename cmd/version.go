@@ -19,7 +19,10 @@ var (
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
-	Long:  `Display version, build, and runtime information for AGK.`,
+	Long: `Display version, build, and runtime information for AGK.
+
+Use --check to look up the latest release on GitHub and see whether an
+update is available.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("AGK Developer CLI\n")
 		fmt.Printf("Version:     %s\n", Version)
@@ -27,9 +30,14 @@ var versionCmd = &cobra.Command{
 		fmt.Printf("Build Date:  %s\n", BuildDate)
 		fmt.Printf("Go Version:  %s\n", GoVersion)
 		fmt.Printf("OS/Arch:     %s/%s\n", runtime.GOOS, runtime.GOARCH)
+
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			printUpdateCheck(cmd.Context())
+		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().Bool("check", false, "Check GitHub for a newer release")
 }
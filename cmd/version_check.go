@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// latestReleaseURL is the GitHub API endpoint for the newest agk release
+	latestReleaseURL = "https://api.github.com/repos/agenticgokit/agk/releases/latest"
+	// upgradeCommand installs the newest release
+	upgradeCommand = "go install github.com/agenticgokit/agk@latest"
+	// releaseCheckTimeout keeps an offline check from holding up the command
+	releaseCheckTimeout = 5 * time.Second
+)
+
+// latestRelease is the part of the GitHub release response the check uses
+type latestRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// fetchLatestRelease asks the GitHub releases API for the newest release
+func fetchLatestRelease(ctx context.Context, url string) (*latestRelease, error) {
+	ctx, cancel := context.WithTimeout(ctx, releaseCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "agk/"+Version)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub returned status: %s", resp.Status)
+	}
+
+	var release latestRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to decode release: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("release has no tag")
+	}
+	return &release, nil
+}
+
+// printUpdateCheck reports whether a newer release than the running binary
+// exists. Network problems only produce a short note, since the check is a
+// convenience and must work offline.
+func printUpdateCheck(ctx context.Context) {
+	fmt.Println()
+	release, err := fetchLatestRelease(ctx, latestReleaseURL)
+	if err != nil {
+		GetLogger().Debug().Err(err).Msg("update check failed")
+		fmt.Println("Could not check for updates (offline or GitHub unavailable).")
+		return
+	}
+
+	newer, ok := isNewerVersion(release.TagName, Version)
+	switch {
+	case !ok:
+		fmt.Printf("Latest release: %s (this is a %s build)\n", release.TagName, Version)
+		fmt.Printf("Install it with: %s\n", upgradeCommand)
+	case newer:
+		fmt.Printf("⬆ Update available: %s → %s\n", Version, release.TagName)
+		fmt.Printf("  Upgrade:       %s\n", upgradeCommand)
+		if release.HTMLURL != "" {
+			fmt.Printf("  Release notes: %s\n", release.HTMLURL)
+		}
+	default:
+		fmt.Printf("✓ agk is up to date (%s)\n", Version)
+	}
+}
+
+// isNewerVersion reports whether latest is a higher version than current.
+// ok is false when either isn't a semantic version, e.g. "dev" builds.
+func isNewerVersion(latest, current string) (newer, ok bool) {
+	l, lok := parseVersion(latest)
+	c, cok := parseVersion(current)
+	if !lok || !cok {
+		return false, false
+	}
+	for i := range l.numbers {
+		if l.numbers[i] != c.numbers[i] {
+			return l.numbers[i] > c.numbers[i], true
+		}
+	}
+	// A release outranks its own pre-releases (1.2.0 > 1.2.0-rc.1)
+	if (l.pre == "") != (c.pre == "") {
+		return l.pre == "", true
+	}
+	return comparePrerelease(l.pre, c.pre) > 0, true
+}
+
+// comparePrerelease orders pre-release tags by semantic versioning rules:
+// dot-separated identifiers compare in turn, numeric ones numerically and
+// below alphanumeric ones, so rc.2 < rc.10 and a longer tag wins a tie
+// (rc < rc.1). It returns -1, 0 or +1.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// semanticVersion is a parsed MAJOR.MINOR.PATCH[-PRERELEASE] version
+type semanticVersion struct {
+	numbers [3]int
+	pre     string
+}
+
+// parseVersion parses versions like "v1.2.3", "1.2" or "1.2.3-rc.1+build"
+func parseVersion(s string) (semanticVersion, bool) {
+	var v semanticVersion
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
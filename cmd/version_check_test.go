@@ -0,0 +1,33 @@
+package cmd
+
+import "testing"
+
+func TestIsNewerVersion(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		wantNewer       bool
+		wantOK          bool
+	}{
+		{"v1.3.0", "v1.2.9", true, true},
+		{"v1.2.0", "v1.10.0", false, true},
+		{"v1.2", "1.2.0", false, true},
+		{"v1.2.0", "v1.2.0-rc.1", true, true},
+		{"v1.2.0-rc.1", "v1.2.0", false, true},
+		{"v1.2.0-rc.10", "v1.2.0-rc.2", true, true},
+		{"v1.2.0-rc.2", "v1.2.0-rc.10", false, true},
+		{"v1.2.0-rc.1", "v1.2.0-rc", true, true},
+		{"v1.2.0-rc", "v1.2.0-beta.5", true, true},
+		{"v1.2.0-beta", "v1.2.0-1", true, true},
+		{"v1.2.0-x.-1", "v1.2.0-x.5", true, true},
+		{"v1.2.0-rc.1+build.5", "v1.2.0-rc.1", false, true},
+		{"v1.2.0", "dev", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.latest+" vs "+tt.current, func(t *testing.T) {
+			newer, ok := isNewerVersion(tt.latest, tt.current)
+			if newer != tt.wantNewer || ok != tt.wantOK {
+				t.Errorf("isNewerVersion(%q, %q) = %t, %t; want %t, %t", tt.latest, tt.current, newer, ok, tt.wantNewer, tt.wantOK)
+			}
+		})
+	}
+}
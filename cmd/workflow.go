@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/agenticgokit/agenticgokit/observability"
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+	"github.com/agenticgokit/agk/internal/config"
+	"github.com/agenticgokit/agk/internal/utils"
+	"github.com/agenticgokit/agk/internal/workflow"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Run multi-agent workflows",
+	Long: `Run workflows defined in YAML or TOML files.
+
+A definition uses the keys of AgenticGoKit's [workflow] table; TOML files
+put them under [workflow]:
+
+  name: research
+  mode: sequential                # sequential, parallel, dag, loop
+  llm:                            # optional; defaults to agk.toml's [llm]
+    provider: openai
+    model: gpt-4o-mini
+    http_timeout: 60s
+  agent_defs:
+    - name: researcher
+      system_prompt: Research the topic thoroughly.
+    - name: writer
+      system_prompt: Turn the research into a short report.
+  step_defs:
+    - name: research
+      agent: researcher
+    - name: write
+      agent: writer
+      depends_on: [research]      # dag mode only
+
+TOML files are built by AgenticGoKit exactly as its LoadWorkflowFromTOML
+builds them. AgenticGoKit reads API keys from the provider's environment
+variable (e.g. OPENAI_API_KEY), not from the definition or agk.toml.
+'agk init --template workflow' scaffolds workflow/main.yaml.`,
+}
+
+var workflowRunCmd = &cobra.Command{
+	Use:   "run [input...]",
+	Short: "Run a workflow, streaming each step's output",
+	Long: `Run a workflow and stream each step's progress and output as it arrives.
+
+The workflow is --workflow, or agk.toml's [workflow] default_workflow. The
+input is the arguments, or stdin when it is piped. Exits with status 1
+when a step fails. With --trace or
+AGK_TRACE=true the run is recorded under .agk/runs like any other traced
+command, so 'agk trace show' works on it.
+
+Examples:
+  agk workflow run "The history of the transistor"
+  agk workflow run --workflow workflow/research.yaml "Quantum error correction"
+  echo "Solar sails" | AGK_TRACE=true agk workflow run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, cfg, err := resolveWorkflowPath(cmd)
+		if err != nil {
+			return err
+		}
+		def, err := workflow.Load(path)
+		if err != nil {
+			return err
+		}
+
+		input := strings.Join(args, " ")
+		if input == "" && !isInteractiveStdin() {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+			input = strings.TrimSpace(string(data))
+		}
+		if input == "" {
+			return fmt.Errorf("no input; pass it as arguments or pipe it on stdin")
+		}
+
+		var fallback agk.LLMConfig
+		if cfg != nil {
+			fallback = agk.LLMConfig{
+				Provider: cfg.LLMProvider,
+				Model:    cfg.Model,
+				BaseURL:  os.ExpandEnv(cfg.LLMBaseURL),
+			}
+			if cfg.LLMTimeout != "" {
+				if fallback.HTTPTimeout, err = time.ParseDuration(cfg.LLMTimeout); err != nil {
+					return fmt.Errorf("invalid [llm] timeout %q: %w", cfg.LLMTimeout, err)
+				}
+			}
+		}
+		if trace {
+			for key, value := range frameworkTraceEnv() {
+				_ = os.Setenv(key, value)
+			}
+		}
+		wf, err := def.Build(fallback)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := runWorkflow(ctx, wf, def, path, input); err != nil {
+			color.Red("\n✗ %v", err)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true // Printed above
+			return withExitCode(1, err)
+		}
+		return nil
+	},
+}
+
+// frameworkTraceEnv returns the settings that make AgenticGoKit, which
+// sets up its own tracer for a workflow and reads its settings only from
+// the environment, record into the command's run: the same run ID,
+// exporter, destination and sample rate. The console exporter isn't passed
+// on, so the framework's default file exporter records the run under
+// .agk/runs while the command's spans still print.
+func frameworkTraceEnv() map[string]string {
+	env := map[string]string{
+		"AGK_TRACE":        "true",
+		"AGK_RUN_ID":       traceRunID,
+		"AGK_TRACE_SAMPLE": strconv.FormatFloat(traceSample, 'f', -1, 64),
+	}
+	switch traceExporter {
+	case "console":
+	case "file":
+		env["AGK_TRACE_EXPORTER"] = "file"
+		env["AGK_TRACE_FILEPATH"] = traceFilePath
+	default:
+		env["AGK_TRACE_EXPORTER"] = traceExporter
+		env["AGK_TRACE_ENDPOINT"] = traceEndpoint
+	}
+	return env
+}
+
+// resolveWorkflowPath returns --workflow, or the project's default workflow,
+// along with the project configuration when there is one
+func resolveWorkflowPath(cmd *cobra.Command) (string, *config.ProjectConfig, error) {
+	path, _ := cmd.Flags().GetString("workflow")
+	projectDir, _ := cmd.Flags().GetString("project")
+
+	var cfg *config.ProjectConfig
+	configPath := filepath.Join(projectDir, "agk.toml")
+	if utils.FileExists(configPath) {
+		var err error
+		if cfg, err = config.LoadConfig(configPath); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if path == "" {
+		if cfg == nil || cfg.DefaultWorkflow == "" {
+			return "", nil, fmt.Errorf("no workflow to run; pass --workflow or set [workflow] default_workflow in agk.toml")
+		}
+		path = filepath.Join(projectDir, cfg.DefaultWorkflow)
+	}
+	if !utils.FileExists(path) {
+		return "", nil, fmt.Errorf("workflow %s not found", path)
+	}
+	return path, cfg, nil
+}
+
+// runWorkflow runs the workflow, printing step progress and output as they
+// stream in. It fails when the workflow or any of its steps does.
+func runWorkflow(ctx context.Context, wf agk.Workflow, def *workflow.Definition, path, input string) error {
+	tracer := observability.GetTracer("agk-cli")
+	ctx, span := tracer.Start(ctx, "agk.workflow.run")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("workflow", def.Config.Name),
+		attribute.String("workflow_file", path),
+		attribute.String("mode", string(def.Config.Mode)),
+		attribute.Int("steps", len(def.Config.StepDefs)),
+	)
+
+	fail := func(err error, status string) error {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, status)
+		return err
+	}
+
+	if err := wf.Initialize(ctx); err != nil {
+		return fail(fmt.Errorf("failed to initialize workflow: %w", err), "initialize failed")
+	}
+	defer func() { _ = wf.Shutdown(context.Background()) }()
+
+	if !quiet {
+		color.Cyan("Running workflow %s (%s, %d steps)", def.Config.Name, def.Config.Mode, len(def.Config.StepDefs))
+	}
+
+	stream, err := wf.RunStream(ctx, input)
+	if err != nil {
+		return fail(fmt.Errorf("failed to start workflow: %w", err), "start failed")
+	}
+
+	printer := &workflowPrinter{streamed: make(map[string]bool), failed: make(map[string]bool)}
+	for chunk := range stream.Chunks() {
+		printer.print(chunk)
+	}
+	printer.endLine()
+
+	result, err := stream.Wait()
+	if err != nil {
+		return fail(fmt.Errorf("workflow %s failed: %w", def.Config.Name, err), "workflow failed")
+	}
+	// A step whose agent streams an error still completes
+	if len(printer.failed) > 0 {
+		return fail(fmt.Errorf("workflow %s failed: %d of %d steps reported errors", def.Config.Name, len(printer.failed), len(def.Config.StepDefs)), "step errors")
+	}
+	span.SetStatus(codes.Ok, "workflow completed")
+
+	if !quiet {
+		duration := printer.elapsed()
+		if result != nil && result.Duration > 0 {
+			duration = result.Duration
+		}
+		color.Green("\n✓ Workflow %s completed in %s", def.Config.Name, duration.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// workflowPrinter writes workflow stream chunks to the terminal
+type workflowPrinter struct {
+	start    time.Time
+	step     string          // Step whose output was printed last
+	streamed map[string]bool // Steps that sent deltas, whose text chunks repeat them
+	failed   map[string]bool // Steps that sent errors
+	midLine  bool            // Output so far doesn't end in a newline
+}
+
+// print writes one chunk: step starts and completions as status lines,
+// text as it arrives. Parallel steps' output is interleaved, so a step's
+// name is repeated whenever the output switches to it.
+func (p *workflowPrinter) print(chunk *agk.StreamChunk) {
+	if p.start.IsZero() {
+		p.start = time.Now()
+	}
+	step, _ := chunk.Metadata["step_name"].(string)
+
+	switch chunk.Type {
+	case agk.ChunkTypeAgentStart:
+		p.endLine()
+		color.Cyan("\n▶ %s", step)
+		p.step = step
+	case agk.ChunkTypeAgentComplete:
+		p.endLine()
+		seconds, _ := chunk.Metadata["duration"].(float64)
+		if ok, _ := chunk.Metadata["success"].(bool); ok && !p.failed[step] {
+			color.Green("✓ %s (%.1fs)", step, seconds)
+		} else if errMsg, _ := chunk.Metadata["error"].(string); errMsg != "" {
+			p.failed[step] = true
+			color.Red("✗ %s: %s", step, errMsg)
+		} else {
+			p.failed[step] = true
+			color.Red("✗ %s (%.1fs)", step, seconds)
+		}
+		p.step = ""
+	case agk.ChunkTypeDelta:
+		p.streamed[step] = true
+		p.text(step, chunk.Delta)
+	case agk.ChunkTypeText:
+		// The workflow's own final text repeats its steps' output
+		if step != "" && !p.streamed[step] {
+			p.text(step, chunk.Content)
+		}
+	case agk.ChunkTypeError:
+		p.endLine()
+		p.failed[step] = true
+		if chunk.Error != nil {
+			color.Red("✗ %s", chunk.Error)
+		}
+	}
+}
+
+// text writes step output, naming the step when another step's output
+// came before it
+func (p *workflowPrinter) text(step, s string) {
+	if s == "" {
+		return
+	}
+	if step != p.step {
+		p.endLine()
+		color.Cyan("[%s]", step)
+		p.step = step
+	}
+	fmt.Print(s)
+	p.midLine = !strings.HasSuffix(s, "\n")
+}
+
+// endLine finishes a partly written line of output
+func (p *workflowPrinter) endLine() {
+	if p.midLine {
+		fmt.Println()
+		p.midLine = false
+	}
+}
+
+// elapsed is the time since the first chunk
+func (p *workflowPrinter) elapsed() time.Duration {
+	if p.start.IsZero() {
+		return 0
+	}
+	return time.Since(p.start)
+}
+
+func init() {
+	rootCmd.AddCommand(workflowCmd)
+	workflowCmd.AddCommand(workflowRunCmd)
+
+	workflowRunCmd.Flags().StringP("workflow", "w", "", "Workflow definition file (default: agk.toml's [workflow] default_workflow)")
+	workflowRunCmd.Flags().String("project", ".", "Project directory containing agk.toml")
+}
@@ -3,17 +3,21 @@ package audit
 
 import (
 	"encoding/json"
-	"os"
+	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
+
+	"github.com/agenticgokit/agk/internal/utils"
 )
 
 // Collector extracts trace events from stored span data
 type Collector struct {
-	runPath string
-	spans   []RawSpan
+	runPath     string
+	spans       []RawSpan
+	events      []TraceEvent // From events.jsonl, set by LoadEvents
+	contentKeys *ContentKeys // Nil uses DefaultContentKeys
+	dropped     int          // Untimed events Collect couldn't place
 }
 
 // RawSpan represents a parsed span from trace.jsonl
@@ -41,8 +45,7 @@ type SpanStatus struct {
 
 // NewCollector creates a collector from a run path
 func NewCollector(runPath string) (*Collector, error) {
-	tracePath := filepath.Join(runPath, "trace.jsonl")
-	data, err := os.ReadFile(tracePath)
+	data, err := ReadTraceFile(runPath)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +69,12 @@ func NewCollector(runPath string) (*Collector, error) {
 	}, nil
 }
 
+// SetContentKeys changes which span attributes Collect takes event
+// content from
+func (c *Collector) SetContentKeys(keys *ContentKeys) {
+	c.contentKeys = keys
+}
+
 // Collect extracts TraceObject from the spans
 func (c *Collector) Collect() (*TraceObject, error) {
 	runID := filepath.Base(c.runPath)
@@ -79,9 +88,12 @@ func (c *Collector) Collect() (*TraceObject, error) {
 	}
 
 	for _, span := range c.spans {
-		event := c.spanToEvent(span)
-		obj.Events = append(obj.Events, event)
+		obj.Events = append(obj.Events, c.spanToEvent(span))
+	}
+	obj.Events = append(obj.Events, c.events...)
+	obj.Events, c.dropped = orderEvents(obj.Events)
 
+	for _, event := range obj.Events {
 		// Update summary counts
 		switch event.Type {
 		case EventTypeThought:
@@ -101,7 +113,10 @@ func (c *Collector) Collect() (*TraceObject, error) {
 			obj.Summary.HasDetailedData = true
 		}
 
-		// Update timing
+		// Update timing; untimed events don't say when the run happened
+		if event.Timestamp.IsZero() {
+			continue
+		}
 		if obj.StartTime.IsZero() || event.Timestamp.Before(obj.StartTime) {
 			obj.StartTime = event.Timestamp
 		}
@@ -113,12 +128,81 @@ func (c *Collector) Collect() (*TraceObject, error) {
 	obj.Summary.TotalEvents = len(obj.Events)
 	obj.Summary.TotalDurationMs = obj.EndTime.Sub(obj.StartTime).Milliseconds()
 
-	// Sort events by timestamp
-	sort.Slice(obj.Events, func(i, j int) bool {
-		return obj.Events[i].Timestamp.Before(obj.Events[j].Timestamp)
+	return obj, nil
+}
+
+// DroppedEvents returns how many events the last Collect left out because
+// they had no timestamp and no parent to place them after
+func (c *Collector) DroppedEvents() int {
+	return c.dropped
+}
+
+// BadTimestamps counts the spans and events with a timestamp that is set
+// but can't be parsed and returns one as an example. Collect orders them as
+// if they had no timestamp.
+func (c *Collector) BadTimestamps() (count int, example string) {
+	var bad []string
+	for _, span := range c.spans {
+		for _, value := range []string{span.StartTime, span.EndTime} {
+			if value == "" {
+				continue
+			}
+			if _, err := utils.ParseTimestamp(value); err != nil {
+				bad = append(bad, value)
+				break
+			}
+		}
+	}
+	for _, event := range c.events {
+		// rawToEvent keeps timestamps it couldn't parse in the metadata
+		if value, ok := event.Metadata["timestamp"].(string); ok && event.Timestamp.IsZero() {
+			bad = append(bad, value)
+		}
+	}
+	if len(bad) == 0 {
+		return 0, ""
+	}
+	return len(bad), bad[0]
+}
+
+// orderEvents sorts events by timestamp and places each event without one
+// right after its parent, following the parent's other untimed children.
+// Untimed events with no parent in the run to follow are dropped.
+func orderEvents(events []TraceEvent) (ordered []TraceEvent, dropped int) {
+	var timed []TraceEvent
+	untimed := make(map[string][]TraceEvent) // By parent
+	for _, event := range events {
+		switch {
+		case !event.Timestamp.IsZero():
+			timed = append(timed, event)
+		case event.ParentID != "":
+			untimed[event.ParentID] = append(untimed[event.ParentID], event)
+		default:
+			dropped++
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].Timestamp.Before(timed[j].Timestamp)
 	})
 
-	return obj, nil
+	ordered = make([]TraceEvent, 0, len(events))
+	var place func(event TraceEvent)
+	place = func(event TraceEvent) {
+		ordered = append(ordered, event)
+		children := untimed[event.SpanID]
+		// Placed once, even if several events share the parent's ID
+		delete(untimed, event.SpanID)
+		for _, child := range children {
+			place(child)
+		}
+	}
+	for _, event := range timed {
+		place(event)
+	}
+	for _, children := range untimed {
+		dropped += len(children)
+	}
+	return ordered, dropped
 }
 
 // spanToEvent converts a raw span to a TraceEvent
@@ -131,13 +215,13 @@ func (c *Collector) spanToEvent(span RawSpan) TraceEvent {
 	}
 
 	// Parse timestamp
-	if t, err := time.Parse(time.RFC3339, span.StartTime); err == nil {
+	if t, err := utils.ParseTimestamp(span.StartTime); err == nil {
 		event.Timestamp = t
 	}
 
 	// Calculate duration
-	if start, err := time.Parse(time.RFC3339, span.StartTime); err == nil {
-		if end, err := time.Parse(time.RFC3339, span.EndTime); err == nil {
+	if start, err := utils.ParseTimestamp(span.StartTime); err == nil {
+		if end, err := utils.ParseTimestamp(span.EndTime); err == nil {
 			event.DurationMs = end.Sub(start).Milliseconds()
 		}
 	}
@@ -164,16 +248,9 @@ func (c *Collector) spanToEvent(span RawSpan) TraceEvent {
 		event.Metadata[key] = val
 
 		// Check for content fields (detailed trace level)
-		switch key {
-		case "agk.prompt.user", "agk.llm.response":
-			event.Content = val.(string)
-		case "agk.tool.arguments":
-			if event.Type == EventTypeToolCall {
-				event.Content = val.(string)
-			}
-		case "agk.tool.result":
-			if event.Type == EventTypeObservation {
-				event.Content = val.(string)
+		if c.contentKeys.isContent(key, event.Type) {
+			if content := fmt.Sprint(val); content != "" {
+				event.Content = content
 			}
 		}
 	}
@@ -201,9 +278,5 @@ func (c *Collector) classifySpan(name string) EventType {
 
 // GetReasoningPath extracts the sequence of event types
 func (c *Collector) GetReasoningPath(obj *TraceObject) []EventType {
-	path := make([]EventType, len(obj.Events))
-	for i, event := range obj.Events {
-		path[i] = event.Type
-	}
-	return path
+	return obj.ReasoningPath()
 }
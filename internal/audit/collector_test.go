@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCollectUntimedEvents(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := &Collector{
+		runPath: "run-1",
+		spans: []RawSpan{
+			{Name: "agent.run", SpanContext: SpanContext{SpanID: "a"}, StartTime: start.Format(time.RFC3339), EndTime: start.Add(3 * time.Second).Format(time.RFC3339)},
+			{Name: "llm.call", SpanContext: SpanContext{SpanID: "b"}, Parent: SpanContext{SpanID: "a"}, StartTime: start.Add(time.Second).Format(time.RFC3339)},
+			{Name: "tool.call", SpanContext: SpanContext{SpanID: "c"}, Parent: SpanContext{SpanID: "a"}, StartTime: start.Add(2 * time.Second).Format(time.RFC3339)},
+		},
+		events: []TraceEvent{
+			{SpanID: "e1", ParentID: "b", Type: EventTypeObservation},
+			{SpanID: "e2", ParentID: "e1", Type: EventTypeObservation},
+			{SpanID: "e3", Type: EventTypeObservation},
+			{SpanID: "e4", ParentID: "missing", Type: EventTypeObservation},
+		},
+	}
+
+	obj, err := c.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var order []string
+	for _, event := range obj.Events {
+		order = append(order, event.SpanID)
+	}
+	if want := []string{"a", "b", "e1", "e2", "c"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Collect() events = %v, want %v", order, want)
+	}
+	if got := c.DroppedEvents(); got != 2 {
+		t.Errorf("DroppedEvents() = %d, want 2", got)
+	}
+	if !obj.StartTime.Equal(start) || !obj.EndTime.Equal(start.Add(2*time.Second)) {
+		t.Errorf("Collect() time range = %v to %v, want %v to %v", obj.StartTime, obj.EndTime, start, start.Add(2*time.Second))
+	}
+	if obj.Summary.TotalEvents != 5 || obj.Summary.TotalDurationMs != 2000 {
+		t.Errorf("Collect() summary = %+v, want 5 events over 2000ms", obj.Summary)
+	}
+}
+
+func TestCollectBadTimestamps(t *testing.T) {
+	c := &Collector{
+		runPath: "run-1",
+		spans: []RawSpan{
+			{Name: "agent.run", SpanContext: SpanContext{SpanID: "a"}, StartTime: "2025-01-02T03:04:05Z", EndTime: "2025-01-02T03:04:07Z"},
+			{Name: "llm.call", SpanContext: SpanContext{SpanID: "b"}, Parent: SpanContext{SpanID: "a"}, StartTime: "yesterday"},
+		},
+	}
+	c.events = []TraceEvent{c.rawToEvent(map[string]any{"event_type": "observation", "span_id": "a", "timestamp": "soon"}, 1)}
+
+	obj, err := c.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, example := c.BadTimestamps(); count != 2 || example != "yesterday" {
+		t.Errorf("BadTimestamps() = %d, %q; want 2, \"yesterday\"", count, example)
+	}
+	if want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC); !obj.StartTime.Equal(want) || !obj.EndTime.Equal(want) {
+		t.Errorf("Collect() time range = %v to %v, want both %v", obj.StartTime, obj.EndTime, want)
+	}
+}
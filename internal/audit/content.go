@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ContentKeys decides which span attributes hold an event's content, for
+// agents whose instrumentation names them differently
+type ContentKeys struct {
+	include []string
+	exclude []string
+}
+
+// NewContentKeys creates content key rules from the default content
+// attributes plus extra include patterns, minus exclude patterns. Patterns
+// use case-insensitive glob syntax, e.g. "myapp.*.input"; excludes win over
+// includes and can drop default keys.
+func NewContentKeys(include, exclude []string) (*ContentKeys, error) {
+	var err error
+	k := &ContentKeys{}
+	if k.include, err = contentKeyPatterns(include); err != nil {
+		return nil, err
+	}
+	if k.exclude, err = contentKeyPatterns(exclude); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// contentKeyPatterns lowercases and checks glob patterns, skipping empty ones
+func contentKeyPatterns(patterns []string) ([]string, error) {
+	var result []string
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid content key pattern %q: %w", pattern, err)
+		}
+		result = append(result, pattern)
+	}
+	return result, nil
+}
+
+// isContent reports whether the attribute holds content for an event of
+// the given type. By default these are the attributes AgenticGoKit records
+// content under at the detailed trace level; tool arguments only count for
+// tool calls and tool results only for observations.
+func (k *ContentKeys) isContent(key string, eventType EventType) bool {
+	if k != nil && matchesAny(k.exclude, key) {
+		return false
+	}
+	switch key {
+	case "agk.prompt.user", "agk.llm.response":
+		return true
+	case "agk.tool.arguments":
+		return eventType == EventTypeToolCall
+	case "agk.tool.result":
+		return eventType == EventTypeObservation
+	}
+	return k != nil && matchesAny(k.include, key)
+}
+
+// matchesAny reports whether key matches one of the lowercase glob patterns
+func matchesAny(patterns []string, key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
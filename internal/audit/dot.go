@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateDOT creates a Graphviz digraph of a TraceObject, linking each
+// event to its parent span
+func GenerateDOT(obj *TraceObject) string {
+	var b strings.Builder
+	b.WriteString("digraph trace {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [fontname=\"Helvetica\", fontsize=10, style=filled];\n\n")
+
+	known := make(map[string]bool, len(obj.Events))
+	for _, event := range obj.Events {
+		known[event.SpanID] = true
+	}
+
+	for _, event := range obj.Events {
+		fill, shape := dotStyle(event.Type)
+		b.WriteString(fmt.Sprintf("  %q [label=%q, shape=%s, fillcolor=%q];\n",
+			event.SpanID, dotNodeLabel(event), shape, fill))
+	}
+
+	b.WriteString("\n")
+	for _, event := range obj.Events {
+		if known[event.ParentID] {
+			b.WriteString(fmt.Sprintf("  %q -> %q;\n", event.ParentID, event.SpanID))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotNodeLabel is formatNodeLabel with a plain newline instead of HTML
+func dotNodeLabel(event TraceEvent) string {
+	return strings.ReplaceAll(formatNodeLabel(event), "<br/>", "\n")
+}
+
+// dotStyle returns the fill color and node shape for an event type,
+// matching the Mermaid colors
+func dotStyle(eventType EventType) (fill, shape string) {
+	switch eventType {
+	case EventTypeThought:
+		return "#e1f5fe", "ellipse"
+	case EventTypeToolCall:
+		return "#e8f5e9", "box"
+	case EventTypeObservation:
+		return "#fff3e0", "parallelogram"
+	case EventTypeLLMCall:
+		return "#f3e5f5", "diamond"
+	case EventTypeDecision:
+		return "#fce4ec", "hexagon"
+	default:
+		return "#f5f5f5", "box"
+	}
+}
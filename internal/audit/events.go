@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agenticgokit/agk/internal/utils"
+)
+
+// EventsFileName is the stream of higher-level events some runs write next
+// to their trace, e.g. framework lifecycle events not captured as spans
+const EventsFileName = "events.jsonl"
+
+// eventFields are the events.jsonl fields mapped onto TraceEvent; every other
+// field is kept in the event's metadata
+var eventFields = map[string]bool{
+	"event_type": true, "timestamp": true, "name": true, "id": true,
+	"span_id": true, "parent_id": true, "content": true, "message": true,
+	"duration_ms": true,
+}
+
+// LoadEvents reads the run's events.jsonl so Collect merges its events with
+// the spans. Runs without the file are left unchanged; malformed lines are
+// skipped like malformed spans.
+func (c *Collector) LoadEvents() error {
+	data, err := os.ReadFile(filepath.Join(c.runPath, EventsFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", EventsFileName, err)
+	}
+
+	c.events = nil
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var raw map[string]any
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			continue
+		}
+		c.events = append(c.events, c.rawToEvent(raw, i+1))
+	}
+	return nil
+}
+
+// rawToEvent converts an events.jsonl line to a TraceEvent. An event's
+// span_id names the span it happened in, so it becomes the event's parent;
+// events without an id get one from their line number.
+func (c *Collector) rawToEvent(raw map[string]any, line int) TraceEvent {
+	str := func(key string) string {
+		if v, ok := raw[key].(string); ok {
+			return v
+		}
+		return ""
+	}
+
+	eventType := str("event_type")
+	event := TraceEvent{
+		Type:     c.classifyEventType(eventType),
+		SpanID:   str("id"),
+		SpanName: str("name"),
+		ParentID: str("span_id"),
+		Content:  str("content"),
+		Metadata: map[string]any{"event_type": eventType},
+	}
+	if event.SpanID == "" {
+		event.SpanID = fmt.Sprintf("event-%d", line)
+	}
+	if event.SpanName == "" {
+		event.SpanName = eventType
+	}
+	if event.ParentID == "" {
+		event.ParentID = str("parent_id")
+	}
+	if event.Content == "" {
+		event.Content = str("message")
+	}
+	if t, err := utils.ParseTimestamp(str("timestamp")); err == nil {
+		event.Timestamp = t
+	} else if str("timestamp") != "" {
+		// Kept for BadTimestamps
+		event.Metadata["timestamp"] = str("timestamp")
+	}
+	if d, ok := raw["duration_ms"].(float64); ok {
+		event.DurationMs = int64(d)
+	}
+
+	for key, value := range raw {
+		if !eventFields[key] {
+			event.Metadata[key] = value
+		}
+	}
+	return event
+}
+
+// classifyEventType maps an event_type onto an EventType: the type itself
+// when it is one, otherwise the same name-based rules used for spans
+func (c *Collector) classifyEventType(eventType string) EventType {
+	switch t := EventType(strings.ToLower(eventType)); t {
+	case EventTypeThought, EventTypeToolCall, EventTypeObservation, EventTypeLLMCall, EventTypeDecision:
+		return t
+	}
+	return c.classifySpan(eventType)
+}
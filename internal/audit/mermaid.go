@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"sort"
 	"strconv"
@@ -9,13 +10,35 @@ import (
 	"github.com/TyphonHill/go-mermaid/diagrams/flowchart"
 )
 
+// criticalPathColor is the stroke used for nodes and links on the critical path
+const criticalPathColor = "#d50000"
+
+// MermaidOptions controls optional Mermaid rendering features
+type MermaidOptions struct {
+	// HighlightCriticalPath draws the longest-duration root-to-leaf path
+	// with thick red strokes
+	HighlightCriticalPath bool
+	// CollapseRepeats folds consecutive sibling events with the same name
+	// into one node annotated with the count and total duration
+	CollapseRepeats bool
+	// MaxDepth keeps this many levels of events, summarizing everything
+	// deeper in one node per cut-off subtree (0 = no limit)
+	MaxDepth int
+}
+
 // GenerateMermaid creates a Mermaid flowchart from a TraceObject
 func GenerateMermaid(obj *TraceObject) string {
-	return GenerateMermaidWithHierarchy(obj)
+	return GenerateMermaidWithHierarchy(obj, MermaidOptions{})
 }
 
 // GenerateMermaidWithHierarchy creates a Mermaid diagram respecting parent-child relationships
-func GenerateMermaidWithHierarchy(obj *TraceObject) string {
+func GenerateMermaidWithHierarchy(obj *TraceObject, opts MermaidOptions) string {
+	var groups map[string]int
+	if opts.CollapseRepeats {
+		obj, groups = collapseRepeatedEvents(obj)
+	}
+	obj, folded := limitEventDepth(obj, opts.MaxDepth)
+
 	// Build parent map
 	parentMap := make(map[string][]int)
 	spanIDToIndex := make(map[string]int)
@@ -31,17 +54,47 @@ func GenerateMermaidWithHierarchy(obj *TraceObject) string {
 		}
 	}
 
+	onPath := make(map[int]bool)
+	if opts.HighlightCriticalPath {
+		for _, idx := range criticalPath(obj, spanIDToIndex, parentMap) {
+			onPath[idx] = true
+		}
+	}
+
 	diagram := flowchart.NewFlowchart()
-	diagram.EnableMarkdownFence()
 	diagram.SetDirection(flowchart.FlowchartDirectionTopDown)
 	diagram.Config.SetHtmlLabels(true)
 
+	ids := newNodeIDs()
 	nodes := make([]*flowchart.Node, len(obj.Events))
 	for i, event := range obj.Events {
 		label := formatNodeLabel(event)
+		if count, ok := groups[event.SpanID]; ok {
+			label = formatGroupLabel(event, count)
+		}
+		summary, isFolded := folded[event.SpanID]
+		if isFolded {
+			label = formatFoldedLabel(summary)
+		}
 		node := diagram.AddNode(label)
+		if event.SpanID != "" {
+			node.ID = ids.forKey(event.SpanID)
+		}
 		applyFlowchartShape(node, event.Type)
-		if style := getFlowchartStyle(event.Type); style != nil {
+		style := getFlowchartStyle(event.Type)
+		if isFolded {
+			style = flowchart.NewNodeStyle()
+			style.Stroke = "#9e9e9e"
+			style.StrokeDash = "5 5"
+		}
+		if onPath[i] {
+			if style == nil {
+				style = flowchart.NewNodeStyle()
+			}
+			style.Stroke = criticalPathColor
+			style.StrokeWidth = 3
+		}
+		if style != nil {
 			node.SetStyle(style)
 		}
 		nodes[i] = node
@@ -56,11 +109,15 @@ func GenerateMermaidWithHierarchy(obj *TraceObject) string {
 	sort.Ints(parentIndices)
 
 	addedLinks := make(map[string]bool)
+	var pathLinks []int // Indices of links between critical path nodes
 	addLink := func(fromIdx, toIdx int) {
 		key := fmt.Sprintf("%d->%d", fromIdx, toIdx)
 		if addedLinks[key] {
 			return
 		}
+		if onPath[fromIdx] && onPath[toIdx] {
+			pathLinks = append(pathLinks, len(addedLinks))
+		}
 		addedLinks[key] = true
 		diagram.AddLink(nodes[fromIdx], nodes[toIdx])
 	}
@@ -120,7 +177,7 @@ func GenerateMermaidWithHierarchy(obj *TraceObject) string {
 			}
 		}
 
-		return diagram.String()
+		return renderFlowchart(diagram, pathLinks)
 	}
 
 	for _, parentIdx := range parentIndices {
@@ -173,7 +230,82 @@ func GenerateMermaidWithHierarchy(obj *TraceObject) string {
 		}
 	}
 
-	return diagram.String()
+	return renderFlowchart(diagram, pathLinks)
+}
+
+// nodeIDs derives Mermaid node IDs from stable keys such as span IDs, so
+// rendering the same trace twice gives identical diagrams and a changed
+// trace only changes the lines of the nodes that changed. The diagram's
+// own IDs follow insertion order instead.
+type nodeIDs struct {
+	used map[string]bool
+}
+
+func newNodeIDs() *nodeIDs {
+	return &nodeIDs{used: make(map[string]bool)}
+}
+
+// forKey returns the ID for key: "n" and a hash of it, which is a valid
+// Mermaid identifier whatever the key contains. Keys hashing to an ID
+// already handed out get a numbered suffix.
+func (ids *nodeIDs) forKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	base := fmt.Sprintf("n%x", sum[:6])
+
+	id := base
+	for i := 2; ids.used[id]; i++ {
+		id = fmt.Sprintf("%s_%d", base, i)
+	}
+	ids.used[id] = true
+	return id
+}
+
+// renderFlowchart renders the diagram in a Markdown fence, styling the links
+// at the given indices as critical path links
+func renderFlowchart(diagram *flowchart.Flowchart, pathLinks []int) string {
+	body := diagram.String()
+	if len(pathLinks) > 0 {
+		ids := make([]string, len(pathLinks))
+		for i, idx := range pathLinks {
+			ids[i] = strconv.Itoa(idx)
+		}
+		body += fmt.Sprintf("    linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(ids, ","), criticalPathColor)
+	}
+
+	diagram.EnableMarkdownFence()
+	return diagram.WrapWithFence(body)
+}
+
+// criticalPath returns the event indices of the path that accounts for the
+// run's duration, ordered from root to leaf: the longest root, then at each
+// step its longest child. A child's time is already inside its parent's
+// DurationMs, so children are compared by their own DurationMs rather than
+// adding durations up the chain.
+func criticalPath(obj *TraceObject, spanIDToIndex map[string]int, parentMap map[string][]int) []int {
+	longest := func(indices []int) int {
+		best := -1
+		for _, idx := range indices {
+			if best < 0 || obj.Events[idx].DurationMs > obj.Events[best].DurationMs {
+				best = idx
+			}
+		}
+		return best
+	}
+
+	var roots []int
+	for i, event := range obj.Events {
+		if _, hasParent := spanIDToIndex[event.ParentID]; !hasParent {
+			roots = append(roots, i)
+		}
+	}
+
+	var path []int
+	visited := make(map[int]bool) // Guards against cycles in malformed traces
+	for idx := longest(roots); idx >= 0 && !visited[idx]; idx = longest(parentMap[obj.Events[idx].SpanID]) {
+		visited[idx] = true
+		path = append(path, idx)
+	}
+	return path
 }
 
 func collectDescendantIndices(rootSpanID string, spanIDToIndex map[string]int, childrenBySpan map[string][]string, obj *TraceObject) []int {
@@ -210,7 +342,18 @@ func formatNodeLabel(event TraceEvent) string {
 	// Start with event type icon
 	icon := getEventIcon(event.Type)
 
-	// Get a short description
+	// Add duration on new line
+	duration := ""
+	if event.DurationMs > 0 {
+		duration = fmt.Sprintf("<br/>%dms", event.DurationMs)
+	}
+
+	return fmt.Sprintf("%s %s%s", icon, eventDescription(event), duration)
+}
+
+// eventDescription returns a short name for an event: its workflow step or
+// span name, plus the agent that produced it
+func eventDescription(event TraceEvent) string {
 	desc := event.SpanName
 	if stepName, ok := event.Metadata["agk.workflow.step_name"].(string); ok && stepName != "" {
 		desc = "step:" + stepName
@@ -221,14 +364,7 @@ func formatNodeLabel(event TraceEvent) string {
 	if len(desc) > 60 {
 		desc = desc[:57] + "..."
 	}
-
-	// Add duration on new line
-	duration := ""
-	if event.DurationMs > 0 {
-		duration = fmt.Sprintf("<br/>%dms", event.DurationMs)
-	}
-
-	return fmt.Sprintf("%s %s%s", icon, desc, duration)
+	return desc
 }
 
 func isWorkflowSequential(event TraceEvent) bool {
@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/TyphonHill/go-mermaid/diagrams/flowchart"
+)
+
+// rareBranchRatio is the share of runs below which a node or link counts as
+// a rarely-taken branch
+const rareBranchRatio = 0.5
+
+// aggregateNode is an event merged across runs by its description
+type aggregateNode struct {
+	event TraceEvent   // First event seen, used for the icon, shape and style
+	runs  map[int]bool // Runs that reached this node
+	node  *flowchart.Node
+}
+
+// aggregateLink is a parent-child edge merged across runs
+type aggregateLink struct {
+	from, to string
+	runs     map[int]bool
+}
+
+// GenerateAggregateMermaid overlays several runs in one Mermaid flowchart.
+// Events are merged by their description (step or span name and agent), each
+// node is annotated with how many runs reached it, and nodes and links taken
+// by fewer than half of the runs are drawn dashed.
+func GenerateAggregateMermaid(objs []*TraceObject) string {
+	var order []string
+	nodes := make(map[string]*aggregateNode)
+	var linkOrder []string
+	links := make(map[string]*aggregateLink)
+
+	for run, obj := range objs {
+		names := make(map[string]string, len(obj.Events))
+		for _, event := range obj.Events {
+			names[event.SpanID] = eventDescription(event)
+		}
+
+		for _, event := range obj.Events {
+			name := names[event.SpanID]
+			n, ok := nodes[name]
+			if !ok {
+				n = &aggregateNode{event: event, runs: make(map[int]bool)}
+				nodes[name] = n
+				order = append(order, name)
+			}
+			n.runs[run] = true
+
+			parent, ok := names[event.ParentID]
+			if !ok || parent == name {
+				continue
+			}
+			key := parent + "\x00" + name
+			l, ok := links[key]
+			if !ok {
+				l = &aggregateLink{from: parent, to: name, runs: make(map[int]bool)}
+				links[key] = l
+				linkOrder = append(linkOrder, key)
+			}
+			l.runs[run] = true
+		}
+	}
+
+	diagram := flowchart.NewFlowchart()
+	diagram.EnableMarkdownFence()
+	diagram.SetDirection(flowchart.FlowchartDirectionTopDown)
+	diagram.Config.SetHtmlLabels(true)
+
+	total := len(objs)
+	rare := func(runs map[int]bool) bool {
+		return float64(len(runs)) < rareBranchRatio*float64(total)
+	}
+
+	ids := newNodeIDs()
+	for _, name := range order {
+		n := nodes[name]
+		label := fmt.Sprintf("%s %s (%d/%d)", getEventIcon(n.event.Type), name, len(n.runs), total)
+		n.node = diagram.AddNode(label)
+		n.node.ID = ids.forKey(name)
+		applyFlowchartShape(n.node, n.event.Type)
+
+		style := getFlowchartStyle(n.event.Type)
+		if rare(n.runs) {
+			if style == nil {
+				style = flowchart.NewNodeStyle()
+			}
+			style.Stroke = "#9e9e9e"
+			style.StrokeDash = "5 5"
+		}
+		if style != nil {
+			n.node.SetStyle(style)
+		}
+	}
+
+	for _, key := range linkOrder {
+		l := links[key]
+		link := diagram.AddLink(nodes[l.from].node, nodes[l.to].node)
+		if rare(l.runs) {
+			link.SetShape(flowchart.LinkShapeDotted)
+		}
+		if len(l.runs) < total {
+			link.SetText(fmt.Sprintf("%d/%d", len(l.runs), total))
+		}
+	}
+
+	return diagram.String()
+}
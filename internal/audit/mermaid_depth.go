@@ -0,0 +1,109 @@
+package audit
+
+import "fmt"
+
+// foldedLevels summarizes the events cut off below one event by MaxDepth
+type foldedLevels struct {
+	events int // Events folded away
+	levels int // Levels of events folded away
+}
+
+// limitEventDepth keeps maxDepth levels of events, counting roots as level
+// one. The descendants of each event on the last level are replaced by a
+// single summary event, whose span ID is a key of the returned map. obj is
+// not modified.
+func limitEventDepth(obj *TraceObject, maxDepth int) (*TraceObject, map[string]foldedLevels) {
+	folded := make(map[string]foldedLevels)
+	if maxDepth <= 0 {
+		return obj, folded
+	}
+
+	parentOf := make(map[string]string, len(obj.Events))
+	children := make(map[string][]int)
+	for i, event := range obj.Events {
+		parentOf[event.SpanID] = event.ParentID
+		children[event.ParentID] = append(children[event.ParentID], i)
+	}
+
+	// Walk down from the roots; visited guards against cycles in malformed traces
+	depth := make(map[string]int, len(obj.Events))
+	visited := make(map[string]bool, len(obj.Events))
+	var queue []string
+	for _, event := range obj.Events {
+		if _, ok := parentOf[event.ParentID]; !ok && !visited[event.SpanID] {
+			depth[event.SpanID] = 1
+			visited[event.SpanID] = true
+			queue = append(queue, event.SpanID)
+		}
+	}
+	for len(queue) > 0 {
+		spanID := queue[0]
+		queue = queue[1:]
+		for _, i := range children[spanID] {
+			child := obj.Events[i].SpanID
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			depth[child] = depth[spanID] + 1
+			queue = append(queue, child)
+		}
+	}
+
+	// Count what hangs below each event on the last kept level
+	cutOff := make(map[string]bool)
+	for _, event := range obj.Events {
+		d, ok := depth[event.SpanID]
+		if !ok || d <= maxDepth {
+			continue
+		}
+		ancestor := event.SpanID
+		for depth[ancestor] > maxDepth {
+			ancestor = parentOf[ancestor]
+		}
+		cutOff[event.SpanID] = true
+		summary := folded[ancestor]
+		summary.events++
+		summary.levels = max(summary.levels, d-maxDepth)
+		folded[ancestor] = summary
+	}
+
+	if len(folded) == 0 {
+		return obj, folded
+	}
+
+	limited := *obj
+	limited.Events = make([]TraceEvent, 0, len(obj.Events)-len(cutOff)+len(folded))
+	summaries := make(map[string]foldedLevels, len(folded))
+	for _, event := range obj.Events {
+		if cutOff[event.SpanID] {
+			continue
+		}
+		limited.Events = append(limited.Events, event)
+		if summary, ok := folded[event.SpanID]; ok {
+			id := event.SpanID + "/folded"
+			summaries[id] = summary
+			limited.Events = append(limited.Events, TraceEvent{
+				SpanID:    id,
+				SpanName:  "folded",
+				ParentID:  event.SpanID,
+				Timestamp: event.Timestamp,
+				Metadata:  map[string]any{},
+			})
+		}
+	}
+	return &limited, summaries
+}
+
+// formatFoldedLabel labels the summary of events folded away by MaxDepth
+func formatFoldedLabel(summary foldedLevels) string {
+	return fmt.Sprintf("⋯ %s<br/>+%s", plural(summary.events, "more span"), plural(summary.levels, "level"))
+}
+
+// plural formats a count with a noun, adding an s unless the count is one
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLimitEventDepth(t *testing.T) {
+	// root → plan → tool → exec, with a retry under plan
+	events := []TraceEvent{
+		{SpanID: "root", SpanName: "agent.run"},
+		{SpanID: "plan", SpanName: "llm.call", ParentID: "root"},
+		{SpanID: "tool", SpanName: "tool.call", ParentID: "plan"},
+		{SpanID: "exec", SpanName: "tool.exec", ParentID: "tool"},
+		{SpanID: "retry", SpanName: "llm.call", ParentID: "plan"},
+	}
+
+	tests := []struct {
+		name       string
+		maxDepth   int
+		wantSpans  []string
+		wantFolded map[string]foldedLevels
+	}{
+		{
+			name:       "no limit",
+			wantSpans:  []string{"root", "plan", "tool", "exec", "retry"},
+			wantFolded: map[string]foldedLevels{},
+		},
+		{
+			name:       "roots only",
+			maxDepth:   1,
+			wantSpans:  []string{"root", "root/folded"},
+			wantFolded: map[string]foldedLevels{"root/folded": {events: 4, levels: 3}},
+		},
+		{
+			name:       "two levels",
+			maxDepth:   2,
+			wantSpans:  []string{"root", "plan", "plan/folded"},
+			wantFolded: map[string]foldedLevels{"plan/folded": {events: 3, levels: 2}},
+		},
+		{
+			name:       "one level folded",
+			maxDepth:   3,
+			wantSpans:  []string{"root", "plan", "tool", "tool/folded", "retry"},
+			wantFolded: map[string]foldedLevels{"tool/folded": {events: 1, levels: 1}},
+		},
+		{
+			name:       "as deep as the trace",
+			maxDepth:   4,
+			wantSpans:  []string{"root", "plan", "tool", "exec", "retry"},
+			wantFolded: map[string]foldedLevels{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &TraceObject{Events: append([]TraceEvent(nil), events...)}
+			limited, folded := limitEventDepth(obj, tt.maxDepth)
+
+			var spans []string
+			for _, event := range limited.Events {
+				spans = append(spans, event.SpanID)
+				if _, ok := folded[event.SpanID]; ok && event.ParentID+"/folded" != event.SpanID {
+					t.Errorf("summary %s has parent %q, want the span it folds", event.SpanID, event.ParentID)
+				}
+			}
+			if !reflect.DeepEqual(spans, tt.wantSpans) {
+				t.Errorf("limitEventDepth(%d) events = %v, want %v", tt.maxDepth, spans, tt.wantSpans)
+			}
+			if !reflect.DeepEqual(folded, tt.wantFolded) {
+				t.Errorf("limitEventDepth(%d) folded = %v, want %v", tt.maxDepth, folded, tt.wantFolded)
+			}
+			if !reflect.DeepEqual(obj.Events, events) {
+				t.Error("limitEventDepth modified the trace")
+			}
+		})
+	}
+}
+
+func TestMermaidFoldedSummaryNode(t *testing.T) {
+	obj := &TraceObject{Events: []TraceEvent{
+		{SpanID: "root", SpanName: "agent.run"},
+		{SpanID: "plan", SpanName: "llm.call", ParentID: "root"},
+		{SpanID: "tool", SpanName: "tool.call", ParentID: "plan"},
+		{SpanID: "exec", SpanName: "tool.exec", ParentID: "tool"},
+	}}
+
+	diagram := GenerateMermaidWithHierarchy(obj, MermaidOptions{MaxDepth: 2})
+	if want := "⋯ 2 more spans<br/>+2 levels"; !strings.Contains(diagram, want) {
+		t.Errorf("diagram has no %q summary node:\n%s", want, diagram)
+	}
+	if strings.Contains(diagram, "tool.exec") {
+		t.Errorf("diagram shows the folded tool.exec span:\n%s", diagram)
+	}
+}
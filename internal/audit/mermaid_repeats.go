@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// collapseRepeatedEvents folds runs of consecutive sibling events with the
+// same description and type into the first event of each run. The first
+// event's duration becomes the run's total, the other events and their
+// descendants are dropped, and the returned map gives the group size for
+// each first event's span ID. obj is not modified.
+func collapseRepeatedEvents(obj *TraceObject) (*TraceObject, map[string]int) {
+	known := make(map[string]bool, len(obj.Events))
+	for _, event := range obj.Events {
+		known[event.SpanID] = true
+	}
+
+	// Group siblings under their parent; events without a known parent are roots
+	siblings := make(map[string][]int)
+	var parents []string
+	for i, event := range obj.Events {
+		parent := event.ParentID
+		if !known[parent] {
+			parent = ""
+		}
+		if _, ok := siblings[parent]; !ok {
+			parents = append(parents, parent)
+		}
+		siblings[parent] = append(siblings[parent], i)
+	}
+
+	groups := make(map[string]int)
+	totals := make(map[string]int64)
+	dropped := make(map[string]bool)
+	for _, parent := range parents {
+		indices := siblings[parent]
+		sort.SliceStable(indices, func(a, b int) bool {
+			return obj.Events[indices[a]].Timestamp.Before(obj.Events[indices[b]].Timestamp)
+		})
+
+		for i := 0; i < len(indices); {
+			head := obj.Events[indices[i]]
+			j := i + 1
+			for j < len(indices) && sameRepeatedEvent(head, obj.Events[indices[j]]) {
+				totals[head.SpanID] += obj.Events[indices[j]].DurationMs
+				dropped[obj.Events[indices[j]].SpanID] = true
+				j++
+			}
+			if j-i > 1 {
+				groups[head.SpanID] = j - i
+				totals[head.SpanID] += head.DurationMs
+			}
+			i = j
+		}
+	}
+
+	if len(groups) == 0 {
+		return obj, groups
+	}
+
+	// Drop the descendants of folded events too
+	for changed := true; changed; {
+		changed = false
+		for _, event := range obj.Events {
+			if !dropped[event.SpanID] && dropped[event.ParentID] {
+				dropped[event.SpanID] = true
+				changed = true
+			}
+		}
+	}
+
+	collapsed := *obj
+	collapsed.Events = make([]TraceEvent, 0, len(obj.Events)-len(dropped))
+	for _, event := range obj.Events {
+		if dropped[event.SpanID] {
+			continue
+		}
+		if total, ok := totals[event.SpanID]; ok {
+			event.DurationMs = total
+		}
+		collapsed.Events = append(collapsed.Events, event)
+	}
+	return &collapsed, groups
+}
+
+// sameRepeatedEvent reports whether two sibling events are repeats of the
+// same step, e.g. the same tool called again. Tool calls share a span name,
+// so the tool name has to match too.
+func sameRepeatedEvent(a, b TraceEvent) bool {
+	return a.Type == b.Type &&
+		eventDescription(a) == eventDescription(b) &&
+		fmt.Sprint(a.Metadata["agk.tool.name"]) == fmt.Sprint(b.Metadata["agk.tool.name"])
+}
+
+// formatGroupLabel labels an event standing for count repeated events
+func formatGroupLabel(event TraceEvent, count int) string {
+	return fmt.Sprintf("%s %s ×%d<br/>%dms total", getEventIcon(event.Type), eventDescription(event), count, event.DurationMs)
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestCriticalPathNestedSpans(t *testing.T) {
+	// Children run inside their parents, so the path must follow the child
+	// with the longest own duration (a, 900ms) rather than the chain whose
+	// durations add up to the most (b + b1 = 1150ms)
+	obj := &TraceObject{Events: []TraceEvent{
+		{SpanID: "root", SpanName: "agent.run", DurationMs: 1000},
+		{SpanID: "a", SpanName: "llm.call", DurationMs: 900, ParentID: "root"},
+		{SpanID: "a1", SpanName: "http.request", DurationMs: 100, ParentID: "a"},
+		{SpanID: "b", SpanName: "tool.call", DurationMs: 600, ParentID: "root"},
+		{SpanID: "b1", SpanName: "tool.exec", DurationMs: 550, ParentID: "b"},
+	}}
+
+	spanIDToIndex := make(map[string]int)
+	parentMap := make(map[string][]int)
+	for i, event := range obj.Events {
+		spanIDToIndex[event.SpanID] = i
+		if event.ParentID != "" {
+			parentMap[event.ParentID] = append(parentMap[event.ParentID], i)
+		}
+	}
+
+	var got []string
+	for _, idx := range criticalPath(obj, spanIDToIndex, parentMap) {
+		got = append(got, obj.Events[idx].SpanID)
+	}
+	if want := []string{"root", "a", "a1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("criticalPath() = %v, want %v", got, want)
+	}
+}
+
+// mermaidNodeLine matches a node declaration, capturing its ID and the name
+// its label starts with
+var mermaidNodeLine = regexp.MustCompile(`(?m)^\s+(\S+)@\{.*label: "([^<"]*)`)
+
+// mermaidNodeIDs maps each node's label name to its ID in a rendered diagram
+func mermaidNodeIDs(t *testing.T, diagram string) map[string]string {
+	t.Helper()
+	ids := make(map[string]string)
+	for _, match := range mermaidNodeLine.FindAllStringSubmatch(diagram, -1) {
+		ids[match[2]] = match[1]
+	}
+	if len(ids) == 0 {
+		t.Fatalf("no nodes found in diagram:\n%s", diagram)
+	}
+	return ids
+}
+
+// stableIDTrace is a small trace with a sequence of siblings
+func stableIDTrace() *TraceObject {
+	return &TraceObject{Events: []TraceEvent{
+		{SpanID: "root", SpanName: "agent.run", Type: EventTypeThought, DurationMs: 1000},
+		{SpanID: "plan", SpanName: "llm.plan", Type: EventTypeLLMCall, DurationMs: 300, ParentID: "root"},
+		{SpanID: "search", SpanName: "tool.search", Type: EventTypeToolCall, DurationMs: 400, ParentID: "root"},
+		{SpanID: "answer", SpanName: "llm.answer", Type: EventTypeLLMCall, DurationMs: 200, ParentID: "root"},
+	}}
+}
+
+func TestGenerateMermaidDeterministic(t *testing.T) {
+	first := GenerateMermaidWithHierarchy(stableIDTrace(), MermaidOptions{HighlightCriticalPath: true})
+	second := GenerateMermaidWithHierarchy(stableIDTrace(), MermaidOptions{HighlightCriticalPath: true})
+	if first != second {
+		t.Errorf("rendering the same trace twice differs:\n%s\nvs\n%s", first, second)
+	}
+}
+
+func TestGenerateMermaidIDsSurviveInsertedSpan(t *testing.T) {
+	before := mermaidNodeIDs(t, GenerateMermaid(stableIDTrace()))
+
+	obj := stableIDTrace()
+	extra := TraceEvent{SpanID: "retry", SpanName: "llm.retry", Type: EventTypeLLMCall, DurationMs: 100, ParentID: "root"}
+	obj.Events = append(obj.Events[:2], append([]TraceEvent{extra}, obj.Events[2:]...)...)
+	after := mermaidNodeIDs(t, GenerateMermaid(obj))
+
+	if len(after) != len(before)+1 {
+		t.Fatalf("diagram with the extra span has %d nodes, want %d", len(after), len(before)+1)
+	}
+	for name, id := range before {
+		if after[name] != id {
+			t.Errorf("node %s has ID %s after inserting a span, want %s", name, after[name], id)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnyEventType matches an event of any type in a path pattern
+const AnyEventType EventType = "any"
+
+// pathStep is one parsed element of a path pattern
+type pathStep struct {
+	eventType EventType // AnyEventType matches every type
+	min       int       // Fewest events the step matches
+	unbounded bool      // Matches any number of events beyond min
+}
+
+// ParsePathPattern splits a pattern like "thought, tool_call+, observation,
+// llm_call" into steps for ValidatePath. Each step is an event type or "any",
+// optionally suffixed with + (one or more), * (zero or more) or ? (optional);
+// a bare * stands for "any*".
+func ParsePathPattern(pattern string) ([]EventType, error) {
+	fields := strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	steps := make([]EventType, 0, len(fields))
+	for _, field := range fields {
+		step := EventType(strings.ToLower(field))
+		if _, err := parsePathStep(step); err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("empty path pattern")
+	}
+	return steps, nil
+}
+
+// parsePathStep parses one pattern step, e.g. "tool_call+"
+func parsePathStep(step EventType) (pathStep, error) {
+	s := string(step)
+	if s == "*" {
+		return pathStep{eventType: AnyEventType, unbounded: true}, nil
+	}
+
+	parsed := pathStep{min: 1}
+	switch {
+	case strings.HasSuffix(s, "+"):
+		parsed.unbounded = true
+	case strings.HasSuffix(s, "*"):
+		parsed.min, parsed.unbounded = 0, true
+	case strings.HasSuffix(s, "?"):
+		parsed.min = 0
+	}
+	parsed.eventType = EventType(strings.TrimRight(s, "+*?"))
+
+	switch parsed.eventType {
+	case EventTypeThought, EventTypeToolCall, EventTypeObservation, EventTypeLLMCall, EventTypeDecision, AnyEventType:
+		return parsed, nil
+	}
+	return parsed, fmt.Errorf("unknown event type in path pattern: %q (use thought, tool_call, observation, llm_call, decision or any)", s)
+}
+
+// ValidatePath checks that the trace's whole reasoning path, the event types
+// in time order, matches the expected pattern (see ParsePathPattern). On a
+// mismatch it returns false and a message showing the observed path.
+func ValidatePath(obj *TraceObject, expected []EventType) (bool, string) {
+	steps := make([]pathStep, 0, len(expected))
+	for _, step := range expected {
+		parsed, err := parsePathStep(step)
+		if err != nil {
+			return false, err.Error()
+		}
+		steps = append(steps, parsed)
+	}
+
+	path := obj.ReasoningPath()
+	if matchPath(steps, path) {
+		return true, ""
+	}
+
+	expectedText := make([]string, len(expected))
+	for i, step := range expected {
+		expectedText[i] = string(step)
+	}
+	return false, fmt.Sprintf("reasoning path mismatch: expected %s; observed: %s",
+		strings.Join(expectedText, ", "), describePath(path))
+}
+
+// matchPath reports whether steps match all of path. matched[i][j] records
+// whether the first i steps can consume exactly the first j events.
+func matchPath(steps []pathStep, path []EventType) bool {
+	matched := make([][]bool, len(steps)+1)
+	for i := range matched {
+		matched[i] = make([]bool, len(path)+1)
+	}
+	matched[0][0] = true
+
+	for i, step := range steps {
+		for j := 0; j <= len(path); j++ {
+			if !matched[i][j] {
+				continue
+			}
+			// Consume a run of events starting at j
+			for n := 0; j+n <= len(path); n++ {
+				if n > 0 && step.eventType != AnyEventType && path[j+n-1] != step.eventType {
+					break
+				}
+				if n > 1 && !step.unbounded {
+					break
+				}
+				if n >= step.min {
+					matched[i+1][j+n] = true
+				}
+			}
+		}
+	}
+	return matched[len(steps)][len(path)]
+}
+
+// describePath renders a reasoning path compactly, folding consecutive
+// repeats: "thought → tool_call×3 → llm_call"
+func describePath(path []EventType) string {
+	if len(path) == 0 {
+		return "(none)"
+	}
+	var parts []string
+	for i := 0; i < len(path); {
+		j := i
+		for j < len(path) && path[j] == path[i] {
+			j++
+		}
+		part := string(path[i])
+		if j-i > 1 {
+			part += fmt.Sprintf("×%d", j-i)
+		}
+		parts = append(parts, part)
+		i = j
+	}
+	return strings.Join(parts, " → ")
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePathPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    []EventType
+		wantErr string
+	}{
+		{
+			name:    "commas and spaces",
+			pattern: "thought, tool_call+ observation,llm_call",
+			want:    []EventType{"thought", "tool_call+", "observation", "llm_call"},
+		},
+		{
+			name:    "modifiers and wildcards",
+			pattern: "Thought? any* decision *",
+			want:    []EventType{"thought?", "any*", "decision", "*"},
+		},
+		{
+			name:    "unknown event type",
+			pattern: "thought, tool+",
+			wantErr: `unknown event type in path pattern: "tool+"`,
+		},
+		{
+			name:    "empty",
+			pattern: " , ",
+			wantErr: "empty path pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePathPattern(tt.pattern)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParsePathPattern() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePathPattern() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePathPattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	path := []EventType{
+		EventTypeThought, EventTypeToolCall, EventTypeToolCall, EventTypeObservation, EventTypeLLMCall,
+	}
+	tests := []struct {
+		name    string
+		pattern string
+		path    []EventType
+		want    bool
+	}{
+		{name: "exact", pattern: "thought, tool_call, tool_call, observation, llm_call", path: path, want: true},
+		{name: "one or more", pattern: "thought, tool_call+, observation, llm_call", path: path, want: true},
+		{name: "one or more needs one", pattern: "thought, decision+, tool_call+, observation, llm_call", path: path, want: false},
+		{name: "zero or more", pattern: "thought, decision*, tool_call*, observation, llm_call", path: path, want: true},
+		{name: "optional", pattern: "thought?, tool_call+, observation, llm_call", path: path[1:], want: true},
+		{name: "optional matches once", pattern: "thought, tool_call?, observation, llm_call", path: path, want: false},
+		{name: "any", pattern: "any, any+, llm_call", path: path, want: true},
+		{name: "bare star", pattern: "thought, *", path: path, want: true},
+		{name: "whole path", pattern: "thought, tool_call+", path: path, want: false},
+		{name: "wrong order", pattern: "tool_call+, thought, observation, llm_call", path: path, want: false},
+		{name: "empty path", pattern: "thought*, *", path: nil, want: true},
+		{name: "empty path needs events", pattern: "thought", path: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := ParsePathPattern(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			steps := make([]pathStep, len(expected))
+			for i, step := range expected {
+				if steps[i], err = parsePathStep(step); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := matchPath(steps, tt.path); got != tt.want {
+				t.Errorf("matchPath(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePathMismatch(t *testing.T) {
+	obj := &TraceObject{}
+	for _, eventType := range []EventType{EventTypeThought, EventTypeToolCall, EventTypeToolCall, EventTypeLLMCall} {
+		obj.Events = append(obj.Events, TraceEvent{Type: eventType})
+	}
+
+	ok, msg := ValidatePath(obj, []EventType{"thought", "llm_call"})
+	want := "reasoning path mismatch: expected thought, llm_call; observed: thought → tool_call×2 → llm_call"
+	if ok || msg != want {
+		t.Errorf("ValidatePath() = %v, %q; want false, %q", ok, msg, want)
+	}
+
+	if ok, msg := ValidatePath(obj, []EventType{"thought", "tool_call+", "llm_call"}); !ok {
+		t.Errorf("ValidatePath() = false, %q; want a match", msg)
+	}
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces sensitive values in redacted traces
+const RedactedValue = "***REDACTED***"
+
+// DefaultRedactKeys are attribute key patterns whose values are always redacted
+var DefaultRedactKeys = []string{
+	"agk.prompt.*",
+	"agk.llm.response",
+	"agk.tool.arguments",
+	"agk.tool.result",
+	"*api_key*",
+	"*api-key*",
+	"*apikey*",
+	"*secret*",
+	"*password*",
+	"*authorization*",
+}
+
+// DefaultRedactPatterns match secrets embedded in otherwise harmless values
+var DefaultRedactPatterns = []string{
+	`sk-[A-Za-z0-9_\-]{16,}`,        // OpenAI / Anthropic style keys
+	`AKIA[0-9A-Z]{16}`,              // AWS access key IDs
+	`gh[pousr]_[A-Za-z0-9]{20,}`,    // GitHub tokens
+	`xox[abpr]-[A-Za-z0-9\-]{10,}`,  // Slack tokens
+	`(?i)bearer\s+[A-Za-z0-9._\-]+`, // Authorization headers
+	`(?i)(api[_-]?key|secret|password|token)["']?\s*[:=]\s*["']?[^\s"',}]+`,
+}
+
+// Redactor replaces sensitive attribute values in raw trace spans
+type Redactor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a redactor from the default rules plus extra key
+// patterns (case-insensitive glob syntax, e.g. "myapp.user.*") and value regexes
+func NewRedactor(extraKeys, extraPatterns []string) (*Redactor, error) {
+	r := &Redactor{}
+
+	for _, key := range append(append([]string{}, DefaultRedactKeys...), extraKeys...) {
+		key = strings.ToLower(key)
+		if _, err := filepath.Match(key, ""); err != nil {
+			return nil, fmt.Errorf("invalid redact key pattern %q: %w", key, err)
+		}
+		r.keys = append(r.keys, key)
+	}
+
+	for _, pattern := range append(append([]string{}, DefaultRedactPatterns...), extraPatterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// RedactSpans redacts the attributes of spans, their resources, events and
+// links in place. Spans are in the raw trace.jsonl shape: attributes are a
+// list of {"Key": ..., "Value": {"Type": ..., "Value": ...}} objects.
+func (r *Redactor) RedactSpans(spans []map[string]interface{}) {
+	for _, span := range spans {
+		r.redactAttributes(span["Attributes"])
+		r.redactAttributes(span["Resource"])
+
+		for _, list := range []string{"Events", "Links"} {
+			items, _ := span[list].([]interface{})
+			for _, item := range items {
+				if obj, ok := item.(map[string]interface{}); ok {
+					r.redactAttributes(obj["Attributes"])
+				}
+			}
+		}
+	}
+}
+
+// redactAttributes redacts a raw attribute list
+func (r *Redactor) redactAttributes(raw interface{}) {
+	attrs, ok := raw.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, a := range attrs {
+		attr, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := attr["Value"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		key, _ := attr["Key"].(string)
+		if r.sensitiveKey(key) {
+			// The placeholder is a string whatever the value was, so readers
+			// going by Type don't misread it
+			value["Type"] = "STRING"
+			value["Value"] = RedactedValue
+			continue
+		}
+
+		switch v := value["Value"].(type) {
+		case string:
+			value["Value"] = r.RedactString(v)
+		case []interface{}: // STRINGSLICE
+			for i, element := range v {
+				if s, ok := element.(string); ok {
+					v[i] = r.RedactString(s)
+				}
+			}
+		}
+	}
+}
+
+// sensitiveKey reports whether an attribute key matches a redact key pattern
+func (r *Redactor) sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if ok, _ := filepath.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactString replaces secret-looking substrings of s
+func (r *Redactor) RedactString(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, RedactedValue)
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactSpansResourceAndLinks(t *testing.T) {
+	attr := func(key, value string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"Key":   key,
+			"Value": map[string]interface{}{"Type": "STRING", "Value": value},
+		}}
+	}
+	value := func(attrs interface{}) interface{} {
+		return attrs.([]interface{})[0].(map[string]interface{})["Value"].(map[string]interface{})["Value"]
+	}
+
+	span := map[string]interface{}{
+		"Attributes": attr("agk.llm.response", "hello"),
+		"Resource":   attr("deploy.api_key", "abc123"),
+		"Events":     []interface{}{map[string]interface{}{"Attributes": attr("db.password", "hunter2")}},
+		"Links":      []interface{}{map[string]interface{}{"Attributes": attr("auth.secret", "s3cr3t")}},
+	}
+
+	r, err := NewRedactor(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.RedactSpans([]map[string]interface{}{span})
+
+	for name, attrs := range map[string]interface{}{
+		"span":     span["Attributes"],
+		"resource": span["Resource"],
+		"event":    span["Events"].([]interface{})[0].(map[string]interface{})["Attributes"],
+		"link":     span["Links"].([]interface{})[0].(map[string]interface{})["Attributes"],
+	} {
+		if got := value(attrs); got != RedactedValue {
+			t.Errorf("%s attribute = %v, want it redacted", name, got)
+		}
+	}
+}
+
+func TestRedactSpansTypedValues(t *testing.T) {
+	attr := func(key, valueType string, value interface{}) interface{} {
+		return map[string]interface{}{
+			"Key":   key,
+			"Value": map[string]interface{}{"Type": valueType, "Value": value},
+		}
+	}
+	span := map[string]interface{}{"Attributes": []interface{}{
+		attr("db.password", "INT64", float64(1234)),
+		attr("auth.secret_set", "BOOL", true),
+		attr("http.headers", "STRINGSLICE", []interface{}{"accept: */*", "Bearer abc.def"}),
+	}}
+
+	r, err := NewRedactor(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.RedactSpans([]map[string]interface{}{span})
+
+	want := []interface{}{
+		attr("db.password", "STRING", RedactedValue),
+		attr("auth.secret_set", "STRING", RedactedValue),
+		attr("http.headers", "STRINGSLICE", []interface{}{"accept: */*", RedactedValue}),
+	}
+	if !reflect.DeepEqual(span["Attributes"], want) {
+		t.Errorf("RedactSpans() attributes = %v, want %v", span["Attributes"], want)
+	}
+}
@@ -0,0 +1,57 @@
+package audit
+
+import "fmt"
+
+// ReplayInput is a user prompt recorded in a trace along with what the agent answered
+type ReplayInput struct {
+	SpanID           string   `json:"span_id"`
+	Prompt           string   `json:"prompt"`
+	OriginalResponse string   `json:"original_response,omitempty"`
+	ToolArguments    []string `json:"tool_arguments,omitempty"`
+}
+
+// ExtractReplayInputs collects the user prompts from a trace so they can be re-run.
+// Each prompt is paired with the first recorded LLM response at or after it, and
+// the tool arguments observed before the next prompt. Duplicate prompts (e.g. the
+// same text on an agent span and its LLM child) are collapsed.
+func ExtractReplayInputs(obj *TraceObject) []ReplayInput {
+	var inputs []ReplayInput
+	seen := make(map[string]bool)
+
+	for i, event := range obj.Events {
+		prompt, ok := event.Metadata["agk.prompt.user"]
+		if !ok {
+			continue
+		}
+		promptText := fmt.Sprintf("%v", prompt)
+		if promptText == "" || seen[promptText] {
+			continue
+		}
+		seen[promptText] = true
+
+		input := ReplayInput{
+			SpanID: event.SpanID,
+			Prompt: promptText,
+		}
+
+		for _, next := range obj.Events[i:] {
+			if next.SpanID != event.SpanID {
+				if p, ok := next.Metadata["agk.prompt.user"]; ok && fmt.Sprintf("%v", p) != promptText {
+					break
+				}
+			}
+			if args, ok := next.Metadata["agk.tool.arguments"]; ok {
+				input.ToolArguments = append(input.ToolArguments, fmt.Sprintf("%v", args))
+			}
+			if input.OriginalResponse == "" {
+				if resp, ok := next.Metadata["agk.llm.response"]; ok {
+					input.OriginalResponse = fmt.Sprintf("%v", resp)
+				}
+			}
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	return inputs
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// TraceFileName is the span file written to each run directory
+	TraceFileName = "trace.jsonl"
+	// CompressedTraceFileName is the gzipped form of TraceFileName
+	CompressedTraceFileName = TraceFileName + ".gz"
+)
+
+// TracePath returns the trace file of a run. The uncompressed file wins when
+// both exist, since it is the one still being written to; the gzipped file is
+// returned only when it is the sole trace.
+func TracePath(runPath string) string {
+	plain := filepath.Join(runPath, TraceFileName)
+	if _, err := os.Stat(plain); err == nil {
+		return plain
+	}
+	compressed := filepath.Join(runPath, CompressedTraceFileName)
+	if _, err := os.Stat(compressed); err == nil {
+		return compressed
+	}
+	return plain
+}
+
+// IsCompressedTrace reports whether path is a gzipped trace file
+func IsCompressedTrace(path string) bool {
+	return strings.HasSuffix(path, ".gz")
+}
+
+// ReadTraceFile returns the JSONL contents of a run's trace file,
+// transparently decompressing trace.jsonl.gz
+func ReadTraceFile(runPath string) ([]byte, error) {
+	return ReadTraceData(TracePath(runPath))
+}
+
+// ReadTraceData reads a trace file, decompressing it when it starts with the
+// gzip magic bytes regardless of its extension
+func ReadTraceData(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return io.ReadAll(reader)
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	return data, nil
+}
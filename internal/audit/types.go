@@ -43,6 +43,15 @@ type TraceObject struct {
 	Summary     TraceSummary `json:"summary"`
 }
 
+// ReasoningPath returns the event types in time order
+func (obj *TraceObject) ReasoningPath() []EventType {
+	path := make([]EventType, len(obj.Events))
+	for i, event := range obj.Events {
+		path[i] = event.Type
+	}
+	return path
+}
+
 // TraceSummary provides aggregate metrics for the trace
 type TraceSummary struct {
 	TotalEvents     int     `json:"total_events"`
@@ -13,7 +13,21 @@ type ProjectConfig struct {
 	Description string
 	Template    string
 	LLMProvider string
+	Model       string
 	AgentType   string
+	MemoryType  string // Read from agk.toml; generated projects use in-memory
+	MemoryPath  string // Where a persistent memory store keeps its data
+
+	// Read from agk.toml for 'agk serve'. Credentials may reference
+	// environment variables as ${NAME}.
+	SystemPrompt string
+	LLMAPIKey    string
+	LLMBaseURL   string
+	LLMTimeout   string
+	ServerPort   int
+
+	// DefaultWorkflow is what 'agk workflow run' runs, relative to the project
+	DefaultWorkflow string
 }
 
 // Generator generates configuration files
@@ -33,6 +47,9 @@ func (g *Generator) GenerateConfig(cfg *ProjectConfig, outputPath string) error
 	if cfg.LLMProvider == "" {
 		cfg.LLMProvider = "openai"
 	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultModel(cfg.LLMProvider)
+	}
 	if cfg.AgentType == "" {
 		cfg.AgentType = "single"
 	}
@@ -62,6 +79,7 @@ func (g *Generator) generateConfigContent(cfg *ProjectConfig, _ string) string {
 [project]
 name = "%s"
 description = "%s"
+template = "%s"
 version = "0.1.0"
 authors = ["Your Name <your.email@example.com>"]
 
@@ -71,8 +89,8 @@ templates_dir = "./templates"
 
 [llm]
 provider = "%s"
-model = "gpt-4"
-api_key = "${OPENAI_API_KEY}"
+model = "%s"
+%s
 timeout = "30s"
 
 [agents]
@@ -82,7 +100,7 @@ memory_type = "in-memory"
 
 [workflow]
 type = "sequential"
-default_workflow = "workflow/main.yaml"
+%s
 
 [server]
 port = 8080
@@ -98,5 +116,49 @@ output = "stdout"
 enabled = true
 auto_discover = true
 
-`, cfg.Name, description, cfg.LLMProvider, cfg.AgentType)
+`, cfg.Name, description, cfg.Template, cfg.LLMProvider, cfg.Model, credentialLine(cfg.LLMProvider), cfg.AgentType, workflowLine(cfg.DefaultWorkflow))
+}
+
+// workflowLine returns the [workflow] entry naming the project's default
+// workflow, or a commented-out example when it has none
+func workflowLine(path string) string {
+	if path == "" {
+		return `# default_workflow = "workflow/main.yaml"`
+	}
+	return fmt.Sprintf("default_workflow = %q", path)
+}
+
+// credentialLine returns the [llm] entry that points at the provider's credentials
+func credentialLine(provider string) string {
+	if provider == "ollama" {
+		// Ollama runs locally and needs a host rather than an API key
+		return fmt.Sprintf(`base_url = "${%s}"`, APIKeyEnv(provider))
+	}
+	return fmt.Sprintf(`api_key = "${%s}"`, APIKeyEnv(provider))
+}
+
+// DefaultModel returns the default model for an LLM provider
+func DefaultModel(provider string) string {
+	switch provider {
+	case "anthropic":
+		return "claude-sonnet-4-20250514"
+	case "ollama":
+		return "llama3.2"
+	default:
+		return "gpt-4o"
+	}
+}
+
+// APIKeyEnv returns the environment variable holding the provider's credentials
+func APIKeyEnv(provider string) string {
+	switch provider {
+	case "anthropic":
+		return "ANTHROPIC_API_KEY"
+	case "ollama":
+		return "OLLAMA_HOST" // Ollama doesn't need API key, but uses host
+	case "azure":
+		return "AZURE_OPENAI_API_KEY"
+	default:
+		return "OPENAI_API_KEY"
+	}
 }
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// projectFile is the subset of agk.toml that describes how a project was generated
+type projectFile struct {
+	Project struct {
+		Name        string `toml:"name"`
+		Description string `toml:"description"`
+		Template    string `toml:"template"`
+	} `toml:"project"`
+	LLM struct {
+		Provider string `toml:"provider"`
+		Model    string `toml:"model"`
+		APIKey   string `toml:"api_key"`
+		BaseURL  string `toml:"base_url"`
+		Timeout  string `toml:"timeout"`
+	} `toml:"llm"`
+	Agents struct {
+		Type         string `toml:"type"`
+		SystemPrompt string `toml:"system_prompt"`
+		MemoryType   string `toml:"memory_type"`
+		MemoryPath   string `toml:"memory_path"`
+	} `toml:"agents"`
+	Workflow struct {
+		DefaultWorkflow string `toml:"default_workflow"`
+	} `toml:"workflow"`
+	Server struct {
+		Port int `toml:"port"`
+	} `toml:"server"`
+}
+
+// LoadConfig reads an agk.toml file into a ProjectConfig. Fields missing from
+// the file are left empty so callers can apply their own defaults.
+func LoadConfig(path string) (*ProjectConfig, error) {
+	var file projectFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &ProjectConfig{
+		Name:         file.Project.Name,
+		Description:  file.Project.Description,
+		Template:     file.Project.Template,
+		LLMProvider:  file.LLM.Provider,
+		Model:        file.LLM.Model,
+		AgentType:    file.Agents.Type,
+		SystemPrompt: file.Agents.SystemPrompt,
+		MemoryType:   file.Agents.MemoryType,
+		MemoryPath:   file.Agents.MemoryPath,
+		LLMAPIKey:    file.LLM.APIKey,
+		LLMBaseURL:   file.LLM.BaseURL,
+		LLMTimeout:   file.LLM.Timeout,
+		ServerPort:   file.Server.Port,
+
+		DefaultWorkflow: file.Workflow.DefaultWorkflow,
+	}, nil
+}
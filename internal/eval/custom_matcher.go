@@ -0,0 +1,178 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MatcherConstructor builds a matcher for an expectation of a registered type
+type MatcherConstructor func(exp Expectation) (MatcherInterface, error)
+
+var (
+	customMatchersMu sync.RWMutex
+	customMatchers   = map[string]MatcherConstructor{}
+)
+
+// builtinMatcherTypes are the expectation types handled by MatcherFactory itself
+var builtinMatcherTypes = []string{"exact", "contains", "regex", "fuzzy", "semantic", "command"}
+
+// RegisterMatcher makes a custom matcher available as expect.type name.
+// It is meant to be called from init functions and panics if name is empty,
+// a built-in type, or already registered.
+func RegisterMatcher(name string, constructor MatcherConstructor) {
+	customMatchersMu.Lock()
+	defer customMatchersMu.Unlock()
+
+	if name == "" || constructor == nil {
+		panic("eval: RegisterMatcher requires a name and a constructor")
+	}
+	for _, builtin := range builtinMatcherTypes {
+		if name == builtin {
+			panic(fmt.Sprintf("eval: cannot register matcher %q: it is a built-in type", name))
+		}
+	}
+	if _, exists := customMatchers[name]; exists {
+		panic(fmt.Sprintf("eval: matcher %q is already registered", name))
+	}
+	customMatchers[name] = constructor
+}
+
+// lookupMatcher returns the constructor registered for name
+func lookupMatcher(name string) (MatcherConstructor, bool) {
+	customMatchersMu.RLock()
+	defer customMatchersMu.RUnlock()
+	constructor, ok := customMatchers[name]
+	return constructor, ok
+}
+
+// DefaultCommandMatcherTimeout bounds how long a command matcher may run
+const DefaultCommandMatcherTimeout = 30 * time.Second
+
+// CommandMatcher delegates matching to an external program.
+//
+// The program receives a JSON object with "actual", "expected" and
+// "expected_values" on stdin. If it prints a JSON object with a "matched"
+// field on stdout, that object is the result; otherwise exit code 0 means
+// the output matched and any other exit code means it didn't, with the
+// program's output as the explanation.
+type CommandMatcher struct {
+	command []string
+	timeout time.Duration
+}
+
+// commandMatcherInput is written to the program's stdin
+type commandMatcherInput struct {
+	Actual         string   `json:"actual"`
+	Expected       string   `json:"expected,omitempty"`
+	ExpectedValues []string `json:"expected_values,omitempty"`
+}
+
+// commandMatcherOutput is the optional JSON verdict printed by the program
+type commandMatcherOutput struct {
+	Matched     *bool                  `json:"matched"`
+	Confidence  *float64               `json:"confidence"`
+	Explanation string                 `json:"explanation"`
+	Details     map[string]interface{} `json:"details"`
+}
+
+// NewCommandMatcher creates a matcher running exp.Command
+func NewCommandMatcher(exp Expectation) (*CommandMatcher, error) {
+	if len(exp.Command) == 0 || exp.Command[0] == "" {
+		return nil, fmt.Errorf("expect.command is required for 'command' type")
+	}
+	return &CommandMatcher{command: exp.Command, timeout: DefaultCommandMatcherTimeout}, nil
+}
+
+// Match runs the command and interprets its verdict
+func (m *CommandMatcher) Match(ctx context.Context, actual string, exp Expectation) (*MatchResult, error) {
+	input, err := json.Marshal(commandMatcherInput{Actual: actual, Expected: exp.Value, ExpectedValues: exp.Values})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode command input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, m.command[0], m.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("command matcher timed out after %s", m.timeout)
+	}
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, fmt.Errorf("failed to run command matcher: %w", runErr)
+	}
+
+	details := map[string]interface{}{
+		"command":   strings.Join(m.command, " "),
+		"exit_code": cmd.ProcessState.ExitCode(),
+	}
+
+	// A JSON verdict on stdout takes precedence over the exit code
+	var verdict commandMatcherOutput
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &verdict); err == nil && verdict.Matched != nil {
+		for k, v := range verdict.Details {
+			details[k] = v
+		}
+		confidence := 0.0
+		if *verdict.Matched {
+			confidence = 1.0
+		}
+		if verdict.Confidence != nil {
+			confidence = *verdict.Confidence
+		}
+		explanation := verdict.Explanation
+		if explanation == "" {
+			explanation = fmt.Sprintf("command reported matched=%v", *verdict.Matched)
+		}
+		return &MatchResult{
+			Matched:     *verdict.Matched,
+			Confidence:  confidence,
+			Strategy:    "command",
+			Explanation: explanation,
+			Details:     details,
+		}, nil
+	}
+
+	if runErr == nil {
+		return &MatchResult{
+			Matched:     true,
+			Confidence:  1.0,
+			Strategy:    "command",
+			Explanation: "command exited with status 0",
+			Details:     details,
+		}, nil
+	}
+
+	explanation := strings.TrimSpace(stderr.String())
+	if explanation == "" {
+		explanation = strings.TrimSpace(stdout.String())
+	}
+	if explanation == "" {
+		explanation = fmt.Sprintf("command exited with status %d", exitErr.ExitCode())
+	}
+	return &MatchResult{
+		Matched:     false,
+		Confidence:  0.0,
+		Strategy:    "command",
+		Explanation: explanation,
+		Details:     details,
+	}, nil
+}
+
+// Name returns the matcher strategy name
+func (m *CommandMatcher) Name() string {
+	return "command"
+}
@@ -0,0 +1,171 @@
+package eval
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dataRow is one case from a test data file
+type dataRow struct {
+	Name     string
+	Input    string
+	Expected []string
+}
+
+// expandDataTests replaces every test that has a data_file with one test
+// per row of that file. Relative paths are resolved against baseDir.
+// The original test acts as a template: each generated test copies it and
+// takes its input and expected value(s) from the row.
+func expandDataTests(suite *TestSuite, baseDir string) error {
+	var tests []Test
+	for _, test := range suite.Tests {
+		if test.DataFile == "" {
+			tests = append(tests, test)
+			continue
+		}
+
+		path := test.DataFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+
+		rows, err := readDataFile(path)
+		if err != nil {
+			return fmt.Errorf("test '%s': %w", test.Name, err)
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("test '%s': data file %s has no rows", test.Name, test.DataFile)
+		}
+
+		for i, row := range rows {
+			tests = append(tests, testFromRow(test, row, i+1))
+		}
+	}
+
+	suite.Tests = tests
+	return nil
+}
+
+// testFromRow builds the test for one data row
+func testFromRow(template Test, row dataRow, n int) Test {
+	test := template
+	test.DataFile = ""
+	test.Input = row.Input
+
+	test.Name = fmt.Sprintf("%s[%d]", template.Name, n)
+	if row.Name != "" {
+		test.Name = fmt.Sprintf("%s/%s", template.Name, row.Name)
+	}
+
+	switch {
+	case len(row.Expected) == 0:
+		// Keep the template's expectation
+	case test.Expect.Type == "regex":
+		test.Expect.Pattern = row.Expected[0]
+	case test.Expect.Type == "contains" || len(row.Expected) > 1:
+		test.Expect.Value = ""
+		test.Expect.Values = row.Expected
+	default:
+		test.Expect.Value = row.Expected[0]
+		test.Expect.Values = nil
+	}
+
+	return test
+}
+
+// readDataFile reads test rows from a .csv or .json file
+func readDataFile(path string) ([]dataRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return readCSVRows(f)
+	case ".json":
+		return readJSONRows(f)
+	default:
+		return nil, fmt.Errorf("unsupported data file type: %s (supported: .csv, .json)", filepath.Ext(path))
+	}
+}
+
+// readCSVRows reads rows from a CSV file with a header line containing an
+// input column and optional expected and name columns. Several expected
+// values can be given in one cell separated by "|".
+func readCSVRows(r io.Reader) ([]dataRow, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, header := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	inputCol, ok := columns["input"]
+	if !ok {
+		return nil, fmt.Errorf("CSV header must have an 'input' column")
+	}
+	cell := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []dataRow
+	for _, record := range records[1:] {
+		row := dataRow{Name: cell(record, "name")}
+		if inputCol < len(record) {
+			row.Input = record[inputCol]
+		}
+		if expected := cell(record, "expected"); expected != "" {
+			for _, value := range strings.Split(expected, "|") {
+				row.Expected = append(row.Expected, strings.TrimSpace(value))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// jsonDataRow is the JSON form of a data row; expected may be a string or
+// a list of strings
+type jsonDataRow struct {
+	Name     string          `json:"name"`
+	Input    string          `json:"input"`
+	Expected json.RawMessage `json:"expected"`
+}
+
+// readJSONRows reads rows from a JSON array of {"input", "expected", "name"} objects
+func readJSONRows(r io.Reader) ([]dataRow, error) {
+	var raw []jsonDataRow
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON data file: %w", err)
+	}
+
+	rows := make([]dataRow, 0, len(raw))
+	for i, item := range raw {
+		row := dataRow{Name: item.Name, Input: item.Input}
+		if len(item.Expected) > 0 && string(item.Expected) != "null" {
+			var single string
+			if err := json.Unmarshal(item.Expected, &single); err == nil {
+				row.Expected = []string{single}
+			} else if err := json.Unmarshal(item.Expected, &row.Expected); err != nil {
+				return nil, fmt.Errorf("row %d: expected must be a string or a list of strings", i+1)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,154 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultsFileName is the file in ~/.agk holding the judge and embedding
+// models suites inherit when they don't configure their own
+const DefaultsFileName = "eval.toml"
+
+// SemanticDefaults are the judge LLM and embedding model used by semantic
+// tests whose suite and test leave them out. They rank below the suite's
+// semantic block, which ranks below per-test overrides.
+type SemanticDefaults struct {
+	LLM       *LLMConfig
+	Embedding *EmbeddingConfig
+}
+
+// defaultsModel is one [llm] or [embedding] table of eval.toml
+type defaultsModel struct {
+	Provider string `toml:"provider"`
+	Model    string `toml:"model"`
+	BaseURL  string `toml:"base_url"`
+}
+
+// defaultsFile is the layout of eval.toml
+type defaultsFile struct {
+	LLM       defaultsModel `toml:"llm"`
+	Embedding defaultsModel `toml:"embedding"`
+}
+
+var (
+	semanticDefaultsMu sync.RWMutex
+	semanticDefaults   *SemanticDefaults
+)
+
+// SetSemanticDefaults installs the defaults used when parsing suites and
+// creating semantic matchers. nil removes them.
+func SetSemanticDefaults(defaults *SemanticDefaults) {
+	semanticDefaultsMu.Lock()
+	defer semanticDefaultsMu.Unlock()
+	semanticDefaults = defaults
+}
+
+// currentSemanticDefaults returns the installed defaults, or nil
+func currentSemanticDefaults() *SemanticDefaults {
+	semanticDefaultsMu.RLock()
+	defer semanticDefaultsMu.RUnlock()
+	return semanticDefaults
+}
+
+// LoadSemanticDefaults reads the defaults from path, if it exists, and then
+// from the AGK_EVAL_LLM_* and AGK_EVAL_EMBEDDING_* environment variables
+// (PROVIDER, MODEL, BASE_URL), which take precedence over the file
+func LoadSemanticDefaults(path string) (*SemanticDefaults, error) {
+	var file defaultsFile
+	if path != "" {
+		if _, err := toml.DecodeFile(path, &file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+
+	envOverride(&file.LLM, "AGK_EVAL_LLM_")
+	envOverride(&file.Embedding, "AGK_EVAL_EMBEDDING_")
+
+	defaults := &SemanticDefaults{}
+	if file.LLM != (defaultsModel{}) {
+		if file.LLM.Provider == "" {
+			return nil, fmt.Errorf("default judge LLM needs a provider (llm.provider in %s or AGK_EVAL_LLM_PROVIDER)", DefaultsFileName)
+		}
+		defaults.LLM = &LLMConfig{Provider: file.LLM.Provider, Model: file.LLM.Model, BaseURL: file.LLM.BaseURL}
+	}
+	if file.Embedding != (defaultsModel{}) {
+		if file.Embedding.Provider == "" {
+			return nil, fmt.Errorf("default embedding model needs a provider (embedding.provider in %s or AGK_EVAL_EMBEDDING_PROVIDER)", DefaultsFileName)
+		}
+		defaults.Embedding = &EmbeddingConfig{Provider: file.Embedding.Provider, Model: file.Embedding.Model, BaseURL: file.Embedding.BaseURL}
+	}
+	return defaults, nil
+}
+
+// envOverride replaces model settings with the environment variables that
+// are set, e.g. AGK_EVAL_LLM_MODEL
+func envOverride(model *defaultsModel, prefix string) {
+	for suffix, field := range map[string]*string{
+		"PROVIDER": &model.Provider,
+		"MODEL":    &model.Model,
+		"BASE_URL": &model.BaseURL,
+	} {
+		if value := os.Getenv(prefix + suffix); value != "" {
+			*field = value
+		}
+	}
+}
+
+// hasLLM reports whether the defaults configure a judge LLM
+func (d *SemanticDefaults) hasLLM() bool {
+	return d != nil && d.LLM != nil
+}
+
+// hasEmbedding reports whether the defaults configure an embedding model
+func (d *SemanticDefaults) hasEmbedding() bool {
+	return d != nil && d.Embedding != nil
+}
+
+// fill completes config with the defaults: a missing llm or embedding block
+// is taken whole, and settings left empty in a block are filled in when it
+// names no provider or the default one. Blocks are copied, never modified.
+func (d *SemanticDefaults) fill(config *SemanticConfig) {
+	if d == nil {
+		return
+	}
+
+	if d.LLM != nil {
+		switch {
+		case config.LLM == nil:
+			llmCopy := *d.LLM
+			config.LLM = &llmCopy
+		case config.LLM.Provider == "" || config.LLM.Provider == d.LLM.Provider:
+			llmCopy := *config.LLM
+			fillEmpty(&llmCopy.Provider, d.LLM.Provider)
+			fillEmpty(&llmCopy.Model, d.LLM.Model)
+			fillEmpty(&llmCopy.BaseURL, d.LLM.BaseURL)
+			config.LLM = &llmCopy
+		}
+	}
+
+	if d.Embedding != nil {
+		switch {
+		case config.Embedding == nil:
+			embCopy := *d.Embedding
+			config.Embedding = &embCopy
+		case config.Embedding.Provider == "" || config.Embedding.Provider == d.Embedding.Provider:
+			embCopy := *config.Embedding
+			fillEmpty(&embCopy.Provider, d.Embedding.Provider)
+			fillEmpty(&embCopy.Model, d.Embedding.Model)
+			fillEmpty(&embCopy.BaseURL, d.Embedding.BaseURL)
+			config.Embedding = &embCopy
+		}
+	}
+}
+
+// fillEmpty sets *field to value when it is empty
+func fillEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
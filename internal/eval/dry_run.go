@@ -0,0 +1,98 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TestPlan describes how a test would be evaluated, as resolved by a dry run
+type TestPlan struct {
+	TestName  string
+	Type      string   // Expectation type
+	Matcher   string   // Name of the matcher that would run
+	Strategy  string   // Semantic strategy; empty for other types
+	Judge     string   // provider/model of the judge LLM, if any
+	Embedding string   // provider/model of the embedding model, if any
+	Threshold *float64 // Threshold the matcher applies, if it uses one
+	Cached    bool     // Semantic results may be served from the cache
+	Err       error    // Why the matcher can't be created
+}
+
+// PlanSuite resolves the matcher every test would use, merging the suite's
+// semantic config with per-test overrides and the runtime judge overrides,
+// without calling the target or any model
+func PlanSuite(suite *TestSuite, config *RunnerConfig) []TestPlan {
+	factory := NewMatcherFactory(suite.Semantic)
+	if config != nil {
+		factory.SetJudgeOverrides(config.JudgeTemperature, config.JudgeMaxTokens)
+	}
+
+	plans := make([]TestPlan, 0, len(suite.Tests))
+	for _, test := range suite.Tests {
+		exp := test.Expect
+		plan := TestPlan{TestName: test.Name, Type: exp.Type}
+
+		matcher, err := factory.CreateMatcher(exp)
+		if err != nil {
+			plan.Err = err
+		} else {
+			plan.Matcher = matcher.Name()
+		}
+
+		switch exp.Type {
+		case "semantic":
+			merged := factory.mergeSemanticConfig(exp)
+			plan.Strategy = merged.Strategy
+			if plan.Strategy == "" {
+				plan.Strategy = MatcherStrategyLLMJudge
+			}
+			if merged.LLM != nil && plan.Strategy != MatcherStrategyEmbedding {
+				plan.Judge = merged.LLM.Provider + "/" + merged.LLM.Model
+			}
+			if merged.Embedding != nil && plan.Strategy != MatcherStrategyLLMJudge {
+				model, _ := merged.Embedding.resolvedModel()
+				plan.Embedding = merged.Embedding.Provider + "/" + model
+			}
+			threshold := merged.Threshold
+			plan.Threshold = &threshold
+			plan.Cached = merged.Cache && (config == nil || !config.NoCache)
+		case "fuzzy":
+			threshold := DefaultFuzzyThreshold
+			if exp.Threshold != nil {
+				threshold = *exp.Threshold
+			}
+			plan.Threshold = &threshold
+		}
+
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+// Summary describes the plan on one line, e.g.
+// "semantic/hybrid judge=ollama/llama3 embedding=ollama/nomic threshold=0.85"
+func (p TestPlan) Summary() string {
+	if p.Err != nil {
+		return fmt.Sprintf("%s: %v", p.Type, p.Err)
+	}
+
+	parts := []string{p.Type}
+	if p.Strategy != "" {
+		parts[0] += "/" + p.Strategy
+	} else if p.Matcher != "" && p.Matcher != p.Type {
+		parts[0] += " (" + p.Matcher + ")"
+	}
+	if p.Judge != "" {
+		parts = append(parts, "judge="+p.Judge)
+	}
+	if p.Embedding != "" {
+		parts = append(parts, "embedding="+p.Embedding)
+	}
+	if p.Threshold != nil {
+		parts = append(parts, fmt.Sprintf("threshold=%.2f", *p.Threshold))
+	}
+	if p.Cached {
+		parts = append(parts, "cached")
+	}
+	return strings.Join(parts, " ")
+}
@@ -8,6 +8,9 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,6 +18,7 @@ import (
 type EmbeddingMatcher struct {
 	config   *SemanticConfig
 	embedder EmbeddingClient
+	model    string
 }
 
 // EmbeddingClient interface for generating embeddings
@@ -35,9 +39,11 @@ func NewEmbeddingMatcher(config *SemanticConfig) (*EmbeddingMatcher, error) {
 		return nil, fmt.Errorf("failed to create embedding client: %w", err)
 	}
 
+	model, _ := config.Embedding.resolvedModel()
 	return &EmbeddingMatcher{
 		config:   config,
 		embedder: embedder,
+		model:    model,
 	}, nil
 }
 
@@ -88,7 +94,7 @@ func (m *EmbeddingMatcher) Match(ctx context.Context, actual string, exp Expecta
 			"similarity": maxSimilarity,
 			"threshold":  threshold,
 			"best_match": bestMatch,
-			"model":      m.config.Embedding.Model,
+			"model":      m.model,
 		},
 	}, nil
 }
@@ -122,8 +128,41 @@ func cosineSimilarity(a, b []float64) float64 {
 // Embedding Clients
 // ========================================
 
+// defaultEmbeddingModels are the models used for each supported provider
+// when an embedding config names none
+var defaultEmbeddingModels = map[string]string{
+	"ollama": "nomic-embed-text",
+	"openai": "text-embedding-3-small",
+}
+
+// loggedEmbeddingDefaults remembers the providers whose default model was
+// already reported, so a suite logs it once rather than once per test
+var loggedEmbeddingDefaults sync.Map
+
+// resolvedModel returns the configured model, or the provider's default when
+// none is set; defaulted reports whether the default was used
+func (c *EmbeddingConfig) resolvedModel() (model string, defaulted bool) {
+	if c.Model != "" {
+		return c.Model, false
+	}
+	model, ok := defaultEmbeddingModels[c.Provider]
+	return model, ok
+}
+
 // createEmbeddingClient creates appropriate embedding client based on provider
 func createEmbeddingClient(config *EmbeddingConfig) (EmbeddingClient, error) {
+	if _, ok := defaultEmbeddingModels[config.Provider]; !ok {
+		return nil, fmt.Errorf("unsupported embedding provider: %s", config.Provider)
+	}
+	if model, defaulted := config.resolvedModel(); defaulted {
+		if _, logged := loggedEmbeddingDefaults.LoadOrStore(config.Provider, true); !logged {
+			fmt.Fprintf(os.Stderr, "ℹ️  No embedding model set for %s, using %s\n", config.Provider, model)
+		}
+		withModel := *config
+		withModel.Model = model
+		config = &withModel
+	}
+
 	switch config.Provider {
 	case "ollama":
 		return NewOllamaEmbeddingClient(config)
@@ -159,13 +198,67 @@ func NewOllamaEmbeddingClient(config *EmbeddingConfig) (*OllamaEmbeddingClient,
 		baseURL = "http://localhost:11434"
 	}
 
-	return &OllamaEmbeddingClient{
+	client := &OllamaEmbeddingClient{
 		baseURL: baseURL,
 		model:   config.Model,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-	}, nil
+	}
+	if err := client.checkModel(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// checkedOllamaModels caches checkModel's result per server and model
+var checkedOllamaModels sync.Map
+
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+// checkModel fails when the Ollama server is reachable but hasn't pulled the
+// model, which would otherwise fail every test with a 404. An unreachable
+// server isn't reported here; the first Embed call does that.
+func (c *OllamaEmbeddingClient) checkModel() error {
+	key := c.baseURL + "|" + c.model
+	if cached, ok := checkedOllamaModels.Load(key); ok {
+		err, _ := cached.(error)
+		return err
+	}
+
+	err := c.findModel()
+	checkedOllamaModels.Store(key, err)
+	return err
+}
+
+func (c *OllamaEmbeddingClient) findModel() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/tags", nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	var tags ollamaTagsResponse
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&tags) != nil {
+		return nil
+	}
+	for _, m := range tags.Models {
+		if m.Name == c.model || strings.TrimSuffix(m.Name, ":latest") == c.model {
+			return nil
+		}
+	}
+	return fmt.Errorf("embedding model %q not found on the Ollama server at %s (run 'ollama pull %s')", c.model, c.baseURL, c.model)
 }
 
 func (c *OllamaEmbeddingClient) Embed(ctx context.Context, text string) ([]float64, error) {
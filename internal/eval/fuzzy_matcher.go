@@ -0,0 +1,194 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultFuzzyThreshold is the similarity fuzzy matches need by default
+	DefaultFuzzyThreshold = 0.9
+	// maxFuzzyDiffCells bounds the edit table kept for building a diff;
+	// longer comparisons report similarity only
+	maxFuzzyDiffCells = 4_000_000
+)
+
+// FuzzyMatcher matches outputs that are nearly identical to the expected
+// value, using Levenshtein similarity after collapsing whitespace
+type FuzzyMatcher struct{}
+
+func NewFuzzyMatcher() *FuzzyMatcher {
+	return &FuzzyMatcher{}
+}
+
+// Match succeeds when the similarity between actual and exp.Value (or the
+// closest of exp.Values) reaches exp.Threshold
+func (m *FuzzyMatcher) Match(ctx context.Context, actual string, exp Expectation) (*MatchResult, error) {
+	threshold := DefaultFuzzyThreshold
+	if exp.Threshold != nil {
+		threshold = *exp.Threshold
+	}
+
+	var candidates []string
+	if exp.Value != "" {
+		candidates = append(candidates, exp.Value)
+	}
+	candidates = append(candidates, exp.Values...)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("fuzzy match requires expect.value or expect.values")
+	}
+
+	normalized := normalizeFuzzy(actual)
+	best, bestSimilarity := "", -1.0
+	for _, candidate := range candidates {
+		if s := fuzzySimilarity(normalized, normalizeFuzzy(candidate)); s > bestSimilarity {
+			best, bestSimilarity = candidate, s
+		}
+	}
+
+	details := map[string]interface{}{
+		"similarity":    bestSimilarity,
+		"threshold":     threshold,
+		"closest_value": best,
+	}
+	if diff, ok := fuzzyDiff(normalizeFuzzy(best), normalized); ok {
+		details["diff"] = diff
+	}
+
+	matched := bestSimilarity >= threshold
+	explanation := fmt.Sprintf("similarity %.2f >= %.2f", bestSimilarity, threshold)
+	if !matched {
+		explanation = fmt.Sprintf("similarity %.2f below threshold %.2f (closest: %q)", bestSimilarity, threshold, best)
+	}
+
+	return &MatchResult{
+		Matched:     matched,
+		Confidence:  bestSimilarity,
+		Strategy:    "fuzzy",
+		Explanation: explanation,
+		Details:     details,
+	}, nil
+}
+
+func (m *FuzzyMatcher) Name() string {
+	return "fuzzy"
+}
+
+// normalizeFuzzy trims and collapses whitespace so layout drift doesn't count
+func normalizeFuzzy(s string) []rune {
+	return []rune(strings.Join(strings.Fields(s), " "))
+}
+
+// fuzzySimilarity returns 1 - distance/length, from 0 (nothing in common)
+// to 1 (identical)
+func fuzzySimilarity(a, b []rune) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// fuzzyDiff renders the edits turning expected into actual, marking removed
+// text as [-...-] and added text as {+...+}. It reports false when the
+// inputs are too long to diff cheaply.
+func fuzzyDiff(expected, actual []rune) (string, bool) {
+	n, m := len(expected), len(actual)
+	if (n+1)*(m+1) > maxFuzzyDiffCells {
+		return "", false
+	}
+
+	dist := make([][]int, n+1)
+	for i := range dist {
+		dist[i] = make([]int, m+1)
+		dist[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		dist[0][j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if expected[i-1] == actual[j-1] {
+				cost = 0
+			}
+			dist[i][j] = min(dist[i-1][j]+1, dist[i][j-1]+1, dist[i-1][j-1]+cost)
+		}
+	}
+
+	// Walk back from the end, collecting edit operations in reverse
+	type op struct {
+		kind byte // '=', '-' or '+'
+		r    rune
+	}
+	var ops []op
+	for i, j := n, m; i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && expected[i-1] == actual[j-1] && dist[i][j] == dist[i-1][j-1]:
+			ops = append(ops, op{'=', expected[i-1]})
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			ops = append(ops, op{'+', actual[j-1]}, op{'-', expected[i-1]})
+			i, j = i-1, j-1
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			ops = append(ops, op{'-', expected[i-1]})
+			i--
+		default:
+			ops = append(ops, op{'+', actual[j-1]})
+			j--
+		}
+	}
+
+	// Replay forwards, grouping each run of edits as removals then additions
+	var b, removed, added strings.Builder
+	flush := func() {
+		if removed.Len() > 0 {
+			b.WriteString("[-" + removed.String() + "-]")
+			removed.Reset()
+		}
+		if added.Len() > 0 {
+			b.WriteString("{+" + added.String() + "+}")
+			added.Reset()
+		}
+	}
+	for k := len(ops) - 1; k >= 0; k-- {
+		switch o := ops[k]; o.kind {
+		case '-':
+			removed.WriteRune(o.r)
+		case '+':
+			added.WriteRune(o.r)
+		default:
+			flush()
+			b.WriteRune(o.r)
+		}
+	}
+	flush()
+
+	return b.String(), true
+}
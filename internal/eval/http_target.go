@@ -2,6 +2,7 @@ package eval
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,15 @@ type HTTPTarget struct {
 	client  *http.Client
 }
 
+func init() {
+	RegisterTarget("http", func(config TargetConfig, timeout time.Duration) (Target, error) {
+		if config.URL == "" {
+			return nil, fmt.Errorf("target URL is required for HTTP targets")
+		}
+		return NewHTTPTarget(config.URL, timeout), nil
+	})
+}
+
 // NewHTTPTarget creates a new HTTP target
 func NewHTTPTarget(baseURL string, timeout time.Duration) *HTTPTarget {
 	return &HTTPTarget{
@@ -25,7 +35,10 @@ func NewHTTPTarget(baseURL string, timeout time.Duration) *HTTPTarget {
 	}
 }
 
-// InvokeRequest matches the EvalServer's request format
+// InvokeRequest matches the EvalServer's request format.
+// Per-test environment variables are sent as Options["env"], a string map the
+// target should apply (e.g. as config overrides or feature flags) for this
+// invocation only.
 type InvokeRequest struct {
 	Input     string                 `json:"input"`
 	SessionID string                 `json:"sessionID,omitempty"`
@@ -43,16 +56,21 @@ type InvokeResponse struct {
 	Error       string   `json:"error,omitempty"`
 }
 
-// Invoke sends a test to the target and returns the response
-func (ht *HTTPTarget) Invoke(input string, timeout int) (*InvokeResponse, error) {
+// Invoke POSTs a test to the target's /invoke endpoint, within the
+// invocation's session and with its environment variables, and returns the
+// response. Cancelling ctx aborts the request.
+func (ht *HTTPTarget) Invoke(ctx context.Context, inv Invocation) (*InvokeResponse, error) {
 	// Build request
 	req := InvokeRequest{
-		Input:     input,
-		SessionID: "",
+		Input:     inv.Input,
+		SessionID: inv.SessionID,
 		Options: map[string]interface{}{
-			"timeout": timeout,
+			"timeout": inv.Timeout,
 		},
 	}
+	if len(inv.Env) > 0 {
+		req.Options["env"] = inv.Env
+	}
 
 	reqBody, err := json.Marshal(req)
 	if err != nil {
@@ -60,7 +78,7 @@ func (ht *HTTPTarget) Invoke(input string, timeout int) (*InvokeResponse, error)
 	}
 
 	// Send HTTP request
-	httpReq, err := http.NewRequest("POST", ht.baseURL+"/invoke", bytes.NewBuffer(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", ht.baseURL+"/invoke", bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
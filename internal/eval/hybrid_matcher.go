@@ -50,6 +50,10 @@ func (m *HybridMatcher) Match(ctx context.Context, actual string, exp Expectatio
 		return nil, fmt.Errorf("embedding match failed: %w", err)
 	}
 
+	if m.config.HybridMode == HybridModeConsensus {
+		return m.matchConsensus(ctx, actual, exp, embResult)
+	}
+
 	// If embedding confidence is very high, trust it (fast path)
 	if embResult.Confidence >= 0.95 {
 		embResult.Strategy = "hybrid (embedding-confident)"
@@ -87,6 +91,48 @@ func (m *HybridMatcher) Match(ctx context.Context, actual string, exp Expectatio
 	return llmResult, nil
 }
 
+// matchConsensus passes only when both the embedding check and the LLM judge match
+func (m *HybridMatcher) matchConsensus(ctx context.Context, actual string, exp Expectation, embResult *MatchResult) (*MatchResult, error) {
+	// Both must agree, so an embedding rejection is already final
+	if !embResult.Matched {
+		embResult.Strategy = "hybrid (consensus)"
+		embResult.Details["hybrid_mode"] = HybridModeConsensus
+		embResult.Details["decision"] = "embedding did not match; LLM judge skipped"
+		return embResult, nil
+	}
+
+	llmResult, err := m.llmMatcher.Match(ctx, actual, exp)
+	if err != nil {
+		// Without the judge there is no consensus to report
+		return nil, fmt.Errorf("LLM judge failed in consensus mode: %w", err)
+	}
+
+	result := &MatchResult{
+		Matched:    embResult.Matched && llmResult.Matched,
+		Confidence: min(embResult.Confidence, llmResult.Confidence),
+		Strategy:   "hybrid (consensus)",
+		Details: map[string]interface{}{
+			"hybrid_mode":          HybridModeConsensus,
+			"embedding_matched":    embResult.Matched,
+			"embedding_confidence": embResult.Confidence,
+			"llm_matched":          llmResult.Matched,
+			"llm_confidence":       llmResult.Confidence,
+			"judge_response":       llmResult.Details["judge_response"],
+		},
+	}
+
+	if result.Matched {
+		result.Explanation = "embedding and LLM judge both matched"
+		result.Details["decision"] = "consensus: both matched"
+	} else {
+		result.Explanation = fmt.Sprintf("embedding and LLM judge disagree: embedding matched (%.2f) but LLM judge did not: %s",
+			embResult.Confidence, llmResult.Explanation)
+		result.Details["decision"] = "disagreement: embedding matched, LLM judge rejected"
+	}
+
+	return result, nil
+}
+
 // Name returns the matcher name
 func (m *HybridMatcher) Name() string {
 	return MatcherStrategyHybrid
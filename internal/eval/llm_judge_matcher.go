@@ -2,31 +2,62 @@ package eval
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	agk "github.com/agenticgokit/agenticgokit/v1beta"
 )
 
+// JudgeAgent is the part of an agent the LLM judge relies on.
+// agk.Agent satisfies it; tests can substitute a fake with canned verdicts.
+type JudgeAgent interface {
+	Initialize(ctx context.Context) error
+	RunStream(ctx context.Context, input string, opts ...agk.StreamOption) (agk.Stream, error)
+	Cleanup(ctx context.Context) error
+}
+
 // LLMJudgeMatcher uses an LLM to evaluate semantic similarity
 type LLMJudgeMatcher struct {
 	config *SemanticConfig
-	agent  agk.Agent
+	agent  JudgeAgent
+
+	// Progress reporting while the judge streams (nil disables it)
+	progress io.Writer
+	verbose  bool // Echo judge tokens instead of showing a spinner
+}
+
+// setProgress enables streaming feedback on w
+func (m *LLMJudgeMatcher) setProgress(w io.Writer, verbose bool) {
+	m.progress = w
+	m.verbose = verbose
 }
 
 // NewLLMJudgeMatcher creates a new LLM judge matcher
 func NewLLMJudgeMatcher(config *SemanticConfig) (*LLMJudgeMatcher, error) {
+	return NewLLMJudgeMatcherWithAgent(config, nil)
+}
+
+// NewLLMJudgeMatcherWithAgent creates an LLM judge matcher that asks agent
+// for verdicts. A nil agent creates a chat agent from config.LLM.
+func NewLLMJudgeMatcherWithAgent(config *SemanticConfig, agent JudgeAgent) (*LLMJudgeMatcher, error) {
 	// Validate LLM config
 	if config.LLM == nil {
 		return nil, fmt.Errorf("LLM configuration required for llm-judge strategy")
 	}
 
-	// Create judge agent using AgenticGoKit
-	agent, err := createJudgeAgent(config.LLM)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create judge agent: %w", err)
+	if agent == nil {
+		// Create judge agent using AgenticGoKit
+		chatAgent, err := createJudgeAgent(config.LLM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create judge agent: %w", err)
+		}
+		agent = chatAgent
 	}
 
 	return &LLMJudgeMatcher{
@@ -65,21 +96,32 @@ func (m *LLMJudgeMatcher) Match(ctx context.Context, actual string, exp Expectat
 	// Delta chunks (type="delta"): incremental text in Delta field
 	// Text chunks (type="text"): complete text in Content field
 	var response strings.Builder
+	progress := m.startProgress()
 	for chunk := range stream.Chunks() {
 		// Prefer Delta for incremental streaming, fallback to Content for text chunks
-		if chunk.Delta != "" {
-			response.WriteString(chunk.Delta)
-		} else if chunk.Content != "" {
-			response.WriteString(chunk.Content)
+		text := chunk.Delta
+		if text == "" {
+			text = chunk.Content
+		}
+		if text != "" {
+			response.WriteString(text)
+			progress.token(text)
 		}
 	}
+	streamedTokens := progress.stop()
 
 	// Wait for stream completion and check for errors
-	_, err = stream.Wait()
+	result, err := stream.Wait()
 	if err != nil {
 		return nil, fmt.Errorf("stream error: %w", err)
 	}
 
+	// Prefer the provider's usage; fall back to the number of streamed chunks
+	judgeTokens, estimated := streamedTokens, true
+	if result != nil && result.TokensUsed > 0 {
+		judgeTokens, estimated = result.TokensUsed, false
+	}
+
 	// Parse response
 	responseText := response.String()
 	log.Printf("[LLM Judge] Final response (%d bytes): %q", len(responseText), responseText)
@@ -91,13 +133,87 @@ func (m *LLMJudgeMatcher) Match(ctx context.Context, actual string, exp Expectat
 		Strategy:    "llm-judge",
 		Explanation: explanation,
 		Details: map[string]interface{}{
-			"judge_response": responseText,
-			"model":          m.config.LLM.Model,
-			"provider":       m.config.LLM.Provider,
+			"judge_response":         responseText,
+			"judge_tokens":           judgeTokens,
+			"judge_tokens_estimated": estimated,
+			"model":                  m.config.LLM.Model,
+			"provider":               m.config.LLM.Provider,
 		},
 	}, nil
 }
 
+// judgeProgress shows feedback while the judge response streams in.
+// In verbose mode tokens are echoed as they arrive; otherwise a spinner
+// with a running token count is redrawn until stop is called.
+type judgeProgress struct {
+	w       io.Writer
+	verbose bool
+
+	mu     sync.Mutex
+	tokens int
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// startProgress begins progress reporting for one judge call
+func (m *LLMJudgeMatcher) startProgress() *judgeProgress {
+	p := &judgeProgress{w: m.progress, verbose: m.verbose, done: make(chan struct{})}
+	if p.w == nil {
+		return p
+	}
+
+	if p.verbose {
+		fmt.Fprint(p.w, "  [LLM Judge] ")
+		return p
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			select {
+			case <-p.done:
+				fmt.Fprint(p.w, "\r\033[K")
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				tokens := p.tokens
+				p.mu.Unlock()
+				fmt.Fprintf(p.w, "\r%s Judging... %d tokens", spinnerFrames[frame%len(spinnerFrames)], tokens)
+			}
+		}
+	}()
+	return p
+}
+
+// token records a streamed chunk
+func (p *judgeProgress) token(text string) {
+	p.mu.Lock()
+	p.tokens++
+	p.mu.Unlock()
+
+	if p.w != nil && p.verbose {
+		fmt.Fprint(p.w, text)
+	}
+}
+
+// stop ends progress reporting and returns the number of streamed chunks
+func (p *judgeProgress) stop() int {
+	if p.w != nil {
+		if p.verbose {
+			fmt.Fprintln(p.w)
+		} else {
+			close(p.done)
+			p.wg.Wait()
+		}
+	}
+	return p.tokens
+}
+
 // Name returns the matcher name
 func (m *LLMJudgeMatcher) Name() string {
 	return MatcherStrategyLLMJudge
@@ -108,7 +224,22 @@ func (m *LLMJudgeMatcher) buildJudgePrompt(actual string, exp Expectation) strin
 	template := m.config.JudgePrompt
 
 	// Use default template if none provided
-	if template == "" {
+	if template == "" && m.config.VerdictFormat == VerdictFormatJSON {
+		template = `You are evaluating if an AI system's output matches the expected criteria.
+
+Expected criteria: The output should contain one or more of these concepts:
+{expected}
+
+Actual output:
+{actual}
+
+Does the actual output satisfy the expected criteria? Consider semantic meaning, not just exact wording.
+Respond with ONLY a JSON object, no other text:
+
+{"verdict": "YES" or "NO", "confidence": <number 0.0-1.0>, "reasoning": "<brief explanation>"}
+
+Example: {"verdict": "YES", "confidence": 0.95, "reasoning": "The output clearly addresses all expected concepts"}`
+	} else if template == "" {
 		template = `You are evaluating if an AI system's output matches the expected criteria.
 
 Expected criteria: The output should contain one or more of these concepts:
@@ -141,8 +272,58 @@ Example: YES 0.95 - The output clearly addresses all expected concepts`
 	return prompt
 }
 
-// parseJudgment parses the LLM's response
+// parseJudgment parses the LLM's response according to the configured verdict format
 func (m *LLMJudgeMatcher) parseJudgment(response string) (bool, float64, string) {
+	if m.config.VerdictFormat == VerdictFormatJSON {
+		if matched, confidence, explanation, ok := parseJSONJudgment(response); ok {
+			return matched, confidence, explanation
+		}
+		log.Printf("[LLM Judge] Response is not a valid JSON verdict, falling back to text parsing")
+	}
+	return parseTextJudgment(response)
+}
+
+// judgeVerdict is the reply expected from the judge in json verdict format
+type judgeVerdict struct {
+	Verdict    string   `json:"verdict"`
+	Confidence *float64 `json:"confidence"`
+	Reasoning  string   `json:"reasoning"`
+}
+
+// parseJSONJudgment parses a {"verdict", "confidence", "reasoning"} reply.
+// The object may be wrapped in other text such as a markdown code fence.
+func parseJSONJudgment(response string) (bool, float64, string, bool) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return false, 0, "", false
+	}
+
+	var verdict judgeVerdict
+	if err := json.Unmarshal([]byte(response[start:end+1]), &verdict); err != nil || verdict.Verdict == "" {
+		return false, 0, "", false
+	}
+
+	var matched bool
+	switch strings.ToUpper(strings.TrimSpace(verdict.Verdict)) {
+	case "YES", "PASS", "TRUE", "MATCH":
+		matched = true
+	}
+
+	// Same defaults as the text format when no confidence is given
+	confidence := 0.1
+	if matched {
+		confidence = 0.9
+	}
+	if verdict.Confidence != nil {
+		confidence = *verdict.Confidence
+	}
+
+	return matched, confidence, verdict.Reasoning, true
+}
+
+// parseTextJudgment parses a "YES|NO <confidence> - <explanation>" reply
+func parseTextJudgment(response string) (bool, float64, string) {
 	response = strings.TrimSpace(response)
 
 	// Parse response format: "YES 0.95 - Explanation..."
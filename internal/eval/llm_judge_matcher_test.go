@@ -0,0 +1,121 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+)
+
+// fakeJudge is a JudgeAgent that streams a canned reply
+type fakeJudge struct {
+	reply   string
+	err     error
+	prompts []string
+}
+
+func (f *fakeJudge) Initialize(ctx context.Context) error { return nil }
+
+func (f *fakeJudge) Cleanup(ctx context.Context) error { return nil }
+
+func (f *fakeJudge) RunStream(ctx context.Context, input string, _ ...agk.StreamOption) (agk.Stream, error) {
+	f.prompts = append(f.prompts, input)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	stream, writer := agk.NewStream(ctx, &agk.StreamMetadata{AgentName: "fake-judge"})
+	go func() {
+		for _, word := range strings.SplitAfter(f.reply, " ") {
+			_ = writer.Write(&agk.StreamChunk{Type: agk.ChunkTypeDelta, Delta: word})
+		}
+		_ = writer.Close()
+	}()
+	return stream, nil
+}
+
+func newTestJudge(t *testing.T, reply, format string) (*LLMJudgeMatcher, *fakeJudge) {
+	t.Helper()
+	judge := &fakeJudge{reply: reply}
+	config := &SemanticConfig{
+		Strategy:      MatcherStrategyLLMJudge,
+		LLM:           &LLMConfig{Provider: "fake", Model: "fake-model"},
+		VerdictFormat: format,
+	}
+	matcher, err := NewLLMJudgeMatcherWithAgent(config, judge)
+	if err != nil {
+		t.Fatalf("NewLLMJudgeMatcherWithAgent() error = %v", err)
+	}
+	return matcher, judge
+}
+
+func TestLLMJudgeMatcherMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		reply          string
+		format         string
+		wantMatched    bool
+		wantConfidence float64
+	}{
+		{
+			name:           "text yes with confidence",
+			reply:          "YES 0.95 - mentions Paris",
+			wantMatched:    true,
+			wantConfidence: 0.95,
+		},
+		{
+			name:           "text no without confidence",
+			reply:          "NO - unrelated answer",
+			wantMatched:    false,
+			wantConfidence: 0.1,
+		},
+		{
+			name:           "json verdict in code fence",
+			reply:          "```json\n{\"verdict\": \"YES\", \"confidence\": 0.8, \"reasoning\": \"correct\"}\n```",
+			format:         VerdictFormatJSON,
+			wantMatched:    true,
+			wantConfidence: 0.8,
+		},
+		{
+			name:           "json format falls back to text",
+			reply:          "NO 0.3 - wrong city",
+			format:         VerdictFormatJSON,
+			wantMatched:    false,
+			wantConfidence: 0.3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, judge := newTestJudge(t, tt.reply, tt.format)
+
+			result, err := matcher.Match(context.Background(), "The capital is Paris", Expectation{Type: "semantic", Value: "Paris"})
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %v, want %v", result.Matched, tt.wantMatched)
+			}
+			if result.Confidence != tt.wantConfidence {
+				t.Errorf("Confidence = %v, want %v", result.Confidence, tt.wantConfidence)
+			}
+			if result.Details["judge_response"] != tt.reply {
+				t.Errorf("judge_response = %q, want %q", result.Details["judge_response"], tt.reply)
+			}
+			if len(judge.prompts) != 1 || !strings.Contains(judge.prompts[0], "The capital is Paris") {
+				t.Errorf("judge prompt does not contain the actual output: %q", judge.prompts)
+			}
+		})
+	}
+}
+
+func TestLLMJudgeMatcherStreamError(t *testing.T) {
+	matcher, judge := newTestJudge(t, "", "")
+	judge.err = errors.New("provider unavailable")
+
+	if _, err := matcher.Match(context.Background(), "anything", Expectation{Type: "semantic", Value: "x"}); err == nil {
+		t.Error("Match() error = nil, want error")
+	}
+}
@@ -0,0 +1,150 @@
+package eval
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultMatchCacheDir is where cached semantic match results are stored
+	DefaultMatchCacheDir = ".agk/cache/matches"
+	// DefaultMatchCacheTTL is how long cached results stay valid
+	DefaultMatchCacheTTL = 24 * time.Hour
+)
+
+// MatchCache stores semantic match results on disk so re-running a suite
+// doesn't re-invoke judges and embedding models for identical inputs
+type MatchCache struct {
+	dir string
+	ttl time.Duration // Zero keeps entries forever
+}
+
+// NewMatchCache creates a cache rooted at dir
+func NewMatchCache(dir string, ttl time.Duration) *MatchCache {
+	return &MatchCache{dir: dir, ttl: ttl}
+}
+
+// matchCacheEntry is the on-disk format of a cached result
+type matchCacheEntry struct {
+	CreatedAt time.Time   `json:"created_at"`
+	Result    MatchResult `json:"result"`
+}
+
+// matchCacheKey holds everything that can change a semantic match outcome
+type matchCacheKey struct {
+	Strategy       string   `json:"strategy"`
+	LLMProvider    string   `json:"llm_provider,omitempty"`
+	LLMModel       string   `json:"llm_model,omitempty"`
+	LLMBaseURL     string   `json:"llm_base_url,omitempty"`
+	LLMTemperature float64  `json:"llm_temperature,omitempty"`
+	LLMMaxTokens   int      `json:"llm_max_tokens,omitempty"`
+	EmbedProvider  string   `json:"embed_provider,omitempty"`
+	EmbedModel     string   `json:"embed_model,omitempty"`
+	EmbedBaseURL   string   `json:"embed_base_url,omitempty"`
+	Threshold      float64  `json:"threshold"`
+	JudgePrompt    string   `json:"judge_prompt,omitempty"`
+	HybridMode     string   `json:"hybrid_mode,omitempty"`
+	VerdictFormat  string   `json:"verdict_format,omitempty"`
+	Actual         string   `json:"actual"`
+	Expected       string   `json:"expected,omitempty"`
+	ExpectedValues []string `json:"expected_values,omitempty"`
+}
+
+// key hashes the inputs of a match
+func (c *MatchCache) key(config *SemanticConfig, actual string, exp Expectation) string {
+	k := matchCacheKey{
+		Strategy:       config.Strategy,
+		Threshold:      config.Threshold,
+		JudgePrompt:    config.JudgePrompt,
+		HybridMode:     config.HybridMode,
+		VerdictFormat:  config.VerdictFormat,
+		Actual:         actual,
+		Expected:       exp.Value,
+		ExpectedValues: exp.Values,
+	}
+	if config.LLM != nil {
+		k.LLMProvider, k.LLMModel, k.LLMBaseURL = config.LLM.Provider, config.LLM.Model, config.LLM.BaseURL
+		k.LLMTemperature, k.LLMMaxTokens = config.LLM.Temperature, config.LLM.MaxTokens
+	}
+	if config.Embedding != nil {
+		k.EmbedProvider, k.EmbedModel, k.EmbedBaseURL = config.Embedding.Provider, config.Embedding.Model, config.Embedding.BaseURL
+	}
+
+	data, _ := json.Marshal(k)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached result for key, if present and fresh
+func (c *MatchCache) get(key string) (*MatchResult, bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry matchCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl {
+		return nil, false
+	}
+	return &entry.Result, true
+}
+
+// put stores a result under key
+func (c *MatchCache) put(key string, result *MatchResult) error {
+	if err := os.MkdirAll(c.dir, 0750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(matchCacheEntry{CreatedAt: time.Now(), Result: *result})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, key+".json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// cachedMatcher serves semantic matches from a MatchCache, falling back to
+// the wrapped matcher on a miss
+type cachedMatcher struct {
+	inner  MatcherInterface
+	config *SemanticConfig
+	cache  *MatchCache
+}
+
+// Match returns a cached result when available. Cached results carry
+// Details["cached"] = true so reports can tell them apart.
+func (m *cachedMatcher) Match(ctx context.Context, actual string, exp Expectation) (*MatchResult, error) {
+	key := m.cache.key(m.config, actual, exp)
+	if result, ok := m.cache.get(key); ok {
+		if result.Details == nil {
+			result.Details = map[string]interface{}{}
+		}
+		result.Details["cached"] = true
+		return result, nil
+	}
+
+	result, err := m.inner.Match(ctx, actual, exp)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.cache.put(key, result); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+	}
+	return result, nil
+}
+
+// Name returns the wrapped matcher's name
+func (m *cachedMatcher) Name() string {
+	return m.inner.Name()
+}
@@ -0,0 +1,26 @@
+package eval
+
+import "testing"
+
+func TestMatchCacheKeyIncludesBaseURL(t *testing.T) {
+	cache := NewMatchCache(t.TempDir(), DefaultMatchCacheTTL)
+	exp := Expectation{Value: "Paris"}
+	config := func(llmURL, embedURL string) *SemanticConfig {
+		return &SemanticConfig{
+			Strategy:  "hybrid",
+			LLM:       &LLMConfig{Provider: "ollama", Model: "llama3", BaseURL: llmURL},
+			Embedding: &EmbeddingConfig{Provider: "ollama", Model: "nomic-embed-text", BaseURL: embedURL},
+		}
+	}
+
+	base := cache.key(config("http://localhost:11434", "http://localhost:11434"), "Paris", exp)
+	if again := cache.key(config("http://localhost:11434", "http://localhost:11434"), "Paris", exp); again != base {
+		t.Errorf("key() = %s for the same inputs, want %s", again, base)
+	}
+	if key := cache.key(config("http://gpu-box:11434", "http://localhost:11434"), "Paris", exp); key == base {
+		t.Error("key() ignores the judge base_url")
+	}
+	if key := cache.key(config("http://localhost:11434", "http://gpu-box:11434"), "Paris", exp); key == base {
+		t.Error("key() ignores the embedding base_url")
+	}
+}
@@ -3,6 +3,7 @@ package eval
 import (
 	"context"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 )
@@ -28,6 +29,12 @@ type MatcherInterface interface {
 // MatcherFactory creates matchers based on configuration
 type MatcherFactory struct {
 	semanticConfig *SemanticConfig
+	progress       io.Writer // Where LLM judges report streaming progress
+	verbose        bool
+	cache          *MatchCache // Semantic result cache (nil disables caching)
+
+	judgeTemperature *float64 // Judge LLM temperature override (nil keeps the suite's)
+	judgeMaxTokens   int      // Judge LLM max_tokens override (0 keeps the suite's)
 }
 
 // NewMatcherFactory creates a new matcher factory
@@ -35,6 +42,27 @@ func NewMatcherFactory(config *SemanticConfig) *MatcherFactory {
 	return &MatcherFactory{semanticConfig: config}
 }
 
+// SetProgress makes LLM judge matchers report streaming progress on w.
+// With verbose set the judge's tokens are echoed instead of a spinner.
+func (f *MatcherFactory) SetProgress(w io.Writer, verbose bool) {
+	f.progress = w
+	f.verbose = verbose
+}
+
+// SetCache serves semantic matches from cache for suites that enable
+// semantic.cache. A nil cache disables caching.
+func (f *MatcherFactory) SetCache(cache *MatchCache) {
+	f.cache = cache
+}
+
+// SetJudgeOverrides overrides the temperature and max tokens of every judge
+// LLM, taking precedence over suite and test configuration. A nil temperature
+// or zero maxTokens leaves that setting alone.
+func (f *MatcherFactory) SetJudgeOverrides(temperature *float64, maxTokens int) {
+	f.judgeTemperature = temperature
+	f.judgeMaxTokens = maxTokens
+}
+
 // CreateMatcher creates appropriate matcher for expectation type
 func (f *MatcherFactory) CreateMatcher(exp Expectation) (MatcherInterface, error) {
 	switch exp.Type {
@@ -44,9 +72,16 @@ func (f *MatcherFactory) CreateMatcher(exp Expectation) (MatcherInterface, error
 		return NewContainsMatcher(), nil
 	case "regex":
 		return NewRegexMatcher(), nil
+	case "fuzzy":
+		return NewFuzzyMatcher(), nil
 	case "semantic":
 		return f.createSemanticMatcher(exp)
+	case "command":
+		return NewCommandMatcher(exp)
 	default:
+		if constructor, ok := lookupMatcher(exp.Type); ok {
+			return constructor(exp)
+		}
 		return nil, fmt.Errorf("unknown expectation type: %s", exp.Type)
 	}
 }
@@ -57,25 +92,45 @@ func (f *MatcherFactory) createSemanticMatcher(exp Expectation) (MatcherInterfac
 	config := f.mergeSemanticConfig(exp)
 
 	// Determine strategy
-	strategy := MatcherStrategyLLMJudge // default
-	if config.Strategy != "" {
-		strategy = config.Strategy
+	if config.Strategy == "" {
+		config.Strategy = MatcherStrategyLLMJudge // default
 	}
 
 	// Create appropriate matcher
-	switch strategy {
+	var matcher MatcherInterface
+	switch config.Strategy {
 	case MatcherStrategyEmbedding:
-		return NewEmbeddingMatcher(config)
+		embedding, err := NewEmbeddingMatcher(config)
+		if err != nil {
+			return nil, err
+		}
+		matcher = embedding
 	case MatcherStrategyLLMJudge:
-		return NewLLMJudgeMatcher(config)
+		judge, err := NewLLMJudgeMatcher(config)
+		if err != nil {
+			return nil, err
+		}
+		judge.setProgress(f.progress, f.verbose)
+		matcher = judge
 	case MatcherStrategyHybrid:
-		return NewHybridMatcher(config)
+		hybrid, err := NewHybridMatcher(config)
+		if err != nil {
+			return nil, err
+		}
+		hybrid.llmMatcher.setProgress(f.progress, f.verbose)
+		matcher = hybrid
 	default:
-		return nil, fmt.Errorf("unknown semantic strategy: %s", strategy)
+		return nil, fmt.Errorf("unknown semantic strategy: %s", config.Strategy)
+	}
+
+	if f.cache != nil && config.Cache {
+		return &cachedMatcher{inner: matcher, config: config, cache: f.cache}, nil
 	}
+	return matcher, nil
 }
 
-// mergeSemanticConfig merges global semantic config with test-specific overrides
+// mergeSemanticConfig merges global semantic config with test-specific
+// overrides, on top of the installed SemanticDefaults
 func (f *MatcherFactory) mergeSemanticConfig(exp Expectation) *SemanticConfig {
 	// Start with global config or defaults
 	config := &SemanticConfig{
@@ -88,6 +143,9 @@ func (f *MatcherFactory) mergeSemanticConfig(exp Expectation) *SemanticConfig {
 		config.Strategy = f.semanticConfig.Strategy
 		config.Threshold = f.semanticConfig.Threshold
 		config.JudgePrompt = f.semanticConfig.JudgePrompt
+		config.HybridMode = f.semanticConfig.HybridMode
+		config.VerdictFormat = f.semanticConfig.VerdictFormat
+		config.Cache = f.semanticConfig.Cache
 
 		if f.semanticConfig.LLM != nil {
 			llmCopy := *f.semanticConfig.LLM
@@ -113,6 +171,14 @@ func (f *MatcherFactory) mergeSemanticConfig(exp Expectation) *SemanticConfig {
 		config.JudgePrompt = exp.JudgePrompt
 	}
 
+	if exp.HybridMode != "" {
+		config.HybridMode = exp.HybridMode
+	}
+
+	if exp.VerdictFormat != "" {
+		config.VerdictFormat = exp.VerdictFormat
+	}
+
 	if exp.LLM != nil {
 		config.LLM = exp.LLM
 	}
@@ -121,6 +187,21 @@ func (f *MatcherFactory) mergeSemanticConfig(exp Expectation) *SemanticConfig {
 		config.Embedding = exp.Embedding
 	}
 
+	// Fill in what neither the suite nor the test configured
+	currentSemanticDefaults().fill(config)
+
+	// Apply runtime judge overrides to a copy so the suite is left untouched
+	if config.LLM != nil && (f.judgeTemperature != nil || f.judgeMaxTokens > 0) {
+		llmCopy := *config.LLM
+		if f.judgeTemperature != nil {
+			llmCopy.Temperature = *f.judgeTemperature
+		}
+		if f.judgeMaxTokens > 0 {
+			llmCopy.MaxTokens = f.judgeMaxTokens
+		}
+		config.LLM = &llmCopy
+	}
+
 	return config
 }
 
@@ -135,26 +216,41 @@ func NewExactMatcher() *ExactMatcher {
 	return &ExactMatcher{}
 }
 
+// Match succeeds when actual equals exp.Value or any of exp.Values
 func (m *ExactMatcher) Match(ctx context.Context, actual string, exp Expectation) (*MatchResult, error) {
-	expected := exp.Value
-	if expected == "" && len(exp.Values) > 0 {
-		expected = exp.Values[0]
+	var candidates []string
+	if exp.Value != "" {
+		candidates = append(candidates, exp.Value)
 	}
-
-	matched := actual == expected
-	confidence := 1.0
-	if !matched {
-		confidence = 0.0
+	candidates = append(candidates, exp.Values...)
+
+	for _, expected := range candidates {
+		if actual == expected {
+			return &MatchResult{
+				Matched:     true,
+				Confidence:  1.0,
+				Strategy:    "exact",
+				Explanation: "exact match",
+				Details: map[string]interface{}{
+					"matched_value": expected,
+				},
+			}, nil
+		}
 	}
 
-	explanation := "exact match"
-	if !matched {
-		explanation = fmt.Sprintf("expected exact match: %q, got: %q", expected, actual)
+	var explanation string
+	switch len(candidates) {
+	case 0:
+		explanation = fmt.Sprintf("no expected value given, got: %q", actual)
+	case 1:
+		explanation = fmt.Sprintf("expected exact match: %q, got: %q", candidates[0], actual)
+	default:
+		explanation = fmt.Sprintf("expected exact match with one of %q, got: %q", candidates, actual)
 	}
 
 	return &MatchResult{
-		Matched:     matched,
-		Confidence:  confidence,
+		Matched:     false,
+		Confidence:  0.0,
 		Strategy:    "exact",
 		Explanation: explanation,
 	}, nil
@@ -3,7 +3,11 @@ package eval
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
+	"github.com/agenticgokit/agk/internal/audit"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,6 +23,11 @@ func ParseTestFile(filePath string) (*TestSuite, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	// Expand data-driven tests into one test per data row
+	if err := expandDataTests(&suite, filepath.Dir(filePath)); err != nil {
+		return nil, fmt.Errorf("failed to load test data: %w", err)
+	}
+
 	// Validate suite
 	if err := validateSuite(&suite); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -37,8 +46,9 @@ func validateSuite(suite *TestSuite) error {
 		return fmt.Errorf("target type is required")
 	}
 
-	if suite.Target.Type == "http" && suite.Target.URL == "" {
-		return fmt.Errorf("target URL is required for HTTP targets")
+	// The target type's constructor checks the rest of the block
+	if _, err := NewTarget(suite.Target, 0); err != nil {
+		return err
 	}
 
 	if len(suite.Tests) == 0 {
@@ -60,8 +70,8 @@ func validateSuite(suite *TestSuite) error {
 		// Validate expectation based on type
 		switch test.Expect.Type {
 		case "exact":
-			if test.Expect.Value == "" {
-				return fmt.Errorf("test '%s': expect.value is required for 'exact' type", test.Name)
+			if test.Expect.Value == "" && len(test.Expect.Values) == 0 {
+				return fmt.Errorf("test '%s': expect.value or expect.values is required for 'exact' type", test.Name)
 			}
 		case "contains":
 			if len(test.Expect.Values) == 0 {
@@ -79,6 +89,23 @@ func validateSuite(suite *TestSuite) error {
 			if err := validateSemanticExpectation(&test.Expect, suite.Semantic); err != nil {
 				return fmt.Errorf("test '%s': %w", test.Name, err)
 			}
+		case "fuzzy":
+			if test.Expect.Value == "" && len(test.Expect.Values) == 0 {
+				return fmt.Errorf("test '%s': expect.value or expect.values is required for 'fuzzy' type", test.Name)
+			}
+			if t := test.Expect.Threshold; t != nil && (*t < 0 || *t > 1) {
+				return fmt.Errorf("test '%s': expect.threshold must be between 0 and 1 for 'fuzzy' type", test.Name)
+			}
+		case "command":
+			if len(test.Expect.Command) == 0 {
+				return fmt.Errorf("test '%s': expect.command is required for 'command' type", test.Name)
+			}
+		}
+
+		if trace := test.Expect.Trace; trace != nil && len(trace.ReasoningPath) > 0 {
+			if _, err := audit.ParsePathPattern(strings.Join(trace.ReasoningPath, ",")); err != nil {
+				return fmt.Errorf("test '%s': expect.trace.reasoning_path: %w", test.Name, err)
+			}
 		}
 	}
 
@@ -95,31 +122,120 @@ func validateSemanticExpectation(exp *Expectation, globalConfig *SemanticConfig)
 		strategy = globalConfig.Strategy
 	}
 
+	format := exp.VerdictFormat
+	if format == "" && globalConfig != nil {
+		format = globalConfig.VerdictFormat
+	}
+	if format != "" && format != VerdictFormatText && format != VerdictFormatJSON {
+		return fmt.Errorf("unknown verdict format: %s (valid: text, json)", format)
+	}
+
+	// Configs may come from the test, the suite or the installed defaults
+	defaults := currentSemanticDefaults()
+	hasLLM := exp.LLM != nil || (globalConfig != nil && globalConfig.LLM != nil) || defaults.hasLLM()
+	hasEmb := exp.Embedding != nil || (globalConfig != nil && globalConfig.Embedding != nil) || defaults.hasEmbedding()
+
 	// Validate based on strategy
 	switch strategy {
 	case "llm-judge":
 		// Need LLM config from somewhere
-		if exp.LLM == nil && (globalConfig == nil || globalConfig.LLM == nil) {
-			return fmt.Errorf("LLM configuration required for llm-judge strategy (provide in test or global semantic config)")
+		if !hasLLM {
+			return fmt.Errorf("LLM configuration required for llm-judge strategy (provide in test or global semantic config, or set a default in ~/.agk/%s)", DefaultsFileName)
 		}
 	case "embedding":
 		// Need embedding config from somewhere
-		if exp.Embedding == nil && (globalConfig == nil || globalConfig.Embedding == nil) {
-			return fmt.Errorf("embedding configuration required for embedding strategy (provide in test or global semantic config)")
+		if !hasEmb {
+			return fmt.Errorf("embedding configuration required for embedding strategy (provide in test or global semantic config, or set a default in ~/.agk/%s)", DefaultsFileName)
+		}
+		if err := validateEmbeddingProvider(exp, globalConfig); err != nil {
+			return err
 		}
 	case "hybrid":
 		// Need both configs
-		hasLLM := exp.LLM != nil || (globalConfig != nil && globalConfig.LLM != nil)
-		hasEmb := exp.Embedding != nil || (globalConfig != nil && globalConfig.Embedding != nil)
 		if !hasLLM {
 			return fmt.Errorf("LLM configuration required for hybrid strategy")
 		}
 		if !hasEmb {
 			return fmt.Errorf("embedding configuration required for hybrid strategy")
 		}
+		if err := validateEmbeddingProvider(exp, globalConfig); err != nil {
+			return err
+		}
+		mode := exp.HybridMode
+		if mode == "" && globalConfig != nil {
+			mode = globalConfig.HybridMode
+		}
+		if mode != "" && mode != HybridModeBlend && mode != HybridModeConsensus {
+			return fmt.Errorf("unknown hybrid mode: %s (valid: blend, consensus)", mode)
+		}
 	default:
 		return fmt.Errorf("unknown semantic strategy: %s (valid: llm-judge, embedding, hybrid)", strategy)
 	}
 
 	return nil
 }
+
+// validateEmbeddingProvider checks that the embedding config the test ends
+// up with names a supported provider. The model may be left out; the
+// provider's default is used then.
+func validateEmbeddingProvider(exp *Expectation, globalConfig *SemanticConfig) error {
+	embedding := NewMatcherFactory(globalConfig).mergeSemanticConfig(*exp).Embedding
+	if embedding == nil {
+		return nil
+	}
+	if _, ok := defaultEmbeddingModels[embedding.Provider]; !ok {
+		return fmt.Errorf("unsupported embedding provider: %q (valid: ollama, openai)", embedding.Provider)
+	}
+	return nil
+}
+
+// LintSuite returns warnings for tests whose expectations would match any output.
+// Such tests pass vacuously and give false confidence.
+func LintSuite(suite *TestSuite) []string {
+	var warnings []string
+	for _, test := range suite.Tests {
+		if reason := vacuousExpectation(test.Expect); reason != "" {
+			warnings = append(warnings, fmt.Sprintf("test '%s': %s", test.Name, reason))
+		}
+	}
+	return warnings
+}
+
+// vacuousExpectation reports why an expectation would match any output, or "" if it wouldn't
+func vacuousExpectation(exp Expectation) string {
+	switch exp.Type {
+	case "contains":
+		for _, value := range exp.Values {
+			if strings.TrimSpace(value) == "" {
+				return "expect.values contains an empty value, which matches any output"
+			}
+		}
+		if len(exp.Values) == 0 && strings.TrimSpace(exp.Value) == "" {
+			return "contains expectation has no values and matches any output"
+		}
+	case "regex":
+		pattern := exp.Pattern
+		if pattern == "" {
+			pattern = exp.Value
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return ""
+		}
+		// A pattern matching both empty and arbitrary text matches everything
+		if re.MatchString("") && re.MatchString("\x00arbitrary output\nline two") {
+			return fmt.Sprintf("regex pattern %q matches any output", pattern)
+		}
+	case "fuzzy":
+		if exp.Threshold != nil && *exp.Threshold <= 0 {
+			return "fuzzy threshold of 0 matches any output"
+		}
+	case "semantic":
+		for _, value := range exp.Values {
+			if strings.TrimSpace(value) == "" {
+				return "expect.values contains an empty value"
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+)
+
+// TestStability summarizes how one test fared across repeated runs of a suite
+type TestStability struct {
+	TestName string
+	Runs     int
+	Passes   int
+	// Semantic tests report the mean and standard deviation of their
+	// confidence, which show how close to the threshold a test wobbles
+	Semantic         bool
+	MeanConfidence   float64
+	ConfidenceStdDev float64
+}
+
+// PassRate returns the share of runs the test passed, as a percentage
+func (s TestStability) PassRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Passes) / float64(s.Runs) * 100
+}
+
+// Flaky reports whether the test both passed and failed across runs
+func (s TestStability) Flaky() bool {
+	return s.Passes > 0 && s.Passes < s.Runs
+}
+
+// RunRepeated runs the suite n times to measure how stable each test is.
+// The returned results hold every run's test results, counted together,
+// with each test's pass rate and confidence spread in Stability. Sampled
+// and shuffled suites select the same tests on every run, and the match
+// cache is bypassed so every run is judged afresh. With n of one or less it
+// is RunContext.
+func (r *Runner) RunRepeated(ctx context.Context, suite *TestSuite, n int) (*SuiteResults, error) {
+	if n <= 1 {
+		return r.RunContext(ctx, suite)
+	}
+
+	// Reuse the first run's seed so every run selects the same tests
+	seed := r.config.Seed
+	defer func() { r.config.Seed = seed }()
+
+	// Cached verdicts would score a repeated output the same every time,
+	// hiding the spread the runs are meant to measure
+	noCache := r.config.NoCache
+	defer func() { r.config.NoCache = noCache }()
+	if suite.Semantic != nil && suite.Semantic.Cache && !noCache && !r.config.Quiet {
+		fmt.Fprintln(os.Stderr, "ℹ️  semantic.cache is ignored with --repeat so every run is judged afresh")
+	}
+	r.config.NoCache = true
+
+	var combined *SuiteResults
+	for attempt := 1; attempt <= n; attempt++ {
+		if !r.config.Quiet {
+			fmt.Fprintf(os.Stderr, "🔁 Run %d/%d\n", attempt, n)
+		}
+		results, err := r.RunContext(ctx, suite)
+		if err != nil {
+			return nil, fmt.Errorf("run %d of %d: %w", attempt, n, err)
+		}
+		for i := range results.Results {
+			results.Results[i].Attempt = attempt
+		}
+
+		if combined == nil {
+			combined = results
+			combined.Repeats = n
+			combined.Planned = results.Planned * n
+			r.config.Seed = results.Seed
+		} else {
+			combined.Results = append(combined.Results, results.Results...)
+			combined.TotalTests += results.TotalTests
+			combined.PassedTests += results.PassedTests
+			combined.FailedTests += results.FailedTests
+			combined.EndTime = results.EndTime
+			combined.Interrupted = results.Interrupted
+		}
+
+		if results.Interrupted || (r.config.FailFast && results.FailedTests > 0) {
+			break
+		}
+	}
+
+	combined.Duration = combined.EndTime.Sub(combined.StartTime)
+	combined.Stability = testStability(combined.Results)
+	return combined, nil
+}
+
+// testStability aggregates results by test name, in the order tests first ran
+func testStability(results []TestResult) []TestStability {
+	var stability []TestStability
+	index := make(map[string]int)
+	confidences := make(map[string][]float64)
+	for _, result := range results {
+		i, ok := index[result.TestName]
+		if !ok {
+			i = len(stability)
+			index[result.TestName] = i
+			stability = append(stability, TestStability{TestName: result.TestName})
+		}
+		stability[i].Runs++
+		if result.Passed {
+			stability[i].Passes++
+		}
+		if isSemanticStrategy(result.MatchStrategy) {
+			stability[i].Semantic = true
+			confidences[result.TestName] = append(confidences[result.TestName], result.Confidence)
+		}
+	}
+
+	for i := range stability {
+		values := confidences[stability[i].TestName]
+		if len(values) == 0 {
+			continue
+		}
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		mean := sum / float64(len(values))
+		var squares float64
+		for _, v := range values {
+			squares += (v - mean) * (v - mean)
+		}
+		stability[i].MeanConfidence = mean
+		stability[i].ConfidenceStdDev = math.Sqrt(squares / float64(len(values)))
+	}
+	return stability
+}
@@ -0,0 +1,58 @@
+package eval
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// echoTarget answers every test with its input
+type echoTarget struct{}
+
+func (echoTarget) Invoke(ctx context.Context, inv Invocation) (*InvokeResponse, error) {
+	return &InvokeResponse{Output: inv.Input, Success: true}, nil
+}
+
+func (echoTarget) Health() error { return nil }
+
+func TestRunRepeatedBypassesCache(t *testing.T) {
+	t.Chdir(t.TempDir())
+	RegisterTarget("echo", func(TargetConfig, time.Duration) (Target, error) {
+		return echoTarget{}, nil
+	})
+	t.Cleanup(func() {
+		targetTypesMu.Lock()
+		defer targetTypesMu.Unlock()
+		delete(targetTypes, "echo")
+	})
+
+	suite := &TestSuite{
+		Name:     "repeat",
+		Target:   TargetConfig{Type: "echo"},
+		Semantic: &SemanticConfig{Cache: true},
+		Tests:    []Test{{Name: "echo", Input: "hello", Expect: Expectation{Type: "contains", Value: "hello"}}},
+	}
+	config := &RunnerConfig{Timeout: time.Second, Quiet: true}
+	runner := NewRunner(config)
+
+	results, err := runner.RunRepeated(context.Background(), suite, 2)
+	if err != nil {
+		t.Fatalf("RunRepeated() error = %v", err)
+	}
+	if results.TotalTests != 2 || !results.AllPassed() {
+		t.Errorf("RunRepeated() = %d tests, all passed %v; want 2 passing", results.TotalTests, results.AllPassed())
+	}
+	if runner.matcherFactory.cache != nil {
+		t.Error("RunRepeated() used the match cache")
+	}
+	if config.NoCache {
+		t.Error("RunRepeated() left NoCache set")
+	}
+
+	if _, err := runner.RunRepeated(context.Background(), suite, 1); err != nil {
+		t.Fatal(err)
+	}
+	if runner.matcherFactory.cache == nil {
+		t.Error("a single run didn't use the match cache")
+	}
+}
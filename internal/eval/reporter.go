@@ -6,11 +6,15 @@ import (
 	"io"
 	"strings"
 	"time"
+
+	"github.com/fatih/color"
 )
 
 // Reporter generates test reports in various formats
 type Reporter struct {
 	format string
+	quiet  bool // Leave out hints about where to look next
+	color  bool // Color console output
 }
 
 // NewReporter creates a new reporter
@@ -18,6 +22,20 @@ func NewReporter(format string) *Reporter {
 	return &Reporter{format: format}
 }
 
+// Quiet sets whether console reports leave out hints and tips, keeping
+// only the results
+func (r *Reporter) Quiet(quiet bool) *Reporter {
+	r.quiet = quiet
+	return r
+}
+
+// Color sets whether console reports use ANSI colors. Callers decide, so
+// NO_COLOR and non-terminal output are handled where the output is known.
+func (r *Reporter) Color(enabled bool) *Reporter {
+	r.color = enabled
+	return r
+}
+
 // Generate creates a report and writes it to the writer
 func (r *Reporter) Generate(results *SuiteResults, w io.Writer) error {
 	switch r.format {
@@ -48,8 +66,36 @@ func (r *Reporter) generateConsole(results *SuiteResults, w io.Writer) error {
 	fmt.Fprintf(w, "Failed:         %d ✗\n", results.FailedTests)
 	fmt.Fprintf(w, "Pass Rate:      %.1f%%\n", results.PassRate())
 	fmt.Fprintf(w, "Duration:       %s\n", formatDuration(results.Duration))
+	if note := results.SelectionNote(); note != "" {
+		fmt.Fprintf(w, "Selection:      %s\n", note)
+	}
+	if note := results.InterruptedNote(); note != "" {
+		fmt.Fprintf(w, "Status:         ⚠ %s\n", note)
+	}
+	if note := results.RepeatNote(); note != "" {
+		fmt.Fprintf(w, "Repeats:        %s\n", note)
+	}
 	fmt.Fprintf(w, "\n")
 
+	// Confidence distribution of semantic tests
+	if hist, ok := newConfidenceHistogram(results.Results); ok {
+		fmt.Fprintf(w, "Confidence (%d semantic tests):\n", hist.Total)
+		for i, count := range hist.Buckets {
+			fmt.Fprintf(w, "  %s  %-10s %d\n", hist.bucketLabel(i), generateBar(count, hist.Total, "█"), count)
+		}
+		if hist.MarginalPasses > 0 {
+			fmt.Fprintf(w, "  ⚠ %s\n", hist.marginalNote())
+		}
+		fmt.Fprintf(w, "\n")
+		// Repeated runs summarize confidence per test in the stability table
+		if len(results.Stability) == 0 {
+			r.writeConfidenceTable(results.Results, w)
+		}
+	}
+	if len(results.Stability) > 0 {
+		r.writeStabilityTable(results.Stability, w)
+	}
+
 	// Failed tests details
 	if results.FailedTests > 0 {
 		fmt.Fprintf(w, "───────────────────────────────────────────────────────────────\n")
@@ -59,7 +105,11 @@ func (r *Reporter) generateConsole(results *SuiteResults, w io.Writer) error {
 
 		for _, result := range results.Results {
 			if !result.Passed {
-				fmt.Fprintf(w, "✗ %s\n", result.TestName)
+				if result.Attempt > 0 {
+					fmt.Fprintf(w, "✗ %s (run %d)\n", result.TestName, result.Attempt)
+				} else {
+					fmt.Fprintf(w, "✗ %s\n", result.TestName)
+				}
 				fmt.Fprintf(w, "  Duration: %s\n", formatDuration(result.Duration))
 
 				// Show semantic matching details if available
@@ -73,8 +123,10 @@ func (r *Reporter) generateConsole(results *SuiteResults, w io.Writer) error {
 
 				if result.TraceID != "" {
 					fmt.Fprintf(w, "  Trace ID: %s\n", result.TraceID)
-					fmt.Fprintf(w, "  💡 View detailed trace: agk trace show %s\n", result.TraceID)
-					fmt.Fprintf(w, "  📁 Trace location: .agk/runs/%s/\n", result.TraceID)
+					if !r.quiet {
+						fmt.Fprintf(w, "  💡 View detailed trace: agk trace show %s\n", result.TraceID)
+						fmt.Fprintf(w, "  📁 Trace location: .agk/runs/%s/\n", result.TraceID)
+					}
 				}
 				fmt.Fprintf(w, "  Error: %s\n", result.ErrorMessage)
 				if result.ActualOutput != "" {
@@ -88,13 +140,18 @@ func (r *Reporter) generateConsole(results *SuiteResults, w io.Writer) error {
 
 	// Overall status
 	fmt.Fprintf(w, "───────────────────────────────────────────────────────────────\n")
-	if results.AllPassed() {
+	if results.Interrupted {
+		fmt.Fprintf(w, "  ⚠ RUN INTERRUPTED: %d passed, %d failed before cancellation\n", results.PassedTests, results.FailedTests)
+	} else if results.AllPassed() {
 		fmt.Fprintf(w, "  ✓ ALL TESTS PASSED\n")
 	} else {
 		fmt.Fprintf(w, "  ✗ SOME TESTS FAILED\n")
 	}
 	fmt.Fprintf(w, "───────────────────────────────────────────────────────────────\n")
 	fmt.Fprintf(w, "\n")
+	if r.quiet {
+		return nil
+	}
 
 	// Trace analysis instructions
 	fmt.Fprintf(w, "📊 DETAILED ANALYSIS:\n")
@@ -116,17 +173,56 @@ func (r *Reporter) generateJSON(results *SuiteResults, w io.Writer) error {
 // generateJUnit creates a JUnit XML report
 func (r *Reporter) generateJUnit(results *SuiteResults, w io.Writer) error {
 	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
-	fmt.Fprintf(w, "<testsuite name=\"%s\" tests=\"%d\" failures=\"%d\" time=\"%.3f\">\n",
-		results.SuiteName, results.TotalTests, results.FailedTests, results.Duration.Seconds())
+	fmt.Fprintf(w, "<testsuite name=\"%s\" tests=\"%d\" failures=\"%d\" time=\"%.3f\" timestamp=\"%s\">\n",
+		escapeXML(results.SuiteName), results.TotalTests, results.FailedTests, results.Duration.Seconds(),
+		results.StartTime.Format("2006-01-02T15:04:05"))
+
+	selection, interrupted, repeats := results.SelectionNote(), results.InterruptedNote(), results.RepeatNote()
+	if selection != "" || interrupted != "" || repeats != "" {
+		fmt.Fprintf(w, "  <properties>\n")
+		if selection != "" {
+			fmt.Fprintf(w, "    <property name=\"selection\" value=\"%s\"/>\n", escapeXML(selection))
+		}
+		if interrupted != "" {
+			fmt.Fprintf(w, "    <property name=\"interrupted\" value=\"%s\"/>\n", escapeXML(interrupted))
+		}
+		if repeats != "" {
+			fmt.Fprintf(w, "    <property name=\"repeats\" value=\"%s\"/>\n", escapeXML(repeats))
+		}
+		fmt.Fprintf(w, "  </properties>\n")
+	}
 
 	for _, result := range results.Results {
-		fmt.Fprintf(w, "  <testcase name=\"%s\" time=\"%.3f\">\n",
-			escapeXML(result.TestName), result.Duration.Seconds())
+		fmt.Fprintf(w, "  <testcase name=\"%s\" classname=\"%s\" time=\"%.3f\">\n",
+			escapeXML(result.TestName), escapeXML(results.SuiteName), result.Duration.Seconds())
+
+		// Properties surface trace and matching metadata in CI test panels
+		if result.TraceID != "" || result.MatchStrategy != "" {
+			fmt.Fprintf(w, "    <properties>\n")
+			if result.TraceID != "" {
+				fmt.Fprintf(w, "      <property name=\"trace_id\" value=\"%s\"/>\n", escapeXML(result.TraceID))
+			}
+			if result.MatchStrategy != "" {
+				fmt.Fprintf(w, "      <property name=\"match_strategy\" value=\"%s\"/>\n", escapeXML(result.MatchStrategy))
+				fmt.Fprintf(w, "      <property name=\"confidence\" value=\"%.2f\"/>\n", result.Confidence)
+			}
+			fmt.Fprintf(w, "    </properties>\n")
+		}
 
+		// The output goes in system-out only, for passing and failing tests
 		if !result.Passed {
-			fmt.Fprintf(w, "    <failure message=\"%s\">\n", escapeXML(result.ErrorMessage))
-			fmt.Fprintf(w, "      Actual Output: %s\n", escapeXML(result.ActualOutput))
-			fmt.Fprintf(w, "    </failure>\n")
+			fmt.Fprintf(w, "    <failure message=\"%s\"/>\n", escapeXML(result.ErrorMessage))
+		}
+
+		if result.ActualOutput != "" || result.TraceID != "" {
+			fmt.Fprintf(w, "    <system-out>\n")
+			if result.ActualOutput != "" {
+				fmt.Fprintf(w, "Actual Output:\n%s\n", escapeXML(result.ActualOutput))
+			}
+			if result.TraceID != "" {
+				fmt.Fprintf(w, "View detailed trace: agk trace show %s\n", escapeXML(result.TraceID))
+			}
+			fmt.Fprintf(w, "    </system-out>\n")
 		}
 
 		fmt.Fprintf(w, "  </testcase>\n")
@@ -141,7 +237,10 @@ func (r *Reporter) generateMarkdown(results *SuiteResults, w io.Writer) error {
 	fmt.Fprintf(w, "# Test Report: %s\n\n", results.SuiteName)
 
 	// Executive Summary Banner
-	if results.AllPassed() {
+	if results.Interrupted {
+		fmt.Fprintf(w, "> **Status: INTERRUPTED** - %d of %d planned tests completed (%d failed) in %s\n\n",
+			results.TotalTests, results.Planned, results.FailedTests, formatDuration(results.Duration))
+	} else if results.AllPassed() {
 		fmt.Fprintf(w, "> **Status: PASSED** - %d/%d tests completed successfully in %s\n\n",
 			results.PassedTests, results.TotalTests, formatDuration(results.Duration))
 	} else {
@@ -149,6 +248,16 @@ func (r *Reporter) generateMarkdown(results *SuiteResults, w io.Writer) error {
 			results.FailedTests, results.TotalTests, results.PassRate())
 	}
 
+	if note := results.SelectionNote(); note != "" {
+		fmt.Fprintf(w, "> ⚠️ %s\n\n", note)
+	}
+	if note := results.InterruptedNote(); note != "" {
+		fmt.Fprintf(w, "> ⚠️ %s\n\n", note)
+	}
+	if note := results.RepeatNote(); note != "" {
+		fmt.Fprintf(w, "> 🔁 %s\n\n", note)
+	}
+
 	fmt.Fprintf(w, "**Generated:** %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
 
 	// Quick Stats with visual bars
@@ -161,6 +270,31 @@ func (r *Reporter) generateMarkdown(results *SuiteResults, w io.Writer) error {
 	fmt.Fprintf(w, "| **Pass Rate** | %.1f%% | %s |\n", results.PassRate(), generateProgressBar(results.PassRate()))
 	fmt.Fprintf(w, "| **Duration** | %s | |\n\n", formatDuration(results.Duration))
 
+	// Confidence distribution of semantic tests
+	if hist, ok := newConfidenceHistogram(results.Results); ok {
+		fmt.Fprintf(w, "### Confidence Distribution\n\n")
+		fmt.Fprintf(w, "| Confidence | Tests | Distribution |\n")
+		fmt.Fprintf(w, "|------------|-------|--------------|\n")
+		for i, count := range hist.Buckets {
+			fmt.Fprintf(w, "| %s | %d | %s |\n", hist.bucketLabel(i), count, generateBar(count, hist.Total, "█"))
+		}
+		fmt.Fprintf(w, "\n")
+		if hist.MarginalPasses > 0 {
+			fmt.Fprintf(w, "> ⚠️ %s\n\n", hist.marginalNote())
+		}
+	}
+
+	// Pass rate of each test across repeated runs
+	if len(results.Stability) > 0 {
+		fmt.Fprintf(w, "### Stability\n\n")
+		fmt.Fprintf(w, "| Test | Passes | Pass Rate | Confidence |\n")
+		fmt.Fprintf(w, "|------|--------|-----------|------------|\n")
+		for _, s := range results.Stability {
+			fmt.Fprintf(w, "| %s | %d/%d | %s | %s |\n", s.TestName, s.Passes, s.Runs, stabilityLabel(s), confidenceSpread(s))
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
 	// Quick Navigation for failed tests
 	if !results.AllPassed() {
 		fmt.Fprintf(w, "### Failed Tests\n\n")
@@ -183,7 +317,11 @@ func (r *Reporter) generateMarkdown(results *SuiteResults, w io.Writer) error {
 			statusBadge = "FAILED"
 		}
 
-		fmt.Fprintf(w, "### %d. %s\n\n", i+1, result.TestName)
+		if result.Attempt > 0 {
+			fmt.Fprintf(w, "### %d. %s (run %d)\n\n", i+1, result.TestName, result.Attempt)
+		} else {
+			fmt.Fprintf(w, "### %d. %s\n\n", i+1, result.TestName)
+		}
 
 		// Status badge
 		fmt.Fprintf(w, "**Status:** `%s` | **Duration:** %s\n\n",
@@ -322,6 +460,158 @@ func (r *Reporter) generateMarkdown(results *SuiteResults, w io.Writer) error {
 
 // Helper functions
 
+// marginalConfidence is the confidence below which a semantic pass is
+// flagged as marginal
+const marginalConfidence = 0.7
+
+// confidenceHistogram buckets the confidence of semantic tests in steps of 0.2
+type confidenceHistogram struct {
+	Buckets        [5]int
+	Total          int // Semantic tests
+	Passed         int // Semantic tests that passed
+	MarginalPasses int // Passes with confidence below marginalConfidence
+}
+
+// newConfidenceHistogram builds the histogram for the semantic tests in
+// results. It reports false when there are none.
+func newConfidenceHistogram(results []TestResult) (confidenceHistogram, bool) {
+	var hist confidenceHistogram
+	for _, result := range results {
+		if !isSemanticStrategy(result.MatchStrategy) {
+			continue
+		}
+		bucket := int(result.Confidence * float64(len(hist.Buckets)))
+		if bucket >= len(hist.Buckets) {
+			bucket = len(hist.Buckets) - 1
+		}
+		if bucket < 0 {
+			bucket = 0
+		}
+		hist.Buckets[bucket]++
+		hist.Total++
+		if result.Passed {
+			hist.Passed++
+			if result.Confidence < marginalConfidence {
+				hist.MarginalPasses++
+			}
+		}
+	}
+	return hist, hist.Total > 0
+}
+
+// bucketLabel returns the confidence range of bucket i, e.g. "0.6-0.8"
+func (h confidenceHistogram) bucketLabel(i int) string {
+	step := 1.0 / float64(len(h.Buckets))
+	return fmt.Sprintf("%.1f-%.1f", float64(i)*step, float64(i+1)*step)
+}
+
+// marginalNote describes how many passes had low confidence
+func (h confidenceHistogram) marginalNote() string {
+	return fmt.Sprintf("%d of %d semantic passes (%.0f%%) had confidence below %.1f",
+		h.MarginalPasses, h.Passed, float64(h.MarginalPasses)*100/float64(h.Passed), marginalConfidence)
+}
+
+// confidenceMargin is how far above its threshold a semantic pass must be to
+// count as comfortable rather than marginal
+const confidenceMargin = 0.1
+
+// writeConfidenceTable lists every test with its confidence, colored green
+// for comfortable passes, amber for marginal ones and red below threshold.
+// Tests without a graded confidence show "-".
+func (r *Reporter) writeConfidenceTable(results []TestResult, w io.Writer) {
+	width := 0
+	for _, result := range results {
+		width = max(width, len(result.TestName))
+	}
+
+	fmt.Fprintf(w, "Confidence by test:\n")
+	for _, result := range results {
+		mark := "✓"
+		if !result.Passed {
+			mark = "✗"
+		}
+		value := "   -"
+		if isSemanticStrategy(result.MatchStrategy) {
+			value = r.colorConfidence(result, fmt.Sprintf("%.2f", result.Confidence))
+			value += fmt.Sprintf(" (%s)", result.MatchStrategy)
+		}
+		fmt.Fprintf(w, "  %s %-*s  %s\n", mark, width, result.TestName, value)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// writeStabilityTable lists every test's passes across repeated runs, e.g.
+// "8/10 passes", with the spread of its confidence for semantic tests
+func (r *Reporter) writeStabilityTable(stability []TestStability, w io.Writer) {
+	width := 0
+	for _, s := range stability {
+		width = max(width, len(s.TestName))
+	}
+
+	fmt.Fprintf(w, "Stability by test:\n")
+	for _, s := range stability {
+		mark := "✓"
+		switch {
+		case s.Passes == 0:
+			mark = "✗"
+		case s.Flaky():
+			mark = "~"
+		}
+		passes := fmt.Sprintf("%d/%d passes", s.Passes, s.Runs)
+		fmt.Fprintf(w, "  %s %-*s  %-14s %-10s %s\n", mark, width, s.TestName, passes, stabilityLabel(s), confidenceSpread(s))
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// stabilityLabel gives a test's pass rate, flagging flaky tests
+func stabilityLabel(s TestStability) string {
+	if s.Flaky() {
+		return fmt.Sprintf("%.0f%% flaky", s.PassRate())
+	}
+	return fmt.Sprintf("%.0f%%", s.PassRate())
+}
+
+// confidenceSpread formats a semantic test's mean confidence and standard
+// deviation, e.g. "0.74 ± 0.09", or "-" for other tests
+func confidenceSpread(s TestStability) string {
+	if !s.Semantic {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f ± %.2f", s.MeanConfidence, s.ConfidenceStdDev)
+}
+
+// colorConfidence colors text by how the result's confidence compares to its
+// threshold, falling back to marginalConfidence when the threshold is unknown
+func (r *Reporter) colorConfidence(result TestResult, text string) string {
+	if !r.color {
+		return text
+	}
+	threshold := result.Threshold
+	if threshold <= 0 {
+		threshold = marginalConfidence
+	}
+
+	var c *color.Color
+	switch {
+	case !result.Passed || result.Confidence < threshold:
+		c = color.New(color.FgRed)
+	case result.Confidence < threshold+confidenceMargin:
+		c = color.New(color.FgYellow)
+	default:
+		c = color.New(color.FgGreen)
+	}
+	c.EnableColor()
+	return c.Sprint(text)
+}
+
+// isSemanticStrategy reports whether a match strategy produces a graded
+// confidence, as opposed to the all-or-nothing deterministic matchers
+func isSemanticStrategy(strategy string) bool {
+	return strategy == MatcherStrategyEmbedding ||
+		strategy == MatcherStrategyLLMJudge ||
+		strings.HasPrefix(strategy, MatcherStrategyHybrid)
+}
+
 // generateBar creates a visual bar representation
 func generateBar(count, total int, emoji string) string {
 	if total == 0 {
@@ -2,16 +2,37 @@ package eval
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
 	"time"
 )
 
+// ErrTargetUnavailable is returned when the target fails its health check,
+// so no test could run
+var ErrTargetUnavailable = errors.New("target health check failed")
+
 // RunnerConfig configures the test runner
 type RunnerConfig struct {
 	Timeout      time.Duration
 	Verbose      bool
 	FailFast     bool
+	Strict       bool // Fail tests whose expectations would match any output
 	OutputFormat string
+	NoCache      bool          // Ignore semantic.cache and always re-run matchers
+	CacheTTL     time.Duration // Age after which cached match results are ignored
+
+	JudgeTemperature *float64 // Overrides the judge LLM's temperature when set
+	JudgeMaxTokens   int      // Overrides the judge LLM's max_tokens when > 0
+
+	Sample  int   // Run only this many randomly chosen tests (0 runs all)
+	Shuffle bool  // Run tests in random order
+	Seed    int64 // Seed for Sample and Shuffle (0 picks one from the clock)
+
+	Quiet bool // Leave out the judge spinner; verbose output still shows
 }
 
 // Runner executes test suites
@@ -32,42 +53,87 @@ func NewRunner(config *RunnerConfig) *Runner {
 
 // Run executes a test suite and returns results
 func (r *Runner) Run(suite *TestSuite) (*SuiteResults, error) {
+	return r.RunContext(context.Background(), suite)
+}
+
+// RunContext executes a test suite until ctx is cancelled. A cancelled run
+// stops launching tests, drops the test that was in flight and returns the
+// completed results marked Interrupted.
+func (r *Runner) RunContext(ctx context.Context, suite *TestSuite) (*SuiteResults, error) {
 	results := &SuiteResults{
-		SuiteName:  suite.Name,
-		TotalTests: len(suite.Tests),
-		StartTime:  time.Now(),
-		Results:    make([]TestResult, 0, len(suite.Tests)),
+		SuiteName: suite.Name,
+		SuiteSize: len(suite.Tests),
+		StartTime: time.Now(),
+	}
+
+	tests := suite.Tests
+	if r.config.Sample > 0 || r.config.Shuffle {
+		seed := r.config.Seed
+		if seed == 0 {
+			seed = results.StartTime.UnixNano()
+		}
+		tests = selectTests(tests, r.config.Sample, r.config.Shuffle, rand.New(rand.NewSource(seed)))
+		results.Seed = seed
+		results.Sampled = len(tests) < len(suite.Tests)
+		results.Shuffled = r.config.Shuffle
+	}
+	results.TotalTests = len(tests)
+	results.Planned = len(tests)
+	results.Results = make([]TestResult, 0, len(tests))
+	if note := results.SelectionNote(); note != "" {
+		fmt.Fprintf(os.Stderr, "🎲 %s\n", note)
 	}
 
 	// Create matcher factory with semantic config from suite
 	r.matcherFactory = NewMatcherFactory(suite.Semantic)
+	if r.config.Verbose || (isTerminal(os.Stderr) && !r.config.Quiet) {
+		r.matcherFactory.SetProgress(os.Stderr, r.config.Verbose)
+	}
+	r.matcherFactory.SetJudgeOverrides(r.config.JudgeTemperature, r.config.JudgeMaxTokens)
+	if !r.config.NoCache {
+		r.matcherFactory.SetCache(NewMatchCache(DefaultMatchCacheDir, r.config.CacheTTL))
+	}
 
-	// Create target based on type
-	var target *HTTPTarget
-	if suite.Target.Type == "http" {
-		target = NewHTTPTarget(suite.Target.URL, r.config.Timeout)
+	// Create the target registered for the suite's type
+	target, err := NewTarget(suite.Target, r.config.Timeout)
+	if err != nil {
+		return nil, err
+	}
 
-		// Health check
-		if r.config.Verbose {
-			fmt.Printf("\n🏥 Health check: %s\n", suite.Target.URL)
-		}
-		if err := target.Health(); err != nil {
-			return nil, fmt.Errorf("target health check failed: %w", err)
-		}
-		if r.config.Verbose {
-			fmt.Println("✓ Target is healthy")
-		}
-	} else {
-		return nil, fmt.Errorf("unsupported target type: %s", suite.Target.Type)
+	// Health check
+	if r.config.Verbose {
+		fmt.Printf("\n🏥 Health check: %s\n", suite.Target)
+	}
+	if err := target.Health(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTargetUnavailable, err)
+	}
+	if r.config.Verbose {
+		fmt.Println("✓ Target is healthy")
 	}
 
 	// Run each test
-	for i, test := range suite.Tests {
+	sessions := newSessionTracker(suite.Name, results.StartTime)
+	testsStart := time.Now()
+	for i, test := range tests {
+		if ctx.Err() != nil {
+			results.Interrupted = true
+			break
+		}
 		if r.config.Verbose {
-			fmt.Printf("\n[%d/%d] Running: %s\n", i+1, len(suite.Tests), test.Name)
+			fmt.Printf("\n[%d/%d] Running: %s\n", i+1, len(tests), test.Name)
+		}
+
+		sessionID := sessions.idFor(test)
+		if r.config.Verbose && sessionID != "" {
+			fmt.Printf("  Session: %s\n", sessionID)
 		}
 
-		result := r.runTest(test, target)
+		result := r.runTest(ctx, test, target, sessionID, mergeEnv(suite.Env, test.Env))
+		if ctx.Err() != nil {
+			// Cancelled mid-test: the result would only report the cancellation
+			results.Interrupted = true
+			break
+		}
 		results.Results = append(results.Results, result)
 
 		if result.Passed {
@@ -80,27 +146,44 @@ func (r *Runner) Run(suite *TestSuite) (*SuiteResults, error) {
 			if r.config.Verbose {
 				fmt.Printf("  ✗ FAILED: %s\n", result.ErrorMessage)
 			}
+		}
+		if r.config.Verbose {
+			fmt.Printf("  [%d/%d] %s\n", i+1, len(tests), progressEstimate(i+1, len(tests), time.Since(testsStart)))
+		}
 
-			// Stop on first failure if fail-fast is enabled
-			if r.config.FailFast {
-				break
-			}
+		// Stop on first failure if fail-fast is enabled
+		if !result.Passed && r.config.FailFast {
+			break
 		}
 	}
 
+	if results.Interrupted {
+		results.TotalTests = len(results.Results)
+	}
+
 	results.EndTime = time.Now()
 	results.Duration = results.EndTime.Sub(results.StartTime)
 
 	return results, nil
 }
 
-// runTest executes a single test
-func (r *Runner) runTest(test Test, target *HTTPTarget) TestResult {
+// runTest executes a single test within the given session (empty for none)
+// with the given environment variables
+func (r *Runner) runTest(ctx context.Context, test Test, target Target, sessionID string, env map[string]string) TestResult {
 	result := TestResult{
 		TestName: test.Name,
 		Metadata: test.Metadata,
 	}
 
+	// In strict mode, refuse to run tests that would pass vacuously
+	if r.config.Strict {
+		if reason := vacuousExpectation(test.Expect); reason != "" {
+			result.Passed = false
+			result.ErrorMessage = fmt.Sprintf("suspicious expectation (strict mode): %s", reason)
+			return result
+		}
+	}
+
 	start := time.Now()
 
 	// Get timeout for this test
@@ -110,11 +193,11 @@ func (r *Runner) runTest(test Test, target *HTTPTarget) TestResult {
 	}
 
 	// Invoke the target
-	resp, err := target.Invoke(test.Input, timeout)
+	resp, err := target.Invoke(ctx, Invocation{Input: test.Input, SessionID: sessionID, Env: env, Timeout: timeout})
 	result.Duration = time.Since(start)
 
 	if r.config.Verbose {
-		fmt.Printf("  [HTTP Response] Success=%v, Error=%q, Output=%q (length: %d bytes)\n",
+		fmt.Printf("  [Target Response] Success=%v, Error=%q, Output=%q (length: %d bytes)\n",
 			resp != nil && resp.Success,
 			func() string {
 				if resp != nil {
@@ -163,8 +246,16 @@ func (r *Runner) runTest(test Test, target *HTTPTarget) TestResult {
 		result.ExpectedOutput = fmt.Sprintf("Pattern: %s", test.Expect.Pattern)
 	}
 
+	// Check reported tool usage before paying for any model-based matching
+	if len(test.Expect.ToolsCalled) > 0 {
+		if msg := checkToolsCalled(test.Expect.ToolsCalled, resp.ToolsCalled); msg != "" {
+			result.Passed = false
+			result.ErrorMessage = msg
+			return result
+		}
+	}
+
 	// Match output against expectations using new matcher factory
-	ctx := context.Background()
 	matcher, err := r.matcherFactory.CreateMatcher(test.Expect)
 	if err != nil {
 		result.Passed = false
@@ -183,6 +274,9 @@ func (r *Runner) runTest(test Test, target *HTTPTarget) TestResult {
 	result.MatchStrategy = matchResult.Strategy
 	result.Confidence = matchResult.Confidence
 	result.MatchDetails = matchResult.Details
+	if isSemanticStrategy(matchResult.Strategy) {
+		result.Threshold = r.matcherFactory.mergeSemanticConfig(test.Expect).Threshold
+	}
 
 	if !matchResult.Matched {
 		result.Passed = false
@@ -190,8 +284,159 @@ func (r *Runner) runTest(test Test, target *HTTPTarget) TestResult {
 		return result
 	}
 
-	// TODO: Validate trace expectations if specified (test.Expect.Trace)
+	// Validate trace expectations if specified
+	if test.Expect.Trace != nil && len(test.Expect.Trace.ExecutionPath) > 0 {
+		observed := observedPath(resp)
+		if msg := checkExecutionPath(test.Expect.Trace.ExecutionPath, observed, test.Expect.Trace.OrderedPath); msg != "" {
+			result.Passed = false
+			result.ErrorMessage = msg
+			return result
+		}
+	}
+
+	if test.Expect.Trace != nil && len(test.Expect.Trace.ReasoningPath) > 0 {
+		if msg := checkReasoningPath(test.Expect.Trace.ReasoningPath, resp); msg != "" {
+			result.Passed = false
+			result.ErrorMessage = msg
+			return result
+		}
+	}
+
+	// TODO: Validate remaining trace expectations (tool_calls, llm_calls, min/max steps)
 
 	result.Passed = true
 	return result
 }
+
+// sessionTracker assigns session IDs to tests.
+// Consecutive tests with the same session group share a stable ID; when a
+// different group starts, earlier sessions are dropped so revisiting a group
+// name later begins a fresh conversation.
+type sessionTracker struct {
+	prefix  string
+	current string // Active session group
+	id      string // ID of the active group
+	count   int    // Groups started so far
+}
+
+func newSessionTracker(suiteName string, start time.Time) *sessionTracker {
+	prefix := strings.ToLower(strings.Join(strings.Fields(suiteName), "-"))
+	return &sessionTracker{prefix: fmt.Sprintf("eval-%s-%d", prefix, start.Unix())}
+}
+
+// idFor returns the session ID for a test, or "" when it isn't in a session
+func (s *sessionTracker) idFor(test Test) string {
+	if test.SessionID != "" {
+		return test.SessionID
+	}
+
+	if test.Session == "" {
+		s.current, s.id = "", ""
+		return ""
+	}
+
+	if test.Session != s.current {
+		s.count++
+		s.current = test.Session
+		s.id = fmt.Sprintf("%s-%s-%d", s.prefix, test.Session, s.count)
+	}
+	return s.id
+}
+
+// selectTests picks a random sample of tests and/or shuffles them.
+// Consecutive tests sharing a session are kept together and in order, so
+// conversations stay intact; a sample only exceeds n when the first group
+// picked is larger than n.
+func selectTests(tests []Test, n int, shuffle bool, rng *rand.Rand) []Test {
+	// Split into units: a session group or a single test
+	var units [][]Test
+	for i, test := range tests {
+		if i > 0 && test.Session != "" && test.Session == tests[i-1].Session {
+			units[len(units)-1] = append(units[len(units)-1], test)
+			continue
+		}
+		units = append(units, []Test{test})
+	}
+
+	order := rng.Perm(len(units))
+	if n > 0 {
+		picked, count := order[:0], 0
+		for _, idx := range order {
+			if count >= n {
+				break
+			}
+			// Skip session groups that would overshoot while smaller units may still fit
+			if count > 0 && count+len(units[idx]) > n {
+				continue
+			}
+			picked = append(picked, idx)
+			count += len(units[idx])
+		}
+		order = picked
+		if !shuffle {
+			sort.Ints(order)
+		}
+	}
+
+	selected := make([]Test, 0, len(tests))
+	for _, idx := range order {
+		selected = append(selected, units[idx]...)
+	}
+	return selected
+}
+
+// progressEstimate describes the time spent on the first done of total tests
+// and, from the mean time per test so far, roughly how long the rest will take
+func progressEstimate(done, total int, elapsed time.Duration) string {
+	if done >= total {
+		return fmt.Sprintf("(elapsed %s)", formatElapsed(elapsed))
+	}
+	remaining := elapsed / time.Duration(done) * time.Duration(total-done)
+	return fmt.Sprintf("(elapsed %s, ~%s left)", formatElapsed(elapsed), formatElapsed(remaining))
+}
+
+// formatElapsed rounds d for progress output: whole seconds below an hour
+// ("2m10s"), whole minutes above, without trailing zero units ("1m", "1h")
+func formatElapsed(d time.Duration) string {
+	if d >= time.Hour {
+		d = d.Round(time.Minute)
+	} else {
+		d = d.Round(time.Second)
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// mergeEnv combines suite and test environment variables; test values win
+func mergeEnv(suiteEnv, testEnv map[string]string) map[string]string {
+	if len(suiteEnv) == 0 {
+		return testEnv
+	}
+	if len(testEnv) == 0 {
+		return suiteEnv
+	}
+
+	env := make(map[string]string, len(suiteEnv)+len(testEnv))
+	for k, v := range suiteEnv {
+		env[k] = v
+	}
+	for k, v := range testEnv {
+		env[k] = v
+	}
+	return env
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,179 @@
+package eval
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultStarterTargetURL is the placeholder target URL used by starter suites
+const DefaultStarterTargetURL = "http://localhost:8080"
+
+// starterComments maps top-level suite keys and test names to the comment
+// written above them in a starter suite
+var starterComments = map[string]string{
+	"name":     "Starter evaluation suite generated by 'agk eval init'.\nRun it with: agk eval <this-file>\n\nSuite name shown in reports",
+	"target":   "Where tests are sent. Point url at your running agent (see 'agk init' projects)",
+	"semantic": "Global semantic matching config, used by tests with expect.type: semantic.\nstrategy: embedding | llm-judge | hybrid. Tests may override any of these fields.",
+	"tests":    "Each test sends input to the target and checks the response against expect",
+
+	"exact-match":       "exact: the whole response must equal value",
+	"contains-keywords": "contains: every entry in values must appear in the response",
+	"regex-pattern":     "regex: the response must match pattern (Go regexp syntax)",
+	"semantic-embedding": "semantic (embedding): cosine similarity between response and value\n" +
+		"must reach threshold",
+	"semantic-llm-judge": "semantic (llm-judge): an LLM decides whether the response satisfies value.\n" +
+		"judge_prompt is optional and overrides the default prompt",
+	"semantic-hybrid": "semantic (hybrid): embedding first, falling back to the LLM judge when\n" +
+		"the similarity is inconclusive",
+}
+
+// StarterSuite returns an example suite covering each expectation type
+func StarterSuite(targetURL string) *TestSuite {
+	if targetURL == "" {
+		targetURL = DefaultStarterTargetURL
+	}
+
+	embeddingThreshold := 0.8
+	hybridThreshold := 0.75
+
+	return &TestSuite{
+		Name:        "my-agent-tests",
+		Description: "Example tests for my agent",
+		Target: TargetConfig{
+			Type: "http",
+			URL:  targetURL,
+		},
+		Semantic: &SemanticConfig{
+			Strategy: MatcherStrategyEmbedding,
+			Embedding: &EmbeddingConfig{
+				Provider: "ollama",
+				Model:    "nomic-embed-text:latest",
+				BaseURL:  "http://localhost:11434",
+			},
+			LLM: &LLMConfig{
+				Provider:    "ollama",
+				Model:       "llama3.2:latest",
+				Temperature: 0.0,
+				MaxTokens:   500,
+				BaseURL:     "http://localhost:11434",
+			},
+			Threshold: 0.7,
+		},
+		Tests: []Test{
+			{
+				Name:  "exact-match",
+				Input: "Reply with exactly the word: pong",
+				Expect: Expectation{
+					Type:  "exact",
+					Value: "pong",
+				},
+			},
+			{
+				Name:  "contains-keywords",
+				Input: "What is the capital of France?",
+				Expect: Expectation{
+					Type:   "contains",
+					Values: []string{"Paris"},
+				},
+			},
+			{
+				Name:  "regex-pattern",
+				Input: "What is 12 multiplied by 12?",
+				Expect: Expectation{
+					Type:    "regex",
+					Pattern: `\b144\b`,
+				},
+			},
+			{
+				Name:  "semantic-embedding",
+				Input: "Explain what an AI agent is in one sentence.",
+				Expect: Expectation{
+					Type:      "semantic",
+					Value:     "An AI agent is a program that perceives its environment and takes actions to achieve goals.",
+					Strategy:  MatcherStrategyEmbedding,
+					Threshold: &embeddingThreshold,
+				},
+			},
+			{
+				Name:  "semantic-llm-judge",
+				Input: "Give me three tips for writing clean code.",
+				Expect: Expectation{
+					Type:        "semantic",
+					Value:       "Three practical tips about writing readable, maintainable code",
+					Strategy:    MatcherStrategyLLMJudge,
+					JudgePrompt: "Does the response give three distinct tips about clean code? Answer YES or NO.",
+				},
+			},
+			{
+				Name:  "semantic-hybrid",
+				Input: "Summarize the benefits of unit testing.",
+				Expect: Expectation{
+					Type:      "semantic",
+					Values:    []string{"catches bugs early", "safer refactoring", "documents behavior"},
+					Strategy:  MatcherStrategyHybrid,
+					Threshold: &hybridThreshold,
+				},
+			},
+		},
+	}
+}
+
+// GenerateStarterSuite renders StarterSuite as commented YAML
+func GenerateStarterSuite(targetURL string) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(StarterSuite(targetURL)); err != nil {
+		return nil, fmt.Errorf("failed to encode starter suite: %w", err)
+	}
+
+	annotateStarterNode(&root)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return nil, fmt.Errorf("failed to render starter suite: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render starter suite: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// annotateStarterNode attaches starterComments to top-level keys and test entries
+func annotateStarterNode(root *yaml.Node) {
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if comment, ok := starterComments[key.Value]; ok {
+			key.HeadComment = comment
+		}
+		if key.Value != "tests" || value.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, test := range value.Content {
+			name := mappingValue(test, "name")
+			if comment, ok := starterComments[name]; ok && name != "" {
+				test.HeadComment = comment
+			}
+		}
+	}
+}
+
+// mappingValue returns the scalar value for key in a mapping node
+func mappingValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
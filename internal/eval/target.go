@@ -0,0 +1,77 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Target is a system under test that a suite sends its inputs to
+type Target interface {
+	// Invoke sends one test input and returns the target's response.
+	// Cancelling ctx aborts the call.
+	Invoke(ctx context.Context, inv Invocation) (*InvokeResponse, error)
+	// Health checks that the target is ready before any test runs
+	Health() error
+}
+
+// Invocation is one test input sent to a target
+type Invocation struct {
+	Input     string
+	SessionID string            // Conversation session; empty starts a fresh one
+	Env       map[string]string // Environment variables for this invocation only
+	Timeout   int               // Seconds the target may take
+}
+
+// TargetConstructor builds a target from a suite's target block. timeout
+// bounds each invocation. Suites are validated by constructing their
+// target, so constructors check the block but must not contact the target;
+// Health does that.
+type TargetConstructor func(config TargetConfig, timeout time.Duration) (Target, error)
+
+var (
+	targetTypesMu sync.RWMutex
+	targetTypes   = map[string]TargetConstructor{}
+)
+
+// RegisterTarget makes a target available as target.type name. It is meant
+// to be called from init functions and panics if name is empty or already
+// registered.
+func RegisterTarget(name string, constructor TargetConstructor) {
+	targetTypesMu.Lock()
+	defer targetTypesMu.Unlock()
+
+	if name == "" || constructor == nil {
+		panic("eval: RegisterTarget requires a name and a constructor")
+	}
+	if _, exists := targetTypes[name]; exists {
+		panic(fmt.Sprintf("eval: target %q is already registered", name))
+	}
+	targetTypes[name] = constructor
+}
+
+// NewTarget builds the target a suite's target block describes
+func NewTarget(config TargetConfig, timeout time.Duration) (Target, error) {
+	targetTypesMu.RLock()
+	constructor, ok := targetTypes[config.Type]
+	targetTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported target type: %s (valid: %s)", config.Type, strings.Join(registeredTargetTypes(), ", "))
+	}
+	return constructor(config, timeout)
+}
+
+// registeredTargetTypes returns the registered target types in sorted order
+func registeredTargetTypes() []string {
+	targetTypesMu.RLock()
+	defer targetTypesMu.RUnlock()
+	names := make([]string, 0, len(targetTypes))
+	for name := range targetTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,31 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTargetUnknownType(t *testing.T) {
+	_, err := NewTarget(TargetConfig{Type: "grpc"}, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "unsupported target type: grpc (valid: http)") {
+		t.Errorf("NewTarget() error = %v, want an unsupported type error listing http", err)
+	}
+}
+
+func TestNewTargetChecksConfig(t *testing.T) {
+	if _, err := NewTarget(TargetConfig{Type: "http"}, time.Second); err == nil {
+		t.Error("NewTarget() without a URL succeeded, want the http constructor's error")
+	}
+}
+
+func TestRegisterTargetDuplicate(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), `"http" is already registered`) {
+			t.Errorf("RegisterTarget() panic = %v, want an already registered panic", r)
+		}
+	}()
+	RegisterTarget("http", func(TargetConfig, time.Duration) (Target, error) {
+		return nil, nil
+	})
+}
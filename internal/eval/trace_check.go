@@ -0,0 +1,142 @@
+package eval
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/agenticgokit/agk/internal/audit"
+)
+
+// tracesDir is where agents write their run traces
+const tracesDir = ".agk/runs"
+
+// observedPath returns the sequence of steps recorded for a test run.
+// Steps come from the local trace when one exists for the response's trace ID,
+// named by tool name, workflow step name or span name in that order. Without
+// a trace, the tools reported by the target are used.
+func observedPath(resp *InvokeResponse) []string {
+	if obj := localTrace(resp); obj != nil && len(obj.Events) > 0 {
+		path := make([]string, 0, len(obj.Events))
+		for _, event := range obj.Events {
+			path = append(path, stepLabel(event))
+		}
+		return path
+	}
+	return resp.ToolsCalled
+}
+
+// localTrace collects the local trace for the response's trace ID, or returns
+// nil when there is none
+func localTrace(resp *InvokeResponse) *audit.TraceObject {
+	if resp.TraceID == "" {
+		return nil
+	}
+	collector, err := audit.NewCollector(filepath.Join(tracesDir, resp.TraceID))
+	if err != nil {
+		return nil
+	}
+	obj, err := collector.Collect()
+	if err != nil {
+		return nil
+	}
+	return obj
+}
+
+// checkReasoningPath verifies the local trace's reasoning path matches the
+// expected event type pattern. Unlike execution_path there is no fallback to
+// the target's tools_called, since that carries no event types. It returns a
+// failure message, or "" on success.
+func checkReasoningPath(expected []string, resp *InvokeResponse) string {
+	pattern, err := audit.ParsePathPattern(strings.Join(expected, ","))
+	if err != nil {
+		return err.Error()
+	}
+	obj := localTrace(resp)
+	if obj == nil {
+		return fmt.Sprintf("reasoning path check needs the run's trace in %s, but none was found for trace ID %q", tracesDir, resp.TraceID)
+	}
+	if ok, msg := audit.ValidatePath(obj, pattern); !ok {
+		return msg
+	}
+	return ""
+}
+
+// stepLabel names a trace event for execution path matching
+func stepLabel(event audit.TraceEvent) string {
+	for _, key := range []string{"agk.tool.name", "agk.workflow.step_name"} {
+		if v, ok := event.Metadata[key]; ok {
+			if name := fmt.Sprintf("%v", v); name != "" {
+				return name
+			}
+		}
+	}
+	return event.SpanName
+}
+
+// checkExecutionPath verifies the expected steps appear in the observed path.
+// When ordered is set the steps must appear in the given order (other steps may
+// be interleaved); otherwise each step only has to be present. It returns a
+// failure message including the observed path, or "" on success.
+func checkExecutionPath(expected, observed []string, ordered bool) string {
+	observedText := "(none)"
+	if len(observed) > 0 {
+		observedText = strings.Join(observed, " → ")
+	}
+
+	if ordered {
+		next := 0
+		for _, step := range observed {
+			if next < len(expected) && step == expected[next] {
+				next++
+			}
+		}
+		if next < len(expected) {
+			return fmt.Sprintf("execution path mismatch: expected steps in order [%s], step %q not found in order; observed: %s",
+				strings.Join(expected, " → "), expected[next], observedText)
+		}
+		return ""
+	}
+
+	present := make(map[string]bool, len(observed))
+	for _, step := range observed {
+		present[step] = true
+	}
+	var missing []string
+	for _, step := range expected {
+		if !present[step] {
+			missing = append(missing, step)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("execution path mismatch: missing steps [%s]; observed: %s",
+			strings.Join(missing, ", "), observedText)
+	}
+	return ""
+}
+
+// checkToolsCalled verifies every expected tool is among the tools the target
+// reported calling. It needs no trace, only the response's tools_called field.
+// It returns a failure message including the reported tools, or "" on success.
+func checkToolsCalled(expected, called []string) string {
+	reported := make(map[string]bool, len(called))
+	for _, tool := range called {
+		reported[tool] = true
+	}
+	var missing []string
+	for _, tool := range expected {
+		if !reported[tool] {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+
+	calledText := "(none)"
+	if len(called) > 0 {
+		calledText = strings.Join(called, ", ")
+	}
+	return fmt.Sprintf("tools called mismatch: missing tools [%s]; called: %s",
+		strings.Join(missing, ", "), calledText)
+}
@@ -1,6 +1,9 @@
 package eval
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Matcher strategy constants
 const (
@@ -9,47 +12,81 @@ const (
 	MatcherStrategyHybrid    = "hybrid"
 )
 
+// Judge verdict formats
+const (
+	// VerdictFormatText expects "YES|NO <confidence> - <explanation>"
+	VerdictFormatText = "text"
+	// VerdictFormatJSON expects {"verdict": ..., "confidence": ..., "reasoning": ...}
+	VerdictFormatJSON = "json"
+)
+
+// Hybrid matcher modes
+const (
+	// HybridModeBlend combines embedding and LLM confidences into a weighted score
+	HybridModeBlend = "blend"
+	// HybridModeConsensus requires both the embedding check and the LLM judge to match
+	HybridModeConsensus = "consensus"
+)
+
 // TestSuite represents a collection of tests
 type TestSuite struct {
 	Name        string            `yaml:"name"`
 	Description string            `yaml:"description"`
-	Target      Target            `yaml:"target"`
+	Target      TargetConfig      `yaml:"target"`
 	Semantic    *SemanticConfig   `yaml:"semantic,omitempty"` // Global semantic matching config
 	Tests       []Test            `yaml:"tests"`
+	Env         map[string]string `yaml:"env,omitempty"` // Environment variables sent with every test
 	Metadata    map[string]string `yaml:"metadata,omitempty"`
 }
 
-// Target defines where tests will be executed
-type Target struct {
-	Type string `yaml:"type"` // http, grpc, etc.
+// TargetConfig defines where tests will be executed
+type TargetConfig struct {
+	Type string `yaml:"type"` // A registered target type, e.g. http
 	URL  string `yaml:"url"`  // Base URL for HTTP targets
 }
 
+// String describes the target for progress output
+func (c TargetConfig) String() string {
+	if c.URL == "" {
+		return c.Type
+	}
+	return c.Type + " " + c.URL
+}
+
 // Test represents a single test case
 type Test struct {
 	Name        string                 `yaml:"name"`
 	Description string                 `yaml:"description,omitempty"`
 	Input       string                 `yaml:"input"`
 	Expect      Expectation            `yaml:"expect"`
-	Timeout     int                    `yaml:"timeout,omitempty"` // Override suite timeout
+	Timeout     int                    `yaml:"timeout,omitempty"`    // Override suite timeout
+	SessionID   string                 `yaml:"session_id,omitempty"` // Explicit session ID sent to the target
+	Session     string                 `yaml:"session,omitempty"`    // Session group; consecutive tests in a group share one conversation
+	Env         map[string]string      `yaml:"env,omitempty"`        // Environment variables for this test, overriding the suite's
+	DataFile    string                 `yaml:"data_file,omitempty"`  // CSV/JSON file of input/expected rows; each row becomes a test
 	Metadata    map[string]interface{} `yaml:"metadata,omitempty"`
 }
 
 // Expectation defines what to expect from test execution
 type Expectation struct {
-	Type        string            `yaml:"type"` // exact, contains, regex, semantic
+	Type        string            `yaml:"type"`              // exact, contains, regex, fuzzy, semantic, command or a registered matcher
+	Command     []string          `yaml:"command,omitempty"` // Program and arguments for the 'command' type
 	Value       string            `yaml:"value,omitempty"`
 	Values      []string          `yaml:"values,omitempty"`
 	Pattern     string            `yaml:"pattern,omitempty"`
-	Threshold   *float64          `yaml:"threshold,omitempty"` // For semantic matching (pointer for override detection)
+	Threshold   *float64          `yaml:"threshold,omitempty"` // For fuzzy and semantic matching (pointer for override detection)
 	Description string            `yaml:"description,omitempty"`
 	Trace       *TraceExpectation `yaml:"trace,omitempty"`
+	ToolsCalled []string          `yaml:"tools_called,omitempty"` // Tools the target must report calling
 
 	// Semantic matching overrides (optional, per-test)
 	Strategy    string           `yaml:"strategy,omitempty"`     // Override global strategy
 	LLM         *LLMConfig       `yaml:"llm,omitempty"`          // Override global LLM config
 	Embedding   *EmbeddingConfig `yaml:"embedding,omitempty"`    // Override global embedding config
 	JudgePrompt string           `yaml:"judge_prompt,omitempty"` // Override global judge prompt
+	HybridMode  string           `yaml:"hybrid_mode,omitempty"`  // Override global hybrid mode
+	// Override global verdict format
+	VerdictFormat string `yaml:"verdict_format,omitempty"`
 }
 
 // TraceExpectation defines expectations for trace data
@@ -57,6 +94,8 @@ type TraceExpectation struct {
 	ToolCalls     []string `yaml:"tool_calls,omitempty"`
 	LLMCalls      int      `yaml:"llm_calls,omitempty"`
 	ExecutionPath []string `yaml:"execution_path,omitempty"`
+	OrderedPath   bool     `yaml:"ordered_path,omitempty"`   // Require execution_path steps in the given order
+	ReasoningPath []string `yaml:"reasoning_path,omitempty"` // Event type pattern, e.g. [thought, tool_call+, observation]
 	MinSteps      int      `yaml:"min_steps,omitempty"`
 	MaxSteps      int      `yaml:"max_steps,omitempty"`
 }
@@ -71,10 +110,12 @@ type TestResult struct {
 	ErrorMessage   string
 	TraceID        string
 	Metadata       map[string]interface{}
+	Attempt        int `json:"attempt,omitempty"` // Run of the suite the result is from, with RunRepeated
 
 	// Semantic matching results
 	MatchStrategy string                 `json:"match_strategy,omitempty"` // embedding, llm-judge, hybrid
 	Confidence    float64                `json:"confidence,omitempty"`     // 0.0 - 1.0
+	Threshold     float64                `json:"threshold,omitempty"`      // Confidence needed to pass
 	MatchDetails  map[string]interface{} `json:"match_details,omitempty"`  // Strategy-specific details
 }
 
@@ -88,6 +129,19 @@ type SuiteResults struct {
 	Results     []TestResult
 	StartTime   time.Time
 	EndTime     time.Time
+
+	SuiteSize int   // Tests in the suite; more than TotalTests when sampled
+	Sampled   bool  // Only a random subset of the suite ran
+	Shuffled  bool  // Tests ran in random order
+	Seed      int64 // Seed for sampling and shuffling, to reproduce the run
+
+	Interrupted bool // The run was cancelled; only completed tests are counted
+	Planned     int  // Tests selected to run; more than TotalTests when interrupted
+
+	// With RunRepeated, the suite ran Repeats times; the counts and Results
+	// cover every run and Stability gives each test's pass rate
+	Repeats   int
+	Stability []TestStability
 }
 
 // AllPassed returns true if all tests passed
@@ -95,6 +149,58 @@ func (sr *SuiteResults) AllPassed() bool {
 	return sr.FailedTests == 0
 }
 
+// SelectionNote describes how tests were sampled or shuffled, or returns ""
+// when the whole suite ran in file order
+func (sr *SuiteResults) SelectionNote() string {
+	switch {
+	case sr.Sampled:
+		return fmt.Sprintf("Sampled %d of %d tests (seed %d); this is not full coverage", sr.TotalTests, sr.SuiteSize, sr.Seed)
+	case sr.Shuffled:
+		return fmt.Sprintf("Tests ran in random order (seed %d)", sr.Seed)
+	default:
+		return ""
+	}
+}
+
+// InterruptedNote describes how much of an interrupted run completed, or
+// returns "" when the run finished
+func (sr *SuiteResults) InterruptedNote() string {
+	if !sr.Interrupted {
+		return ""
+	}
+	return fmt.Sprintf("Interrupted after %d of %d tests; results are partial", sr.TotalTests, sr.Planned)
+}
+
+// Warnings lists soft problems of a run that fail no test: semantic passes
+// with marginal confidence. Flaky and skipped tests need a failure, which
+// already fails the run.
+func (sr *SuiteResults) Warnings() []string {
+	var warnings []string
+	if hist, ok := newConfidenceHistogram(sr.Results); ok && hist.MarginalPasses > 0 {
+		warnings = append(warnings, hist.marginalNote())
+	}
+	return warnings
+}
+
+// FlakyTests returns the tests that both passed and failed across repeated runs
+func (sr *SuiteResults) FlakyTests() int {
+	flaky := 0
+	for _, s := range sr.Stability {
+		if s.Flaky() {
+			flaky++
+		}
+	}
+	return flaky
+}
+
+// RepeatNote describes a repeated run, or returns "" for a single run
+func (sr *SuiteResults) RepeatNote() string {
+	if sr.Repeats <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("%d runs of %d tests; %d flaky", sr.Repeats, len(sr.Stability), sr.FlakyTests())
+}
+
 // PassRate returns the pass rate as a percentage
 func (sr *SuiteResults) PassRate() float64 {
 	if sr.TotalTests == 0 {
@@ -110,6 +216,11 @@ type SemanticConfig struct {
 	Embedding   *EmbeddingConfig `yaml:"embedding,omitempty"`    // Embedding configuration
 	Threshold   float64          `yaml:"threshold"`              // Similarity threshold (0.0 - 1.0)
 	JudgePrompt string           `yaml:"judge_prompt,omitempty"` // Custom judge prompt template
+	HybridMode  string           `yaml:"hybrid_mode,omitempty"`  // blend (default) | consensus
+	// Judge reply format: text (default) | json
+	VerdictFormat string `yaml:"verdict_format,omitempty"`
+	// Reuse results for identical inputs across runs (see MatchCache)
+	Cache bool `yaml:"cache,omitempty"`
 }
 
 // LLMConfig for LLM-based semantic matching
@@ -0,0 +1,115 @@
+// Package mcp reads the MCP servers configured in agk.toml and reaches them
+// through AgenticGoKit's MCP manager, as agents do, to check they respond
+// and list their tools.
+package mcp
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Server transport types, as accepted by AgenticGoKit
+const (
+	TypeStdio         = "stdio"
+	TypeTCP           = "tcp"
+	TypeWebSocket     = "websocket"
+	TypeHTTPSSE       = "http_sse"
+	TypeHTTPStreaming = "http_streaming"
+)
+
+// ServerTypes lists the supported transport types
+var ServerTypes = []string{TypeStdio, TypeTCP, TypeWebSocket, TypeHTTPSSE, TypeHTTPStreaming}
+
+// Config is the [mcp] table of agk.toml
+type Config struct {
+	// Enabled is nil when agk.toml doesn't say, which AgenticGoKit treats
+	// as disabled
+	Enabled      *bool    `toml:"enabled"`
+	AutoDiscover bool     `toml:"auto_discover"`
+	Servers      []Server `toml:"servers"`
+}
+
+// IsEnabled reports whether agents use the configured servers. Like
+// AgenticGoKit's MCPConfig, it takes an explicit enabled = true.
+func (c Config) IsEnabled() bool {
+	return c.Enabled != nil && *c.Enabled
+}
+
+// Server is one [[mcp.servers]] entry
+type Server struct {
+	Name    string `toml:"name"`
+	Type    string `toml:"type"`
+	Address string `toml:"address"` // Host the server listens on
+	Port    int    `toml:"port"`
+	Command string `toml:"command"` // Program that runs a stdio server
+	// Args are accepted but not passed on: AgenticGoKit starts Command
+	// without arguments
+	Args []string `toml:"args"`
+	// Disabled servers are listed but not contacted; servers are enabled
+	// unless they set enabled = false
+	Enabled *bool `toml:"enabled"`
+}
+
+// IsEnabled reports whether the server should be contacted
+func (s Server) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// validate checks the server has a name and what its type needs
+func (s Server) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("MCP server needs a name: %+v", s)
+	}
+	switch s.Type {
+	case TypeStdio:
+		if strings.TrimSpace(s.Command) == "" {
+			return fmt.Errorf("MCP server %s: stdio servers need a command", s.Name)
+		}
+	case TypeTCP, TypeWebSocket, TypeHTTPSSE, TypeHTTPStreaming:
+		if s.Address == "" || s.Port <= 0 {
+			return fmt.Errorf("MCP server %s: %s servers need an address and a port", s.Name, s.Type)
+		}
+		// AgenticGoKit builds the URL from the host and port itself
+		if strings.Contains(s.Address, "/") || (strings.Contains(s.Address, ":") && net.ParseIP(s.Address) == nil) {
+			return fmt.Errorf("MCP server %s: address must be a host, not %q; set the port with port =", s.Name, s.Address)
+		}
+	default:
+		return fmt.Errorf("MCP server %s: unknown type %q (supported: %s)", s.Name, s.Type, strings.Join(ServerTypes, ", "))
+	}
+	return nil
+}
+
+// LoadConfig reads the [mcp] table of the agk.toml at path
+func LoadConfig(path string) (*Config, error) {
+	var file struct {
+		MCP Config `toml:"mcp"`
+	}
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range file.MCP.Servers {
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if seen[s.Name] {
+			return nil, fmt.Errorf("%s: MCP server %s is configured twice", path, s.Name)
+		}
+		seen[s.Name] = true
+	}
+	return &file.MCP, nil
+}
+
+// Find returns the configured server with the given name
+func (c *Config) Find(name string) (Server, bool) {
+	for _, s := range c.Servers {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Server{}, false
+}
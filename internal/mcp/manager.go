@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/agenticgokit/agenticgokit/core"
+	// Registers the MCP manager AgenticGoKit's agents connect through
+	_ "github.com/agenticgokit/agenticgokit/plugins/mcp/unified"
+)
+
+// Tool is an MCP tool as AgenticGoKit's manager lists it
+type Tool = core.MCPToolInfo
+
+// ServerInfo describes a server as AgenticGoKit's manager sees it
+type ServerInfo = core.MCPServerInfo
+
+// ErrNoTools is returned for a server that lists no tools. The manager
+// only logs why a server failed the MCP handshake, so it looks the same
+// as one with nothing to offer; either way agents get no tools from it.
+var ErrNoTools = errors.New("listed no tools; the server failed the MCP handshake or has none to offer")
+
+// CoreConfig returns the server as AgenticGoKit's v1beta passes it to the
+// MCP manager: the address is the host, and args are dropped
+func (s Server) CoreConfig() core.MCPServerConfig {
+	return core.MCPServerConfig{
+		Name:    s.Name,
+		Type:    s.Type,
+		Host:    s.Address,
+		Port:    s.Port,
+		Command: s.Command,
+		Enabled: s.IsEnabled(),
+	}
+}
+
+// managerMu serializes use of core's process-wide MCP manager
+var managerMu sync.Mutex
+
+// withManager runs fn with AgenticGoKit's MCP manager configured for
+// servers, shutting it down afterwards
+func withManager(servers []core.MCPServerConfig, fn func(core.MCPManager) error) error {
+	managerMu.Lock()
+	defer managerMu.Unlock()
+
+	// The manager's client logs each request to the standard logger
+	if os.Getenv("MCP_NAVIGATOR_DEBUG") == "" {
+		defer log.SetOutput(log.Writer())
+		log.SetOutput(io.Discard)
+	}
+
+	if err := core.InitializeMCP(core.MCPConfig{Servers: servers}); err != nil {
+		return err
+	}
+	defer func() { _ = core.ShutdownMCP() }()
+	return fn(core.GetMCPManager())
+}
+
+// Describe returns each server as AgenticGoKit's MCP manager sees it
+func Describe(servers []Server) ([]ServerInfo, error) {
+	configs := make([]core.MCPServerConfig, len(servers))
+	for i, s := range servers {
+		configs[i] = s.CoreConfig()
+	}
+
+	infos := make([]ServerInfo, 0, len(servers))
+	err := withManager(configs, func(manager core.MCPManager) error {
+		for _, s := range servers {
+			info, err := manager.GetServerInfo(s.Name)
+			if err != nil {
+				return err
+			}
+			infos = append(infos, *info)
+		}
+		return nil
+	})
+	return infos, err
+}
+
+// ListTools connects to s through AgenticGoKit's MCP manager, as an agent
+// does, and lists its tools. A server that lists none fails with
+// ErrNoTools. Disabled servers are contacted too, since they're asked for
+// by name.
+func ListTools(ctx context.Context, s Server) ([]Tool, error) {
+	config := s.CoreConfig()
+	config.Enabled = true
+
+	var tools []Tool
+	err := withManager([]core.MCPServerConfig{config}, func(manager core.MCPManager) error {
+		if health := manager.HealthCheck(ctx)[s.Name]; health.Status != "healthy" {
+			return errors.New(health.Error)
+		}
+		if err := manager.RefreshTools(ctx); err != nil {
+			return err
+		}
+		if tools = manager.GetToolsFromServer(s.Name); len(tools) == 0 {
+			return ErrNoTools
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return tools, err
+}
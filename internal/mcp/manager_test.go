@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeServerEnv makes the test binary act as a stdio MCP server
+const fakeServerEnv = "AGK_MCP_FAKE_SERVER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(fakeServerEnv) {
+	case "serve":
+		serveStdio()
+		os.Exit(0)
+	case "crash":
+		fmt.Fprintln(os.Stderr, "missing API key")
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// serveStdio answers requests on stdin
+func serveStdio() {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if resp := fakeResponse(scanner.Bytes()); resp != nil {
+			os.Stdout.Write(append(resp, '\n'))
+		}
+	}
+}
+
+// fakeResponse answers one request the way a server with two tools would,
+// returning nil for notifications
+func fakeResponse(data []byte) []byte {
+	var req struct {
+		ID     *int   `json:"id"`
+		Method string `json:"method"`
+	}
+	if json.Unmarshal(data, &req) != nil || req.ID == nil {
+		return nil
+	}
+
+	var result any
+	switch req.Method {
+	case "initialize":
+		result = map[string]any{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "fake", "version": "1.2.3"},
+		}
+	case "tools/list":
+		result = map[string]any{"tools": []map[string]any{
+			{"name": "read_file", "description": "Read a file", "inputSchema": map[string]any{"type": "object"}},
+			{"name": "write_file", "description": "Write a file", "inputSchema": map[string]any{"type": "object"}},
+		}}
+	default:
+		resp, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": *req.ID,
+			"error": map[string]any{"code": -32601, "message": "method not found"},
+		})
+		return resp
+	}
+	resp, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": *req.ID, "result": result})
+	return resp
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestListToolsStdio(t *testing.T) {
+	t.Setenv(fakeServerEnv, "serve")
+	disabled := false
+	server := Server{Name: "fs", Type: TypeStdio, Command: os.Args[0], Enabled: &disabled}
+
+	tools, err := ListTools(testContext(t), server)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+		if tool.ServerName != "fs" {
+			t.Errorf("tool %s ServerName = %q, want fs", tool.Name, tool.ServerName)
+		}
+	}
+	if want := []string{"read_file", "write_file"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListTools() = %v, want %v", names, want)
+	}
+}
+
+func TestListToolsStdioCrash(t *testing.T) {
+	t.Setenv(fakeServerEnv, "crash")
+	server := Server{Name: "fs", Type: TypeStdio, Command: os.Args[0]}
+
+	if _, err := ListTools(testContext(t), server); !errors.Is(err, ErrNoTools) {
+		t.Fatalf("ListTools() error = %v, want ErrNoTools", err)
+	}
+}
+
+func TestListToolsUnreachable(t *testing.T) {
+	// A port that was free a moment ago
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	server := Server{Name: "tcp", Type: TypeTCP, Address: "127.0.0.1", Port: port}
+	if _, err := ListTools(testContext(t), server); err == nil || !strings.Contains(err.Error(), "Connection failed") {
+		t.Fatalf("ListTools() error = %v, want a connection failure", err)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	disabled := false
+	servers := []Server{
+		{Name: "fs", Type: TypeStdio, Command: "mcp-fs", Args: []string{"."}},
+		{Name: "search", Type: TypeHTTPStreaming, Address: "localhost", Port: 8811, Enabled: &disabled},
+	}
+
+	infos, err := Describe(servers)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	want := []ServerInfo{
+		{Name: "fs", Type: TypeStdio, Status: "disconnected"},
+		{Name: "search", Type: TypeHTTPStreaming, Address: "localhost", Port: 8811, Status: "disconnected"},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("Describe() = %+v, want %+v", infos, want)
+	}
+}
+
+func TestServerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  Server
+		wantErr string
+	}{
+		{name: "stdio", server: Server{Name: "fs", Type: TypeStdio, Command: "mcp-fs"}},
+		{name: "host and port", server: Server{Name: "search", Type: TypeHTTPStreaming, Address: "localhost", Port: 8811}},
+		{name: "IPv6 host", server: Server{Name: "search", Type: TypeTCP, Address: "::1", Port: 8811}},
+		{name: "no command", server: Server{Name: "fs", Type: TypeStdio}, wantErr: "stdio servers need a command"},
+		{name: "no port", server: Server{Name: "search", Type: TypeHTTPSSE, Address: "localhost"}, wantErr: "need an address and a port"},
+		{
+			name:    "URL address",
+			server:  Server{Name: "search", Type: TypeHTTPStreaming, Address: "http://localhost:8811/mcp", Port: 8811},
+			wantErr: "address must be a host",
+		},
+		{name: "unknown type", server: Server{Name: "x", Type: "docker"}, wantErr: `unknown type "docker"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.server.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
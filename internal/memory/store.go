@@ -0,0 +1,254 @@
+// Package memory reads the memory stores agk projects configure in the
+// [agents] table of agk.toml, so their entries can be inspected and cleared
+// from the command line.
+package memory
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Memory types accepted in agk.toml's memory_type
+const (
+	TypeInMemory = "in-memory"
+	TypeChromem  = "chromem"
+)
+
+// ErrNotPersistent is returned by Open for stores that only live inside the
+// agent's process, so there is nothing on disk to read
+var ErrNotPersistent = errors.New("memory store is not persisted")
+
+// ErrNotFound is returned by Get for an ID no entry has
+var ErrNotFound = errors.New("memory entry not found")
+
+// Entry is one stored memory or knowledge-base document
+type Entry struct {
+	ID         string
+	Collection string
+	Content    string
+	Metadata   map[string]string
+}
+
+// Kind returns the entry's type metadata, such as "personal" or "knowledge"
+func (e Entry) Kind() string {
+	return e.Metadata["type"]
+}
+
+// Store is a memory store agk can read and clear
+type Store interface {
+	// Location describes where the store keeps its entries
+	Location() string
+	// List returns all entries, ordered by collection and creation time
+	List() ([]Entry, error)
+	// Get returns the entry with the given ID
+	Get(id string) (*Entry, error)
+	// Clear deletes all entries and returns how many were removed
+	Clear() (int, error)
+}
+
+// inMemoryName is accepted as a memory_type meaning in-memory, and as a
+// chromem memory_path, which AgenticGoKit reads as "don't persist"
+const inMemoryName = "memory"
+
+// Open returns the store for a memory_type and memory_path from agk.toml,
+// reading a relative path from projectDir. The in-memory default, and
+// chromem without a path, return ErrNotPersistent.
+func Open(memoryType, path, projectDir string) (Store, error) {
+	switch strings.ToLower(memoryType) {
+	case "", TypeInMemory, inMemoryName:
+		return nil, ErrNotPersistent
+	case TypeChromem:
+		if path == "" || path == inMemoryName {
+			return nil, ErrNotPersistent
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectDir, path)
+		}
+		return &chromemStore{dir: path}, nil
+	default:
+		return nil, fmt.Errorf("agk can't read %s memory stores yet (supported: %s, %s)", memoryType, TypeInMemory, TypeChromem)
+	}
+}
+
+// chromemMetadataFile holds a collection's name in a chromem-go database
+// directory; every other .gob file in the collection directory is a document
+const chromemMetadataFile = "00000000.gob"
+
+// chromemStore reads a chromem-go persistent database: one subdirectory per
+// collection, each document a gob file, optionally gzip-compressed
+type chromemStore struct {
+	dir string
+}
+
+// chromemCollection is the gob layout of a collection's metadata file
+type chromemCollection struct {
+	Name     string
+	Metadata map[string]string
+}
+
+// chromemDocument is the gob layout of a document file; the embedding is
+// left out since gob skips fields the target doesn't have
+type chromemDocument struct {
+	ID       string
+	Metadata map[string]string
+	Content  string
+}
+
+// collectionFiles is a collection with the paths of its document files
+type collectionFiles struct {
+	name  string
+	files []string
+}
+
+func (s *chromemStore) Location() string {
+	return s.dir
+}
+
+func (s *chromemStore) List() ([]Entry, error) {
+	collections, err := s.collections()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, c := range collections {
+		var docs []Entry
+		for _, file := range c.files {
+			var doc chromemDocument
+			if err := readGob(file, &doc); err != nil {
+				return nil, err
+			}
+			docs = append(docs, Entry{ID: doc.ID, Collection: c.name, Content: doc.Content, Metadata: doc.Metadata})
+		}
+		sort.SliceStable(docs, func(i, j int) bool {
+			if a, b := docs[i].Metadata["created_at"], docs[j].Metadata["created_at"]; a != b {
+				return a < b
+			}
+			return docs[i].ID < docs[j].ID
+		})
+		entries = append(entries, docs...)
+	}
+	return entries, nil
+}
+
+func (s *chromemStore) Get(id string) (*Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Clear removes the document files and keeps each collection's metadata,
+// so the collections remain and are simply empty
+func (s *chromemStore) Clear() (int, error) {
+	collections, err := s.collections()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, c := range collections {
+		for _, file := range c.files {
+			if err := os.Remove(file); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// collections returns the collections in the database directory, by name.
+// A missing directory is an empty database, as chromem-go creates it lazily.
+func (s *chromemStore) collections() ([]collectionFiles, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory directory: %w", err)
+	}
+
+	var collections []collectionFiles
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		collectionDir := filepath.Join(s.dir, dirEntry.Name())
+
+		var meta chromemCollection
+		if err := readGob(filepath.Join(collectionDir, chromemMetadataFile), &meta); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // Not a collection
+			}
+			return nil, err
+		}
+
+		c := collectionFiles{name: meta.Name}
+		files, err := os.ReadDir(collectionDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collection %s: %w", meta.Name, err)
+		}
+		for _, f := range files {
+			name := f.Name()
+			if f.IsDir() || name == chromemMetadataFile || name == chromemMetadataFile+".gz" {
+				continue
+			}
+			if strings.HasSuffix(name, ".gob") || strings.HasSuffix(name, ".gob.gz") {
+				c.files = append(c.files, filepath.Join(collectionDir, name))
+			}
+		}
+		collections = append(collections, c)
+	}
+
+	sort.Slice(collections, func(i, j int) bool { return collections[i].name < collections[j].name })
+	return collections, nil
+}
+
+// readGob decodes a gob file into obj, decompressing it first when it is
+// gzip-compressed
+func readGob(path string, obj any) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// chromem-go names compressed files with a .gz suffix
+			if f, err = os.Open(path + ".gz"); err != nil {
+				return fs.ErrNotExist
+			}
+		} else {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var reader io.Reader = r
+	if magic, err := r.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	if err := gob.NewDecoder(reader).Decode(obj); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// The testdata databases were written by chromem-go v0.7.0, one plain and
+// one gzip-compressed, each with an agent_memory and a knowledge collection
+
+func TestChromemStoreList(t *testing.T) {
+	for _, dir := range []string{"chromem", "chromem-gz"} {
+		t.Run(dir, func(t *testing.T) {
+			store, err := Open(TypeChromem, dir, "testdata")
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			entries, err := store.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			var ids []string
+			for _, e := range entries {
+				ids = append(ids, e.Collection+"/"+e.ID)
+			}
+			// Ordered by collection, then creation time
+			want := []string{"agent_memory/mem-1", "agent_memory/mem-2", "knowledge/doc-1"}
+			if !reflect.DeepEqual(ids, want) {
+				t.Fatalf("List() = %v, want %v", ids, want)
+			}
+
+			entry, err := store.Get("mem-1")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if entry.Content != "The user's name is Sam" || entry.Kind() != "personal" || entry.Metadata["session_id"] != "s1" {
+				t.Errorf("Get() = %+v", entry)
+			}
+			if _, err := store.Get("missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestChromemStoreClear(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join("testdata", "chromem"))); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(TypeChromem, dir, "")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	removed, err := store.Clear()
+	if err != nil || removed != 3 {
+		t.Fatalf("Clear() = %d, %v; want 3, nil", removed, err)
+	}
+	entries, err := store.List()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("List() after Clear = %v, %v; want no entries", entries, err)
+	}
+	// The collections stay, empty
+	if collections, err := store.(*chromemStore).collections(); err != nil || len(collections) != 2 {
+		t.Errorf("collections after Clear = %v, %v; want 2", collections, err)
+	}
+}
+
+func TestOpenNotPersistent(t *testing.T) {
+	tests := []struct{ memoryType, path string }{
+		{"", ""},
+		{TypeInMemory, "./.agk/memory"},
+		{"memory", ""},
+		{TypeChromem, ""},
+		{TypeChromem, "memory"},
+	}
+	for _, tt := range tests {
+		if _, err := Open(tt.memoryType, tt.path, "."); !errors.Is(err, ErrNotPersistent) {
+			t.Errorf("Open(%q, %q) error = %v, want ErrNotPersistent", tt.memoryType, tt.path, err)
+		}
+	}
+}
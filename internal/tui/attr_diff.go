@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// attrChange classifies an attribute against the previously selected span
+type attrChange int
+
+const (
+	attrSame attrChange = iota
+	attrChanged
+	attrAdded
+)
+
+// trackSelection remembers the attributes of the span the cursor just left,
+// so the attribute diff can compare the new selection against it
+func (m *Model) trackSelection(before *SpanNode) {
+	if before == nil || before.Synthetic {
+		return
+	}
+	if m.cursor < len(m.visibleNodes) && m.visibleNodes[m.cursor] == before {
+		return
+	}
+	m.prevAttrs = before.Span.GetAllAttributes()
+	m.prevSpanName = before.Span.GetFriendlyName()
+}
+
+// selectedNode returns the node under the cursor, or nil
+func (m Model) selectedNode() *SpanNode {
+	if m.cursor < len(m.visibleNodes) {
+		return m.visibleNodes[m.cursor]
+	}
+	return nil
+}
+
+// diffingAttrs reports whether attributes are shown as a diff against the
+// previously selected span
+func (m Model) diffingAttrs() bool {
+	return m.diffAttrs && m.prevAttrs != nil
+}
+
+// toggleAttrDiff turns highlighting of changed attributes on or off
+func (m Model) toggleAttrDiff() Model {
+	m.diffAttrs = !m.diffAttrs
+	switch {
+	case !m.diffAttrs:
+		m.statusMessage = "Attribute diff off"
+	case m.prevAttrs == nil:
+		m.statusMessage = "Attribute diff on: move to another span to compare"
+	default:
+		m.statusMessage = "Attribute diff on: comparing with " + m.prevSpanName
+	}
+	return m
+}
+
+// attrChangeOf compares one attribute with the previously selected span
+func (m Model) attrChangeOf(key string, value interface{}) attrChange {
+	prev, ok := m.prevAttrs[key]
+	switch {
+	case !ok:
+		return attrAdded
+	case fmt.Sprint(prev) != fmt.Sprint(value):
+		return attrChanged
+	default:
+		return attrSame
+	}
+}
+
+// renderAttrLine marks a formatted attribute line for the diff:
+// ~ changed, + only on this span
+func (m Model) renderAttrLine(key string, value interface{}, line string) string {
+	switch m.attrChangeOf(key, value) {
+	case attrChanged:
+		return WarningStyle.Render("~ " + line)
+	case attrAdded:
+		return SuccessStyle.Render("+ " + line)
+	default:
+		return MutedStyle.Render("  " + line)
+	}
+}
+
+// renderRemovedAttrs lists, marked with -, the attributes the previously
+// selected span had that attrs lacks
+func (m Model) renderRemovedAttrs(attrs map[string]interface{}, keyWidth int) string {
+	var s string
+	for _, key := range m.removedAttrKeys(attrs) {
+		line := fmt.Sprintf("%-*s %v", keyWidth, shortAttrKey(key)+":", m.prevAttrs[key])
+		s += ErrorStyle.Render("- "+line) + "\n"
+	}
+	return s
+}
+
+// shortAttrKey drops the common namespace prefixes from an attribute key
+func shortAttrKey(key string) string {
+	key = strings.TrimPrefix(key, "agk.")
+	key = strings.TrimPrefix(key, "llm.")
+	return strings.TrimPrefix(key, "workflow.")
+}
+
+// removedAttrKeys returns, sorted, the keys the previously selected span had
+// that attrs lacks
+func (m Model) removedAttrKeys(attrs map[string]interface{}) []string {
+	var removed []string
+	for key := range m.prevAttrs {
+		if _, ok := attrs[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// attrDiffSummary describes the diff for the attributes section header
+func (m Model) attrDiffSummary(attrs map[string]interface{}) string {
+	changed := 0
+	for key, value := range attrs {
+		if m.attrChangeOf(key, value) != attrSame {
+			changed++
+		}
+	}
+	changed += len(m.removedAttrKeys(attrs))
+	return fmt.Sprintf("%d differ from %s", changed, m.prevSpanName)
+}
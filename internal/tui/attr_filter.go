@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// attrFilter keeps spans whose attribute key has the given value
+type attrFilter struct {
+	key   string
+	value string
+}
+
+func (f attrFilter) String() string {
+	return f.key + "=" + f.value
+}
+
+// matchesAttrFilters reports whether the span satisfies every filter
+func (n *SpanNode) matchesAttrFilters(filters []attrFilter) bool {
+	if n.Synthetic {
+		return false
+	}
+	attrs := n.Span.GetAllAttributes()
+	for _, f := range filters {
+		v, ok := attrs[f.key]
+		if !ok || fmt.Sprint(v) != f.value {
+			return false
+		}
+	}
+	return true
+}
+
+// markFilteredOut hides the nodes that neither match the filters nor have a
+// matching descendant, so matches keep their ancestors for context. It
+// reports whether any node matched.
+func markFilteredOut(nodes []*SpanNode, filters []attrFilter) bool {
+	found := false
+	for _, node := range nodes {
+		matched := markFilteredOut(node.Children, filters)
+		matched = matched || len(filters) == 0 || node.matchesAttrFilters(filters)
+		node.filteredOut = !matched
+		found = found || matched
+	}
+	return found
+}
+
+// sortedAttrKeys returns the attribute keys in display order
+func sortedAttrKeys(attrs map[string]interface{}) []string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// selectedAttr returns the attribute under the cursor of the Attributes tab
+func (m Model) selectedAttr() (key string, value interface{}, ok bool) {
+	node := m.selectedNode()
+	if node == nil || node.Synthetic {
+		return "", nil, false
+	}
+	attrs := node.Span.GetAllAttributes()
+	keys := sortedAttrKeys(attrs)
+	if len(keys) == 0 {
+		return "", nil, false
+	}
+	key = keys[min(max(m.attrCursor, 0), len(keys)-1)]
+	return key, attrs[key], true
+}
+
+// moveAttrCursor moves the Attributes tab cursor by delta, keeping it on
+// screen
+func (m Model) moveAttrCursor(delta int) Model {
+	node := m.selectedNode()
+	if node == nil {
+		return m
+	}
+	count := len(node.Span.GetAllAttributes())
+	m.attrCursor = min(max(m.attrCursor+delta, 0), max(count-1, 0))
+	m.detailViewport.SetContent(m.renderAttributesTab(node))
+
+	// The tab starts with a header and a blank line
+	line := m.attrCursor + 2
+	if line < m.detailViewport.YOffset {
+		m.detailViewport.SetYOffset(line)
+	} else if line >= m.detailViewport.YOffset+m.detailViewport.Height {
+		m.detailViewport.SetYOffset(line - m.detailViewport.Height + 1)
+	}
+	return m
+}
+
+// copySelectedAttrKey copies the full key of the selected attribute, e.g.
+// "agk.llm.model", to the clipboard
+func (m *Model) copySelectedAttrKey() {
+	key, _, ok := m.selectedAttr()
+	if !ok {
+		m.statusMessage = WarningStyle.Render("No attribute selected")
+		return
+	}
+	termenv.Copy(key)
+	m.statusMessage = SuccessStyle.Render("✓ Copied " + key)
+}
+
+// addSelectedAttrFilter narrows the tree to spans whose selected attribute
+// has the same value, and returns to the tree to show them
+func (m Model) addSelectedAttrFilter() Model {
+	key, value, ok := m.selectedAttr()
+	if !ok {
+		m.statusMessage = WarningStyle.Render("No attribute selected")
+		return m
+	}
+	filter := attrFilter{key: key, value: fmt.Sprint(value)}
+	for _, f := range m.attrFilters {
+		if f == filter {
+			m.viewMode = TreeView
+			return m
+		}
+	}
+	m.attrFilters = append(m.attrFilters, filter)
+	m = m.applyAttrFilters()
+	m.viewMode = TreeView
+	m.statusMessage = SuccessStyle.Render("✓ Filtering on " + filter.String())
+	return m
+}
+
+// clearAttrFilters shows every span again
+func (m Model) clearAttrFilters() Model {
+	m.attrFilters = nil
+	return m.applyAttrFilters()
+}
+
+// applyAttrFilters re-marks the tree after the filters changed, keeping the
+// cursor on the same span where it is still shown
+func (m Model) applyAttrFilters() Model {
+	selected := m.selectedNode()
+	markFilteredOut(m.roots, m.attrFilters)
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	if m.searchQuery != "" {
+		m = m.executeSearch()
+	}
+	m.restoreCursor(selected)
+	return m
+}
+
+// attrFilterLabel describes the active filters for the tree panel title
+func (m Model) attrFilterLabel() string {
+	if len(m.attrFilters) == 0 {
+		return ""
+	}
+	parts := make([]string, len(m.attrFilters))
+	for i, f := range m.attrFilters {
+		parts[i] = f.String()
+	}
+	return "filter: " + strings.Join(parts, ", ") + " (esc to clear)"
+}
@@ -0,0 +1,45 @@
+package tui
+
+import "fmt"
+
+// FoldBelowDepth collapses the spans on the given level and below, counting
+// roots as level one, so the tree opens showing only that many levels.
+// Expanding a folded span reveals one more level at a time.
+func FoldBelowDepth(roots []*SpanNode, levels int) {
+	if levels <= 0 {
+		return
+	}
+	for _, node := range AllNodes(roots) {
+		if node.Depth >= levels-1 && node.HasChildren() {
+			node.Expanded = false
+		}
+	}
+}
+
+// ShowDepth returns a copy that opens the tree showing only the given
+// number of levels (0 = all); deeper spans are folded behind a
+// "+k more levels" marker
+func (m Model) ShowDepth(levels int) Model {
+	if m.showDepth == levels {
+		return m
+	}
+	m.showDepth = levels
+	m.setTree(m.collectAllSpans())
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	m.cursor = 0
+	m.applyRepeatCollapsing()
+	return m
+}
+
+// foldedLevelsLabel marks a span folded by the depth limit with the number
+// of levels hidden below it, or returns "" when the span isn't folded by it
+func (m Model) foldedLevelsLabel(node *SpanNode) string {
+	if m.showDepth <= 0 || node.Expanded || !node.HasChildren() || node.Depth < m.showDepth-1 {
+		return ""
+	}
+	_, levels := treeSize(node.Children)
+	if levels == 1 {
+		return " +1 more level"
+	}
+	return fmt.Sprintf(" +%d more levels", levels)
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFoldBelowDepth(t *testing.T) {
+	// root → plan → tool → exec, with a childless sibling of plan
+	spans := []Span{
+		liveSpan("root", "", 0, 1000),
+		liveSpan("plan", "root", 0, 500),
+		liveSpan("tool", "plan", 100, 400),
+		liveSpan("exec", "tool", 200, 300),
+		liveSpan("write", "root", 600, 900),
+	}
+
+	tests := []struct {
+		name   string
+		levels int
+		want   []string
+	}{
+		{name: "all", levels: 0, want: []string{"root", "plan", "tool", "exec", "write"}},
+		{name: "roots only", levels: 1, want: []string{"root +3 more levels"}},
+		{name: "two levels", levels: 2, want: []string{"root", "plan +2 more levels", "write"}},
+		{name: "one level folded", levels: 3, want: []string{"root", "plan", "tool +1 more level", "write"}},
+		{name: "as deep as the tree", levels: 4, want: []string{"root", "plan", "tool", "exec", "write"}},
+		{name: "deeper than the tree", levels: 5, want: []string{"root", "plan", "tool", "exec", "write"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTraceViewer("run-1", TraceRun{}, spans).ShowDepth(tt.levels)
+			var got []string
+			for _, node := range m.visibleNodes {
+				got = append(got, node.Span.SpanContext.SpanID+m.foldedLevelsLabel(node))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ShowDepth(%d) shows %q, want %q", tt.levels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldBelowDepthExpandOneLevel(t *testing.T) {
+	m := NewTraceViewer("run-1", TraceRun{}, []Span{
+		liveSpan("root", "", 0, 1000),
+		liveSpan("plan", "root", 0, 500),
+		liveSpan("tool", "plan", 100, 400),
+		liveSpan("exec", "tool", 200, 300),
+	}).ShowDepth(2)
+
+	// Expanding the folded span reveals its children, still folded
+	plan := m.nodeByID["plan"]
+	plan.Expanded = true
+	var got []string
+	for _, node := range FlattenTree(m.roots) {
+		got = append(got, node.Span.SpanContext.SpanID+m.foldedLevelsLabel(node))
+	}
+	if want := []string{"root", "plan", "tool +1 more level"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after expanding plan: %q, want %q", got, want)
+	}
+}
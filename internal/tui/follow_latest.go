@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// LatestRun describes a run the viewer can switch to in follow-latest mode
+type LatestRun struct {
+	RunID     string
+	Manifest  TraceRun
+	TracePath string // Trace file to tail from the start
+}
+
+// RunFollower reports the newest run when it is not currentRunID. It returns
+// nil when there is no newer run.
+type RunFollower func(currentRunID string) (*LatestRun, error)
+
+// followInterval is how often the runs directory is checked for a newer run
+const followInterval = 2 * time.Second
+
+// FollowLatest returns a copy that loads newer runs as they appear, then tails
+// their trace files
+func (m Model) FollowLatest(follower RunFollower) Model {
+	m.runFollower = follower
+	return m
+}
+
+// watching reports whether the viewer needs periodic ticks
+func (m Model) watching() bool {
+	return (m.isLive && m.tracePath != "") || m.runFollower != nil
+}
+
+// checkLatestRun looks for a newer run and switches to it, or holds it until
+// the user leaves the detail view or search so the screen doesn't change
+// under them
+func (m Model) checkLatestRun(now time.Time) Model {
+	if m.pendingRun == nil && now.Sub(m.lastFollowCheck) >= followInterval {
+		m.lastFollowCheck = now
+		if run, err := m.runFollower(m.runID); err == nil && run != nil {
+			m.pendingRun = run
+		}
+	}
+
+	if m.pendingRun != nil && m.viewMode == TreeView && !m.searchMode {
+		m = m.switchToRun(*m.pendingRun)
+		m.pendingRun = nil
+	}
+	return m
+}
+
+// switchToRun replaces the displayed run with run, keeping the window size,
+// layout and display options, and starts tailing its trace from the beginning
+func (m Model) switchToRun(run LatestRun) Model {
+	m.runID = run.RunID
+	m.manifest = run.Manifest
+	m.roots = nil
+	m.nodeByID = nil
+	m.droppedParents = nil
+	m.zoomKey = ""
+	m.visibleNodes = nil
+	m.cursor = 0
+	m.tracePath = run.TracePath
+	m.lastOffset = 0
+	m.isLive = run.TracePath != ""
+	m.lastUpdate = time.Now()
+	m.ingest = nil
+	m.searchQuery = ""
+	m.searchMatches = nil
+	m.searchIndex = -1
+
+	if spans := m.checkFileUpdates(); len(spans) > 0 {
+		m = m.addNewSpans(spans)
+		m.ingest = nil // The backlog isn't a live arrival rate
+		m.isLive = !runFinished(spans)
+	} else {
+		m.computeMetrics()
+	}
+	m.followedRuns++
+	m.treeViewport.GotoTop()
+	return m
+}
+
+// renderFollowStatus describes follow-latest mode for the header
+func (m Model) renderFollowStatus() string {
+	if m.pendingRun != nil {
+		return WarningStyle.Render(fmt.Sprintf("⏭ newer run %s (loads on return to tree)", m.pendingRun.RunID))
+	}
+	status := "⏭ following latest"
+	if m.followedRuns > 0 {
+		status += fmt.Sprintf(" (%d new)", m.followedRuns)
+	}
+	return MutedStyle.Render(status)
+}
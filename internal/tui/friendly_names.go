@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// FriendlyNamesFileName is the rules file, looked up in the project's .agk
+// directory and in ~/.agk, that maps custom span names to tree labels
+const FriendlyNamesFileName = "span_names.json"
+
+// FriendlyNameRule labels spans whose name matches Match, a case-insensitive
+// glob such as "myapp.retrieve*" or "GET *", where * matches any characters,
+// '/' included, and ? any one. Label may reference attributes as
+// {attribute.key} and the original span name as {name}; a rule referencing
+// an attribute the span lacks doesn't apply.
+type FriendlyNameRule struct {
+	Match string `json:"match"`
+	Label string `json:"label"`
+}
+
+// friendlyNamesFile is the layout of span_names.json
+type friendlyNamesFile struct {
+	Rules []FriendlyNameRule `json:"rules"`
+}
+
+// friendlyNameRule is a FriendlyNameRule with its glob compiled
+type friendlyNameRule struct {
+	FriendlyNameRule
+	pattern *regexp.Regexp
+}
+
+var (
+	friendlyNameRulesMu sync.RWMutex
+	friendlyNameRules   []friendlyNameRule
+)
+
+// labelPlaceholder matches the {attribute.key} references in a rule label
+var labelPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// SetFriendlyNameRules replaces the custom rules GetFriendlyName consults
+// before its built-in ones. The first matching rule wins.
+func SetFriendlyNameRules(rules []FriendlyNameRule) error {
+	if err := validateFriendlyNameRules(rules); err != nil {
+		return err
+	}
+	compiled := make([]friendlyNameRule, len(rules))
+	for i, rule := range rules {
+		// Already validated
+		pattern, _ := globRegexp(rule.Match)
+		compiled[i] = friendlyNameRule{FriendlyNameRule: rule, pattern: pattern}
+	}
+
+	friendlyNameRulesMu.Lock()
+	defer friendlyNameRulesMu.Unlock()
+	friendlyNameRules = compiled
+	return nil
+}
+
+// LoadFriendlyNameRules reads the rules files at paths, in order, so earlier
+// files take precedence, and installs them with SetFriendlyNameRules. Paths
+// that don't exist are skipped.
+func LoadFriendlyNameRules(paths ...string) error {
+	var rules []FriendlyNameRule
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		var file friendlyNamesFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", p, err)
+		}
+		if err := validateFriendlyNameRules(file.Rules); err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		rules = append(rules, file.Rules...)
+	}
+	return SetFriendlyNameRules(rules)
+}
+
+// validateFriendlyNameRules checks every rule has a label and a valid pattern
+func validateFriendlyNameRules(rules []FriendlyNameRule) error {
+	for _, rule := range rules {
+		if rule.Match == "" || rule.Label == "" {
+			return fmt.Errorf("span name rule needs both match and label: %+v", rule)
+		}
+		if _, err := globRegexp(rule.Match); err != nil {
+			return fmt.Errorf("invalid span name pattern %q: %w", rule.Match, err)
+		}
+	}
+	return nil
+}
+
+// globRegexp turns a glob into an anchored, case-insensitive regexp. Unlike
+// path.Match, * matches any run of characters, '/' included, since span
+// names such as "GET /users/{id}" aren't paths. ? matches one character,
+// [...] a class and \ escapes the next character.
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	for i := 0; i < len(glob); i++ {
+		switch glob[i] {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return nil, errors.New("unclosed [")
+			}
+			b.WriteString(glob[i : i+end+2])
+			i += end + 1
+		case '\\':
+			if i+1 == len(glob) {
+				return nil, errors.New("trailing \\")
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// customFriendlyName returns the label of the first custom rule that applies
+// to the span
+func (s *Span) customFriendlyName() (string, bool) {
+	friendlyNameRulesMu.RLock()
+	defer friendlyNameRulesMu.RUnlock()
+	if len(friendlyNameRules) == 0 {
+		return "", false
+	}
+
+	attrs := s.GetAllAttributes()
+	for _, rule := range friendlyNameRules {
+		if !rule.pattern.MatchString(s.Name) {
+			continue
+		}
+		if label, ok := expandLabel(rule.Label, s.Name, attrs); ok {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// expandLabel fills in a rule label's placeholders, reporting false when it
+// references an attribute the span doesn't have
+func expandLabel(label, spanName string, attrs map[string]interface{}) (string, bool) {
+	complete := true
+	expanded := labelPlaceholder.ReplaceAllStringFunc(label, func(ref string) string {
+		key := ref[1 : len(ref)-1]
+		if value, ok := attrs[key]; ok {
+			return fmt.Sprintf("%v", value)
+		}
+		if key == "name" {
+			return spanName
+		}
+		complete = false
+		return ref
+	})
+	return expanded, complete
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyBinding describes a single key in the help overlay
+type keyBinding struct {
+	Keys string
+	Desc string
+}
+
+// helpSection groups the bindings available in one view
+type helpSection struct {
+	Title    string
+	Bindings []keyBinding
+}
+
+// helpSections lists every binding of the trace viewer, grouped by view.
+// Keep this in sync with the update* handlers.
+var helpSections = []helpSection{
+	{"Run List", []keyBinding{
+		{"↑/k ↓/j", "Move between runs"},
+		{"Enter/l/→", "Open run"},
+		{"s", "Cycle sort (time/duration/tokens/cost/status)"},
+		{"e", "Show all errors of the highlighted run"},
+		{"t", "Edit the highlighted run's notes and #tags"},
+		{"/", "Search the spans of every run"},
+		{"q", "Quit"},
+	}},
+	{"Search Results", []keyBinding{
+		{"↑/k ↓/j", "Move between matching spans"},
+		{"Enter/l/→", "Open the run at the span; n/N then move between its matches"},
+		{"/", "New search"},
+		{"Esc", "Back to run list"},
+	}},
+	{"Tree", []keyBinding{
+		{"↑/k ↓/j", "Move between spans"},
+		{"Enter/l", "Expand span"},
+		{"h", "Collapse span"},
+		{"Space", "Toggle span"},
+		{"x", "Expand/collapse a group of repeated spans"},
+		{"X", "Toggle grouping of repeated sibling spans"},
+		{"o", "Group spans with a missing parent under one node"},
+		{"f", "Focus on the selected span's subtree"},
+		{"a", "Highlight attributes that differ from the previous span"},
+		{"Tab/Shift+Tab", "Cycle panel focus"},
+		{"←/→", "Previous/next detail tab"},
+		{"1-5", "Jump to detail tab"},
+		{"d", "Open detail view"},
+		{"v", "Cycle layout (full/compact/single pane)"},
+		{"e/E", "Next/previous error"},
+		{"[ ]", "Previous/next run"},
+		{"t", "Edit the run's notes and #tags"},
+		{"Esc", "Clear search, zoom out, clear filters, or back to search results or run list"},
+		{"q", "Quit"},
+	}},
+	{"Search", []keyBinding{
+		{"/", "Start search"},
+		{"Enter", "Run search"},
+		{"Esc", "Cancel search"},
+		{"n/N", "Next/previous match"},
+	}},
+	{"Detail", []keyBinding{
+		{"←/→", "Previous/next tab"},
+		{"1-5", "Jump to tab"},
+		{"↑/↓ PgUp/PgDn", "Scroll"},
+		{"+/-", "Show more/less content"},
+		{"f", "Toggle full content"},
+		{"a", "Highlight attributes that differ from the previous span"},
+		{"y", "Copy span as OTLP JSON"},
+		{"Y", "Copy span as curl to the collector"},
+		{"j/k", "Select an attribute (Attributes tab)"},
+		{"c", "Copy the selected attribute's full key"},
+		{"F", "Show only spans with the selected attribute's value"},
+		{"Esc", "Back to tree"},
+		{"q", "Quit"},
+	}},
+	{"Anywhere", []keyBinding{
+		{"?", "Toggle this help"},
+	}},
+}
+
+// helpOverlayStyle frames the help overlay
+var helpOverlayStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(primaryColor).
+	Padding(1, 2)
+
+// renderHelpOverlay renders the full keybinding reference centered on screen
+func (m Model) renderHelpOverlay() string {
+	var columns []string
+	for _, section := range helpSections {
+		var b strings.Builder
+		b.WriteString(SectionHeaderStyle.Render(section.Title))
+		b.WriteString("\n")
+		for _, kb := range section.Bindings {
+			b.WriteString(fmt.Sprintf("%s %s\n", HelpKeyStyle.Render(fmt.Sprintf("%-14s", kb.Keys)), kb.Desc))
+		}
+		columns = append(columns, b.String())
+	}
+
+	// Lay sections out in two columns when there's room
+	var body string
+	left, right := columns[:(len(columns)+1)/2], columns[(len(columns)+1)/2:]
+	leftCol := strings.Join(left, "\n")
+	rightCol := strings.Join(right, "\n")
+	if lipgloss.Width(leftCol)+lipgloss.Width(rightCol)+10 < m.width {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, leftCol, "    ", rightCol)
+	} else {
+		body = strings.Join(columns, "\n")
+	}
+
+	content := TitleStyle.Render("Keyboard Shortcuts") + "\n" + body + "\n" +
+		MutedStyle.Render("Press any key to close")
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, helpOverlayStyle.Render(content))
+}
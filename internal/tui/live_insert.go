@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/agenticgokit/agk/internal/utils"
+)
+
+// reshaped reports whether a view setting changes the tree's shape, so
+// spans can't simply be added to it
+func (m Model) reshaped() bool {
+	return len(m.includeScopes) > 0 || len(m.excludeScopes) > 0 || len(m.attrFilters) > 0 ||
+		m.showDepth > 0 || m.groupOrphans || m.collapseRepeats || m.zoomKey != ""
+}
+
+// insertSpans adds newly arrived spans to the tree in place, so a live
+// update takes time for the new spans and the subtrees they adopt rather
+// than for the whole trace. It returns false, changing nothing, when the
+// tree has to be rebuilt instead: before it is indexed, when a span
+// replaces an earlier copy, or when a view setting reshapes the tree.
+func (m *Model) insertSpans(spans []Span) bool {
+	if m.nodeByID == nil || m.reshaped() {
+		return false
+	}
+	added := make([]*SpanNode, 0, len(spans))
+	fresh := make(map[string]*SpanNode, len(spans))
+	for _, span := range spans {
+		id := span.SpanContext.SpanID
+		if m.nodeByID[id] != nil || fresh[id] != nil {
+			return false
+		}
+		node := newSpanNode(span)
+		added = append(added, node)
+		fresh[id] = node
+	}
+
+	var selected *SpanNode
+	if m.cursor < len(m.visibleNodes) {
+		selected = m.visibleNodes[m.cursor]
+	}
+
+	// Spans are written when they end, so children usually arrive before
+	// their parent: roots waiting for one of the new spans move under it
+	roots := m.roots[:0:0]
+	for _, root := range m.roots {
+		if parent := fresh[root.Span.Parent.SpanID]; parent != nil {
+			m.removeVisible(root)
+			attachChild(parent, root)
+		} else {
+			roots = append(roots, root)
+		}
+	}
+	m.roots = roots
+
+	var shrunk []*SpanNode
+	for _, node := range added {
+		parent := fresh[node.Span.Parent.SpanID]
+		if parent == nil {
+			if parent = m.nodeByID[node.Span.Parent.SpanID]; parent != nil {
+				shrunk = append(shrunk, parent)
+			}
+		}
+		if parent != nil && parent != node {
+			attachChild(parent, node)
+		}
+	}
+	for _, node := range added {
+		m.nodeByID[node.Span.SpanContext.SpanID] = node
+		setSelfTime(node)
+	}
+	for _, node := range shrunk {
+		setSelfTime(node)
+	}
+
+	// Place each new subtree under an existing span, or among the roots
+	var tops []*SpanNode
+	for _, node := range added {
+		if node.Parent == nil || fresh[node.Parent.Span.SpanContext.SpanID] == nil {
+			tops = append(tops, node)
+		}
+	}
+	pending := make(map[*SpanNode]bool, len(tops))
+	for _, top := range tops {
+		depth := 0
+		if top.Parent != nil {
+			depth = top.Parent.Depth + 1
+		} else {
+			m.roots = insertByTime(m.roots, top)
+		}
+		m.treeDepth = max(m.treeDepth, setDepth(top, depth)+1)
+		pending[top] = true
+	}
+	for _, top := range tops {
+		delete(pending, top)
+		if shownUnder(top.Parent) {
+			m.insertVisible(top, pending)
+		}
+	}
+
+	m.spanTotal += len(added)
+	m.manifest.SpanCount += len(added)
+	m.updateMetrics(added, shrunk)
+	m.restoreCursor(selected)
+	return true
+}
+
+// attachChild makes child the last of parent's children to have started
+func attachChild(parent, child *SpanNode) {
+	child.Parent = parent
+	parent.Children = insertByTime(parent.Children, child)
+}
+
+// insertByTime inserts node among siblings sorted by start time, after any
+// that started at the same time
+func insertByTime(nodes []*SpanNode, node *SpanNode) []*SpanNode {
+	start, _ := utils.ParseTimestamp(node.Span.StartTime)
+	i := len(nodes)
+	for i > 0 {
+		previous, _ := utils.ParseTimestamp(nodes[i-1].Span.StartTime)
+		if !previous.After(start) {
+			break
+		}
+		i--
+	}
+	return slices.Insert(nodes, i, node)
+}
+
+// setDepth sets the depth of node and its descendants, returning the
+// deepest one's
+func setDepth(node *SpanNode, depth int) int {
+	node.Depth = depth
+	deepest := depth
+	for _, child := range node.Children {
+		deepest = max(deepest, setDepth(child, depth+1))
+	}
+	return deepest
+}
+
+// shownUnder reports whether the children of parent are visible, which
+// they are for roots (nil) and under expanded spans with expanded ancestors
+func shownUnder(parent *SpanNode) bool {
+	for ; parent != nil; parent = parent.Parent {
+		if !parent.Expanded {
+			return false
+		}
+	}
+	return true
+}
+
+// removeVisible removes node and its visible descendants from the visible
+// list
+func (m *Model) removeVisible(node *SpanNode) {
+	i := slices.Index(m.visibleNodes, node)
+	if i < 0 {
+		return
+	}
+	var block []*SpanNode
+	flattenNode(node, &block)
+	m.visibleNodes = slices.Delete(m.visibleNodes, i, i+len(block))
+}
+
+// insertVisible adds node and its visible descendants to the visible list,
+// before the span that follows them in the tree. Subtrees in pending
+// aren't listed yet and are skipped over.
+func (m *Model) insertVisible(node *SpanNode, pending map[*SpanNode]bool) {
+	var block []*SpanNode
+	flattenNode(node, &block)
+
+	at := len(m.visibleNodes)
+	if next := nextListed(node, m.roots, pending); next != nil {
+		if i := slices.Index(m.visibleNodes, next); i >= 0 {
+			at = i
+		}
+	}
+	m.visibleNodes = slices.Insert(m.visibleNodes, at, block...)
+}
+
+// nextListed returns the first span after node's subtree in tree order
+// that isn't pending, or nil when node's subtree comes last
+func nextListed(node *SpanNode, roots []*SpanNode, pending map[*SpanNode]bool) *SpanNode {
+	for ; node != nil; node = node.Parent {
+		siblings := roots
+		if node.Parent != nil {
+			siblings = node.Parent.Children
+		}
+		i := slices.Index(siblings, node)
+		for _, sibling := range siblings[i+1:] {
+			if !pending[sibling] {
+				return sibling
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// liveSpan returns a span running from startMs to endMs after a fixed time
+func liveSpan(id, parent string, startMs, endMs int) Span {
+	at := func(ms int) string {
+		return time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC).Add(time.Duration(ms) * time.Millisecond).Format(time.RFC3339Nano)
+	}
+	return Span{
+		Name:        "span." + id,
+		StartTime:   at(startMs),
+		EndTime:     at(endMs),
+		SpanContext: SpanContext{SpanID: id},
+		Parent:      ParentSpan{SpanID: parent},
+	}
+}
+
+// treeSummary describes what the viewer shows: the visible spans with
+// their depth and self-time, the tree size and the metrics
+func treeSummary(m Model) []string {
+	var summary []string
+	for _, node := range m.visibleNodes {
+		summary = append(summary, fmt.Sprintf("%s depth=%d self=%d", node.Span.SpanContext.SpanID, node.Depth, node.SelfTimeMs))
+	}
+	summary = append(summary, fmt.Sprintf("spans=%d depth=%d tokens=%d errors=%d", m.spanTotal, m.treeDepth, m.totalTokens, m.errorCount))
+	for _, node := range m.top3Slowest {
+		summary = append(summary, "top "+node.Span.SpanContext.SpanID)
+	}
+	if m.slowestSpan != nil {
+		summary = append(summary, "slowest "+m.slowestSpan.Span.SpanContext.SpanID)
+	}
+	return summary
+}
+
+func TestAddNewSpansInPlace(t *testing.T) {
+	llm := liveSpan("llm", "plan", 10, 100)
+	llm.Attributes = []map[string]interface{}{
+		{"Key": "llm.usage.total_tokens", "Value": map[string]interface{}{"Type": "INT64", "Value": float64(42)}},
+	}
+	batches := [][]Span{
+		// Children arrive before the parents they wait for, and within a
+		// batch in either order
+		{liveSpan("plan", "root", 0, 300), liveSpan("tool.exec", "tool", 450, 850), liveSpan("tool", "root", 400, 900)},
+		// A late child shrinks plan, one of the slowest spans
+		{liveSpan("retry", "plan", 150, 290)},
+		{liveSpan("root", "0000000000000000", 0, 1000)},
+	}
+
+	m := NewTraceViewer("run-1", TraceRun{SpanCount: 1}, []Span{llm})
+	first := m.roots[0]
+	all := []Span{llm}
+	for i, batch := range batches {
+		m = m.addNewSpans(batch)
+		all = append(all, batch...)
+
+		want := treeSummary(NewTraceViewer("run-1", TraceRun{}, all))
+		if got := treeSummary(m); !reflect.DeepEqual(got, want) {
+			t.Errorf("after batch %d:\n got %v\nwant %v", i+1, got, want)
+		}
+	}
+	if m.nodeByID["llm"] != first {
+		t.Error("the existing llm node was replaced; want spans added to the tree in place")
+	}
+	if m.manifest.SpanCount != len(all) {
+		t.Errorf("SpanCount = %d, want %d", m.manifest.SpanCount, len(all))
+	}
+}
+
+func TestAddNewSpansFallsBackToRebuild(t *testing.T) {
+	spans := []Span{liveSpan("root", "", 0, 1000), liveSpan("llm", "root", 10, 500)}
+
+	t.Run("repeated span", func(t *testing.T) {
+		m := NewTraceViewer("run-1", TraceRun{}, spans)
+		before := m.nodeByID["llm"]
+
+		// The span is written again, now with its error status
+		failed := liveSpan("llm", "root", 10, 500)
+		failed.Status.Code = "Error"
+		m = m.addNewSpans([]Span{failed})
+
+		if m.nodeByID["llm"] == before {
+			t.Error("llm node kept; want the tree rebuilt around the new copy")
+		}
+		if m.spanTotal != 2 || m.errorCount != 1 {
+			t.Errorf("spanTotal = %d, errorCount = %d; want 2 and 1", m.spanTotal, m.errorCount)
+		}
+	})
+
+	t.Run("orphan groups", func(t *testing.T) {
+		m := NewTraceViewer("run-1", TraceRun{}, spans).GroupOrphanedSpans(true)
+		before := m.nodeByID["root"]
+
+		orphan := liveSpan("orphan", "missing", 600, 700)
+		m = m.addNewSpans([]Span{orphan})
+
+		if m.nodeByID["root"] == before {
+			t.Error("root node kept; want the tree rebuilt with the orphan grouped")
+		}
+		want := treeSummary(NewTraceViewer("run-1", TraceRun{}, append(spans, orphan)).GroupOrphanedSpans(true))
+		if got := treeSummary(m); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v\nwant %v", got, want)
+		}
+	})
+}
+
+func TestAddNewSpansScopeFilterAcrossBatches(t *testing.T) {
+	scoped := func(span Span, scope string) Span {
+		span.InstrumentationScope = map[string]interface{}{"Name": scope}
+		return span
+	}
+	root := scoped(liveSpan("root", "", 0, 1000), "agk")
+	http := scoped(liveSpan("http", "root", 10, 900), "net/http")
+	llm := scoped(liveSpan("llm", "http", 20, 800), "agk")
+
+	tests := []struct {
+		name    string
+		batches [][]Span
+	}{
+		{name: "child before dropped parent", batches: [][]Span{{llm}, {http}, {root}}},
+		{name: "dropped parent before child", batches: [][]Span{{http}, {llm}, {root}}},
+		{name: "one batch", batches: [][]Span{{llm, http, root}}},
+	}
+	want := treeSummary(NewTraceViewer("run-1", TraceRun{}, []Span{root, http, llm}).ScopeFilter(nil, []string{"net/http"}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTraceViewer("run-1", TraceRun{}, nil).ScopeFilter(nil, []string{"net/http"})
+			for _, batch := range tt.batches {
+				m = m.addNewSpans(batch)
+			}
+			if got := treeSummary(m); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v\nwant %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agenticgokit/agk/internal/utils"
+)
+
+// orphanGroupName labels the synthetic node that holds orphaned spans
+const orphanGroupName = "⚠ Orphaned spans"
+
+// noParentSpanID is the parent span ID exporters write for root spans
+const noParentSpanID = "0000000000000000"
+
+// IsOrphan reports whether the span names a parent that is missing from the
+// trace, e.g. because sampling dropped it
+func (n *SpanNode) IsOrphan() bool {
+	if n.Synthetic || (n.Parent != nil && !n.Parent.Synthetic) {
+		return false
+	}
+	parentID := n.Span.Parent.SpanID
+	return parentID != "" && parentID != noParentSpanID
+}
+
+// GroupOrphans moves roots whose parent is missing from the trace under one
+// synthetic node after the real roots, so sampled or truncated traces keep
+// their true root distinct from the fragments
+func GroupOrphans(roots []*SpanNode) []*SpanNode {
+	var kept, orphans []*SpanNode
+	for _, root := range roots {
+		if root.IsOrphan() {
+			orphans = append(orphans, root)
+		} else {
+			kept = append(kept, root)
+		}
+	}
+	if len(orphans) == 0 {
+		return roots
+	}
+
+	group := &SpanNode{
+		Span:      Span{Name: orphanGroupName},
+		Children:  orphans,
+		Expanded:  true,
+		Synthetic: true,
+	}
+	var start, end time.Time
+	for _, orphan := range orphans {
+		orphan.Parent = group
+		setDepths(orphan, 1)
+		if t, err := utils.ParseTimestamp(orphan.Span.StartTime); err == nil && (start.IsZero() || t.Before(start)) {
+			start = t
+			group.Span.StartTime = orphan.Span.StartTime
+		}
+		if t, err := utils.ParseTimestamp(orphan.Span.EndTime); err == nil && t.After(end) {
+			end = t
+			group.Span.EndTime = orphan.Span.EndTime
+		}
+	}
+	group.DurationMs, _ = calculateDuration(group.Span.StartTime, group.Span.EndTime)
+
+	return append(kept, group)
+}
+
+// SpanNodes returns every node that stands for a real span, leaving out
+// synthetic grouping nodes
+func SpanNodes(roots []*SpanNode) []*SpanNode {
+	var result []*SpanNode
+	for _, node := range AllNodes(roots) {
+		if !node.Synthetic {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// GroupOrphanedSpans returns a copy that gathers spans whose parent is missing
+// from the trace under a synthetic "⚠ Orphaned spans" node
+func (m Model) GroupOrphanedSpans(group bool) Model {
+	if m.groupOrphans == group {
+		return m
+	}
+	m.groupOrphans = group
+	m.setTree(m.collectAllSpans())
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	m.cursor = 0
+	m.applyRepeatCollapsing()
+	m.computeMetrics()
+	return m
+}
+
+// buildTree builds the span tree, grouping orphans, folding levels past the
+// depth limit and hiding spans outside the attribute filters when enabled
+func (m Model) buildTree(spans []Span) []*SpanNode {
+	roots := BuildSpanTree(spans)
+	if m.groupOrphans {
+		roots = GroupOrphans(roots)
+	}
+	FoldBelowDepth(roots, m.showDepth)
+	markFilteredOut(roots, m.attrFilters)
+	return roots
+}
+
+// setTree replaces the tree with one built from spans, indexing its nodes
+// for live updates
+func (m *Model) setTree(spans []Span) {
+	m.roots = m.buildTree(spans)
+	m.nodeByID = indexNodes(m.roots)
+}
+
+// indexNodes maps span IDs to their nodes
+func indexNodes(roots []*SpanNode) map[string]*SpanNode {
+	byID := make(map[string]*SpanNode)
+	for _, node := range SpanNodes(roots) {
+		byID[node.Span.SpanContext.SpanID] = node
+	}
+	return byID
+}
+
+// orphanGroupLabel annotates the synthetic orphan node with its size
+func (n *SpanNode) orphanGroupLabel() string {
+	return fmt.Sprintf(" (%d without a parent in this trace)", len(n.Children))
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// DefaultRefreshInterval is how often a live trace file is polled while
+	// spans are arriving
+	DefaultRefreshInterval = 500 * time.Millisecond
+	// maxRefreshBackoff caps how far polling slows down, as a multiple of the
+	// refresh interval, while the trace file is idle
+	maxRefreshBackoff = 8
+)
+
+// RefreshInterval returns a copy that polls live trace files every interval.
+// Polling backs off while nothing changes and returns to interval as soon as
+// new spans arrive.
+func (m Model) RefreshInterval(interval time.Duration) Model {
+	m.refreshInterval = interval
+	m.pollInterval = 0
+	return m
+}
+
+// baseRefresh returns the effective refresh interval
+func (m Model) baseRefresh() time.Duration {
+	if m.refreshInterval <= 0 {
+		return DefaultRefreshInterval
+	}
+	return m.refreshInterval
+}
+
+// tickCmd returns a command that sends a tick after the current poll interval
+func (m Model) tickCmd() tea.Cmd {
+	interval := m.pollInterval
+	if interval <= 0 {
+		interval = m.baseRefresh()
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// adjustPolling speeds polling back up after activity and doubles the
+// interval, up to maxRefreshBackoff times the base, after an idle tick
+func (m *Model) adjustPolling(active bool) {
+	base := m.baseRefresh()
+	if active || m.pollInterval <= 0 {
+		m.pollInterval = base
+		if active {
+			return
+		}
+	}
+	m.pollInterval = min(m.pollInterval*2, base*maxRefreshBackoff)
+}
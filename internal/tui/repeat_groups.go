@@ -0,0 +1,144 @@
+package tui
+
+import "fmt"
+
+// CollapseRepeats folds each run of consecutive siblings with the same
+// display name into the first span of the run, which then stands for the
+// whole group. Loops that call the same tool many times become one line.
+func CollapseRepeats(roots []*SpanNode) {
+	collapseRepeatRuns(roots)
+	for _, node := range AllNodes(roots) {
+		collapseRepeatRuns(node.Children)
+	}
+}
+
+// ExpandAllRepeats undoes CollapseRepeats
+func ExpandAllRepeats(roots []*SpanNode) {
+	for _, node := range AllNodes(roots) {
+		node.Repeats = nil
+		node.RepeatsExpanded = false
+		node.repeatOf = nil
+	}
+}
+
+func collapseRepeatRuns(siblings []*SpanNode) {
+	for i := 0; i < len(siblings); {
+		head := siblings[i]
+		key := repeatKey(head)
+		j := i + 1
+		for j < len(siblings) && repeatKey(siblings[j]) == key {
+			j++
+		}
+
+		head.repeatOf = nil
+		head.Repeats = nil
+		head.RepeatsExpanded = false
+		if j-i > 1 {
+			head.Repeats = append([]*SpanNode(nil), siblings[i+1:j]...)
+			for _, repeat := range head.Repeats {
+				repeat.repeatOf = head
+				repeat.Repeats = nil
+			}
+		}
+		i = j
+	}
+}
+
+// repeatKey identifies spans that repeat the same step. Tool calls can share
+// a display name, so the tool name is part of the key.
+func repeatKey(n *SpanNode) string {
+	tool, _ := n.Span.GetAttribute("agk.tool.name")
+	return fmt.Sprintf("%s\x00%v", n.Span.GetFriendlyName(), tool)
+}
+
+// IsCollapsedGroup reports whether the node currently stands for a group of
+// identical siblings
+func (n *SpanNode) IsCollapsedGroup() bool {
+	return len(n.Repeats) > 0 && !n.RepeatsExpanded
+}
+
+// hiddenRepeat reports whether the node is folded into a collapsed group
+func (n *SpanNode) hiddenRepeat() bool {
+	return n.repeatOf != nil && !n.repeatOf.RepeatsExpanded
+}
+
+// GroupDurationMs returns the summed duration of the node and its repeats
+func (n *SpanNode) GroupDurationMs() int64 {
+	total := n.DurationMs
+	for _, repeat := range n.Repeats {
+		total += repeat.DurationMs
+	}
+	return total
+}
+
+// groupLabel annotates a collapsed group with its size, e.g. " ×12"
+func (n *SpanNode) groupLabel() string {
+	return fmt.Sprintf(" ×%d", len(n.Repeats)+1)
+}
+
+// CollapseRepeatedSpans returns a copy that folds repeated sibling spans
+// into one line each; press x on a group to expand it
+func (m Model) CollapseRepeatedSpans(collapse bool) Model {
+	m.collapseRepeats = collapse
+	m.applyRepeatCollapsing()
+	return m
+}
+
+// applyRepeatCollapsing regroups the tree after it was rebuilt or the
+// setting changed, keeping the cursor on the same node where possible
+func (m *Model) applyRepeatCollapsing() {
+	var selected *SpanNode
+	if m.cursor < len(m.visibleNodes) {
+		selected = m.visibleNodes[m.cursor]
+	}
+
+	if m.collapseRepeats {
+		CollapseRepeats(m.roots)
+	} else {
+		ExpandAllRepeats(m.roots)
+	}
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	m.restoreCursor(selected)
+}
+
+// toggleSelectedGroup expands the selected group or, when the cursor is on
+// an expanded group or one of its members, collapses it again
+func (m Model) toggleSelectedGroup() Model {
+	if m.cursor >= len(m.visibleNodes) {
+		return m
+	}
+	node := m.visibleNodes[m.cursor]
+	head := node
+	if node.repeatOf != nil {
+		head = node.repeatOf
+	}
+	if len(head.Repeats) == 0 {
+		return m
+	}
+
+	head.RepeatsExpanded = !head.RepeatsExpanded
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	if head.RepeatsExpanded {
+		m.restoreCursor(node)
+	} else {
+		m.restoreCursor(head)
+	}
+	return m
+}
+
+// restoreCursor moves the cursor to node if it is visible, otherwise it
+// keeps the cursor in range
+func (m *Model) restoreCursor(node *SpanNode) {
+	for i, visible := range m.visibleNodes {
+		if visible == node {
+			m.cursor = i
+			return
+		}
+	}
+	if m.cursor >= len(m.visibleNodes) {
+		m.cursor = len(m.visibleNodes) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// spanHasError reports whether a span ended with an error status
+func spanHasError(span Span) bool {
+	return span.Status.Code != "" && span.Status.Code != StatusUnset && span.Status.Code != "Ok"
+}
+
+// collectRunErrors records the error spans of every run so the run list
+// can summarize them without rescanning spans on each render
+func collectRunErrors(runs []RunData) {
+	for i := range runs {
+		// Lazily loaded runs come with their errors already scanned
+		if runs[i].LoadSpans != nil {
+			continue
+		}
+		runs[i].errorSpans = nil
+		for _, span := range runs[i].Spans {
+			if spanHasError(span) {
+				runs[i].errorSpans = append(runs[i].errorSpans, span)
+			}
+		}
+	}
+}
+
+// spanErrorMessage describes why a span failed, falling back to its status
+// code when no description was recorded
+func spanErrorMessage(span Span) string {
+	msg := span.Status.Description
+	if msg == "" {
+		msg = span.Status.Code
+	}
+	return fmt.Sprintf("%s: %s", span.GetFriendlyName(), strings.Join(strings.Fields(msg), " "))
+}
+
+// renderRunErrors renders the error lines shown below a run in the run list:
+// the first error, or every error when expanded
+func renderRunErrors(run RunData, expanded bool, width int) string {
+	if len(run.errorSpans) == 0 {
+		if runFailed(run.Manifest) && expanded {
+			return "      " + MutedStyle.Render("No error spans recorded") + "\n"
+		}
+		return ""
+	}
+
+	spans := run.errorSpans
+	if !expanded {
+		spans = spans[:1]
+	}
+
+	maxLen := width - 8
+	if maxLen < 20 {
+		maxLen = 20
+	}
+
+	var b strings.Builder
+	for _, span := range spans {
+		line := "✗ " + spanErrorMessage(span)
+		if runes := []rune(line); len(runes) > maxLen {
+			line = string(runes[:maxLen-1]) + "…"
+		}
+		b.WriteString("      ")
+		b.WriteString(ErrorStyle.Render(line))
+		b.WriteString("\n")
+	}
+	if !expanded && len(run.errorSpans) > 1 {
+		b.WriteString("      ")
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("+%d more  [e] show all", len(run.errorSpans)-1)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunLoader loads the i-th run for the explorer. ok is false for runs to
+// leave out, such as directories without a readable manifest.
+type RunLoader func(i int) (run RunData, ok bool)
+
+// runLoadedMsg reports that the run at index has been loaded
+type runLoadedMsg struct {
+	index int
+	run   RunData
+	ok    bool
+}
+
+// ExplorerLoader shows "Loading run X/N" while runs are read in the
+// background, then hands over to the trace explorer
+type ExplorerLoader struct {
+	total     int
+	load      RunLoader
+	configure func(Model) Model
+	runs      []RunData
+	loaded    int
+	width     int
+	height    int
+}
+
+// NewExplorerLoader loads total runs with load, then opens an explorer
+// over them, passed through configure for options such as EditableNotes
+func NewExplorerLoader(total int, load RunLoader, configure func(Model) Model) ExplorerLoader {
+	return ExplorerLoader{total: total, load: load, configure: configure}
+}
+
+// NoRuns reports whether loading finished without a single run to show,
+// in which case the program quits without opening the explorer
+func (l ExplorerLoader) NoRuns() bool {
+	return l.loaded == l.total && len(l.runs) == 0
+}
+
+// Init starts loading the first run
+func (l ExplorerLoader) Init() tea.Cmd {
+	if l.total == 0 {
+		return tea.Quit
+	}
+	return l.loadCmd(0)
+}
+
+// loadCmd loads the run at index in the background
+func (l ExplorerLoader) loadCmd(index int) tea.Cmd {
+	if index >= l.total {
+		return nil
+	}
+	return func() tea.Msg {
+		run, ok := l.load(index)
+		return runLoadedMsg{index: index, run: run, ok: ok}
+	}
+}
+
+// Update records loaded runs and switches to the explorer once all are in
+func (l ExplorerLoader) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.width, l.height = msg.Width, msg.Height
+		return l, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return l, tea.Quit
+		}
+		return l, nil
+
+	case runLoadedMsg:
+		l.loaded = msg.index + 1
+		if msg.ok {
+			l.runs = append(l.runs, msg.run)
+		}
+		if l.loaded < l.total {
+			return l, l.loadCmd(l.loaded)
+		}
+		return l.openExplorer()
+	}
+	return l, nil
+}
+
+// openExplorer replaces the loading screen with the explorer, giving it the
+// window size the loader already received
+func (l ExplorerLoader) openExplorer() (tea.Model, tea.Cmd) {
+	if len(l.runs) == 0 {
+		return l, tea.Quit
+	}
+
+	explorer := NewTraceExplorer(l.runs)
+	if l.configure != nil {
+		explorer = l.configure(explorer)
+	}
+	if l.width == 0 {
+		return explorer, explorer.Init()
+	}
+	next, cmd := explorer.Update(tea.WindowSizeMsg{Width: l.width, Height: l.height})
+	return next, tea.Batch(next.Init(), cmd)
+}
+
+// View renders the loading screen
+func (l ExplorerLoader) View() string {
+	line := fmt.Sprintf("Loading run %d/%d", min(l.loaded+1, l.total), l.total)
+	return "\n  " + TitleStyle.Render("AGK Trace Explorer") + "\n\n  " +
+		MutedStyle.Render(line+"…") + "\n"
+}
+
+// LazyRunData describes a run whose spans are parsed by loadSpans only when
+// it is opened. The run list still summarizes its errors, found by scanning
+// data for spans that ended with an error status.
+func LazyRunData(manifest TraceRun, data string, loadSpans func() ([]Span, error)) RunData {
+	return RunData{
+		Manifest:   manifest,
+		LoadSpans:  loadSpans,
+		errorSpans: scanErrorSpans(data),
+	}
+}
+
+// scanErrorSpans returns the spans of JSONL trace data with an error status.
+// Lines with an Ok or Unset status are skipped unparsed, which is most of
+// them, so this is much cheaper than ParseSpans.
+func scanErrorSpans(data string) []Span {
+	var errors []Span
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) == "" ||
+			strings.Contains(line, `"Code":"Ok"`) || strings.Contains(line, `"Code":"`+StatusUnset+`"`) {
+			continue
+		}
+		if spans := ParseSpans(line); len(spans) == 1 && spanHasError(spans[0]) {
+			errors = append(errors, spans[0])
+		}
+	}
+	return errors
+}
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunNotes is a free-text note and tags attached to a run while reviewing it
+type RunNotes struct {
+	Note      string    `json:"note,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// NotesSaver persists the notes of a run
+type NotesSaver func(runID string, notes RunNotes) error
+
+// ParseNotes reads notes typed as one line: words starting with '#' become
+// tags and the rest is the note, e.g. "after prompt v3 #baseline"
+func ParseNotes(input string) RunNotes {
+	var notes RunNotes
+	var words []string
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(input) {
+		if tag := strings.TrimPrefix(word, "#"); tag != word && tag != "" {
+			tag = strings.ToLower(tag)
+			if !seen[tag] {
+				seen[tag] = true
+				notes.Tags = append(notes.Tags, tag)
+			}
+			continue
+		}
+		words = append(words, word)
+	}
+	notes.Note = strings.Join(words, " ")
+	sort.Strings(notes.Tags)
+	return notes
+}
+
+// String formats notes the way ParseNotes reads them
+func (n RunNotes) String() string {
+	parts := make([]string, 0, len(n.Tags)+1)
+	if n.Note != "" {
+		parts = append(parts, n.Note)
+	}
+	for _, tag := range n.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// IsEmpty reports whether there is neither a note nor tags
+func (n RunNotes) IsEmpty() bool {
+	return n.Note == "" && len(n.Tags) == 0
+}
+
+// HasTag reports whether the notes carry tag, ignoring case
+func (n RunNotes) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	for _, t := range n.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// EditableNotes returns a copy where t opens an input to annotate the run,
+// saving the result with saver
+func (m Model) EditableNotes(saver NotesSaver) Model {
+	m.notesSaver = saver
+	return m
+}
+
+// startNotesEdit opens the notes input for runID, prefilled with its notes
+func (m Model) startNotesEdit(runID string, notes RunNotes) Model {
+	if m.notesSaver == nil || runID == "" {
+		m.statusMessage = WarningStyle.Render("Notes not available")
+		return m
+	}
+	m.notesMode = true
+	m.notesRunID = runID
+	m.notesInput = notes.String()
+	return m
+}
+
+// updateNotesInput handles keyboard input while editing notes
+func (m Model) updateNotesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.notesMode = false
+		return m, nil
+
+	case "enter":
+		m.notesMode = false
+		m = m.saveNotes(ParseNotes(m.notesInput))
+		return m, nil
+
+	case "backspace":
+		if len(m.notesInput) > 0 {
+			runes := []rune(m.notesInput)
+			m.notesInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+
+	default:
+		// Pasted text arrives as one event with several runes
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.notesInput += string(msg.Runes)
+		}
+		return m, nil
+	}
+}
+
+// saveNotes persists notes for the run being edited and updates every copy
+// of its manifest the viewer holds
+func (m Model) saveNotes(notes RunNotes) Model {
+	if err := m.notesSaver(m.notesRunID, notes); err != nil {
+		m.statusMessage = ErrorStyle.Render(fmt.Sprintf("Saving notes failed: %v", err))
+		return m
+	}
+
+	for i := range m.allRuns {
+		if m.allRuns[i].Manifest.RunID == m.notesRunID {
+			m.allRuns[i].Manifest.Notes = notes
+		}
+	}
+	if m.runID == m.notesRunID {
+		m.manifest.Notes = notes
+	}
+	m.statusMessage = SuccessStyle.Render(fmt.Sprintf("✓ Saved notes for %s", m.notesRunID))
+	return m
+}
+
+// renderNotesBar renders the notes input
+func (m Model) renderNotesBar() string {
+	hint := MutedStyle.Render("  #word adds a tag · Enter saves · Esc cancels")
+	return BoxStyle.Render(fmt.Sprintf("Notes for %s: %s█", m.notesRunID, m.notesInput)) + hint
+}
+
+// renderNotes formats notes for one line, tags first
+func renderNotes(notes RunNotes) string {
+	var parts []string
+	for _, tag := range notes.Tags {
+		parts = append(parts, SuccessStyle.Render("#"+tag))
+	}
+	if notes.Note != "" {
+		parts = append(parts, MutedStyle.Render(notes.Note))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runSearchHit is a span matching a search across every run in the explorer
+type runSearchHit struct {
+	runID string
+	span  Span
+}
+
+// executeRunSearch searches the spans of every run for the search query,
+// opening the results when there are any. Lazily loaded runs are read
+// first, so the first search over many runs can take a moment.
+func (m Model) executeRunSearch() Model {
+	m.runSearchHits = nil
+	m.runSearchCursor = 0
+	if m.searchQuery == "" {
+		return m
+	}
+
+	query := strings.ToLower(m.searchQuery)
+	for i := range m.allRuns {
+		m.ensureRunSpans(i)
+		run := m.allRuns[i]
+		for _, span := range run.Spans {
+			if m.matchesSearch(&SpanNode{Span: span}, query) {
+				m.runSearchHits = append(m.runSearchHits, runSearchHit{runID: run.Manifest.RunID, span: span})
+			}
+		}
+	}
+	m.runSearchQuery = m.searchQuery
+
+	if len(m.runSearchHits) == 0 {
+		m.statusMessage = WarningStyle.Render(fmt.Sprintf("No spans match %q in %d runs", m.searchQuery, len(m.allRuns)))
+		return m
+	}
+	m.viewMode = RunSearchView
+	return m
+}
+
+// updateRunSearchView handles input in the results of a search across runs
+func (m Model) updateRunSearchView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", CtrlC:
+		return m, tea.Quit
+
+	case KeyUp, "k":
+		if m.runSearchCursor > 0 {
+			m.runSearchCursor--
+		}
+
+	case KeyDown, "j":
+		if m.runSearchCursor < len(m.runSearchHits)-1 {
+			m.runSearchCursor++
+		}
+
+	case "enter", "l", "right":
+		m = m.openRunSearchHit()
+
+	case "/":
+		m.viewMode = RunListView
+		m.searchMode = true
+		m.searchQuery = ""
+
+	case "esc", "h", "left":
+		m.runSearchHits = nil
+		m.viewMode = RunListView
+	}
+
+	return m, nil
+}
+
+// openRunSearchHit opens the run of the selected hit with the cursor on its
+// span, keeping the query active so n/N move between the run's matches
+func (m Model) openRunSearchHit() Model {
+	if m.runSearchCursor >= len(m.runSearchHits) {
+		return m
+	}
+	hit := m.runSearchHits[m.runSearchCursor]
+	index := -1
+	for i, run := range m.allRuns {
+		if run.Manifest.RunID == hit.runID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return m
+	}
+
+	m.loadRun(index)
+	m.runCursor = index
+	m.viewMode = TreeView
+	m.searchQuery = m.runSearchQuery
+	m = m.executeSearch()
+
+	spanID := hit.span.SpanContext.SpanID
+	if focused, err := m.FocusSpan(spanID, false); err == nil {
+		m = focused
+	}
+	for i, match := range m.searchMatches {
+		if match.Span.SpanContext.SpanID == spanID {
+			m.searchIndex = i
+			break
+		}
+	}
+	return m
+}
+
+// renderRunSearchView lists the hits of a search across runs, grouped
+// under the run they belong to
+func (m Model) renderRunSearchView() string {
+	var b strings.Builder
+
+	runs := make(map[string]bool)
+	for _, hit := range m.runSearchHits {
+		runs[hit.runID] = true
+	}
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("Search %q", m.runSearchQuery)))
+	b.WriteString("  ")
+	b.WriteString(MutedStyle.Render(fmt.Sprintf("%d spans in %d of %d runs", len(m.runSearchHits), len(runs), len(m.allRuns))))
+	b.WriteString("\n\n")
+
+	// Lay out run headings and hits, then show the window around the cursor
+	var lines []string
+	cursorLine := 0
+	manifests := make(map[string]TraceRun, len(m.allRuns))
+	for _, run := range m.allRuns {
+		manifests[run.Manifest.RunID] = run.Manifest
+	}
+	for i, hit := range m.runSearchHits {
+		if i == 0 || m.runSearchHits[i-1].runID != hit.runID {
+			run := manifests[hit.runID]
+			status := SuccessStyle.Render("[OK]")
+			if runFailed(run) {
+				status = ErrorStyle.Render("[FAIL]")
+			}
+			lines = append(lines, fmt.Sprintf("%s  %s  %s", HeaderStyle.Render(hit.runID), MutedStyle.Render(run.Command), status))
+		}
+
+		line := hit.span.GetFriendlyName()
+		if ms, _ := calculateDuration(hit.span.StartTime, hit.span.EndTime); ms > 0 || hit.span.EndTime != "" {
+			line += " " + m.thresholds().Style(ms).Render(fmt.Sprintf("(%dms)", ms))
+		}
+		if spanHasError(hit.span) {
+			msg := hit.span.Status.Description
+			if msg == "" {
+				msg = hit.span.Status.Code
+			}
+			line += "  " + ErrorStyle.Render("✗ "+strings.Join(strings.Fields(msg), " "))
+		}
+		if i == m.runSearchCursor {
+			cursorLine = len(lines)
+			lines = append(lines, CursorStyle.Render("  → ")+SelectedStyle.Render(line))
+		} else {
+			lines = append(lines, "    "+line)
+		}
+	}
+
+	maxVisible := m.height - 12
+	if maxVisible < 5 {
+		maxVisible = 5
+	}
+	start := 0
+	if cursorLine >= maxVisible {
+		start = cursorLine - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(lines))
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	b.WriteString("\n")
+
+	if len(lines) > maxVisible {
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("[%d/%d matches]", m.runSearchCursor+1, len(m.runSearchHits))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package tui
+
+import "sort"
+
+// RunSort is the order of the explorer's run list
+type RunSort int
+
+const (
+	// RunSortTime lists the newest runs first
+	RunSortTime RunSort = iota
+	// RunSortDuration lists the slowest runs first
+	RunSortDuration
+	// RunSortTokens lists the runs that used the most tokens first
+	RunSortTokens
+	// RunSortCost lists the most expensive runs first
+	RunSortCost
+	// RunSortStatus lists failed runs first, newest first within each group
+	RunSortStatus
+
+	runSortCount
+)
+
+func (s RunSort) String() string {
+	switch s {
+	case RunSortDuration:
+		return "duration ↓"
+	case RunSortTokens:
+		return "tokens ↓"
+	case RunSortCost:
+		return "cost ↓"
+	case RunSortStatus:
+		return "status (failed first)"
+	default:
+		return "time (newest first)"
+	}
+}
+
+// runFailed reports whether a run did not complete successfully
+func runFailed(run TraceRun) bool {
+	return run.Status != "completed" && run.Status != "ok"
+}
+
+// newerRun orders runs newest first. Runs without a start time fall back
+// to their IDs, which embed a timestamp.
+func newerRun(a, b TraceRun) bool {
+	if !a.StartTime.IsZero() && !b.StartTime.IsZero() && !a.StartTime.Equal(b.StartTime) {
+		return a.StartTime.After(b.StartTime)
+	}
+	return a.RunID > b.RunID
+}
+
+// sortRuns orders runs in place
+func sortRuns(runs []RunData, by RunSort) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		a, b := runs[i].Manifest, runs[j].Manifest
+		switch by {
+		case RunSortDuration:
+			if a.Duration != b.Duration {
+				return a.Duration > b.Duration
+			}
+		case RunSortTokens:
+			if a.TotalTokens != b.TotalTokens {
+				return a.TotalTokens > b.TotalTokens
+			}
+		case RunSortCost:
+			if a.EstimatedCost != b.EstimatedCost {
+				return a.EstimatedCost > b.EstimatedCost
+			}
+		case RunSortStatus:
+			if runFailed(a) != runFailed(b) {
+				return runFailed(a)
+			}
+		}
+		return newerRun(a, b)
+	})
+}
+
+// cycleRunSort switches to the next sort order, keeping the cursor and the
+// loaded run on the same runs
+func (m *Model) cycleRunSort() {
+	indexOf := func(runID string) int {
+		for i, run := range m.allRuns {
+			if run.Manifest.RunID == runID {
+				return i
+			}
+		}
+		return 0
+	}
+
+	var cursorID, selectedID string
+	if m.runCursor < len(m.allRuns) {
+		cursorID = m.allRuns[m.runCursor].Manifest.RunID
+	}
+	if m.selectedRun < len(m.allRuns) {
+		selectedID = m.allRuns[m.selectedRun].Manifest.RunID
+	}
+
+	m.runSort = (m.runSort + 1) % runSortCount
+	sortRuns(m.allRuns, m.runSort)
+
+	m.runCursor = indexOf(cursorID)
+	m.selectedRun = indexOf(selectedID)
+}
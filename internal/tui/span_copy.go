@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// DefaultCollectorURL is the OTLP/HTTP traces endpoint used in copied curl commands
+const DefaultCollectorURL = "http://localhost:4318/v1/traces"
+
+// SpanExporter serializes a span of a run as an OTLP JSON payload
+type SpanExporter func(runID, spanID string) ([]byte, error)
+
+// SpanExport returns a copy that can copy spans to the clipboard as OTLP JSON
+// or as a curl command posting them to collectorURL
+func (m Model) SpanExport(exporter SpanExporter, collectorURL string) Model {
+	m.spanExporter = exporter
+	m.collectorURL = collectorURL
+	return m
+}
+
+// copySelectedSpan copies the selected span to the clipboard, as OTLP JSON
+// or, when asCurl is set, as a curl command, and reports the outcome in the
+// status bar
+func (m *Model) copySelectedSpan(asCurl bool) {
+	if m.spanExporter == nil || m.cursor >= len(m.visibleNodes) || m.visibleNodes[m.cursor].Synthetic {
+		m.statusMessage = WarningStyle.Render("Copy not available")
+		return
+	}
+
+	spanID := m.visibleNodes[m.cursor].Span.SpanContext.SpanID
+	payload, err := m.spanExporter(m.runID, spanID)
+	if err != nil {
+		m.statusMessage = ErrorStyle.Render(fmt.Sprintf("Copy failed: %v", err))
+		return
+	}
+
+	text, what := string(payload), "OTLP JSON"
+	if asCurl {
+		text, what = curlCommand(m.collector(), payload), "curl command"
+	}
+
+	// OSC 52 lets the terminal set the clipboard, which also works over SSH
+	termenv.Copy(text)
+	m.statusMessage = SuccessStyle.Render(fmt.Sprintf("✓ Copied %s for span %s", what, spanID))
+}
+
+// collector returns the effective OTLP collector URL
+func (m Model) collector() string {
+	if m.collectorURL == "" {
+		return DefaultCollectorURL
+	}
+	return m.collectorURL
+}
+
+// curlCommand builds a shell command that POSTs an OTLP JSON payload
+func curlCommand(url string, payload []byte) string {
+	quoted := "'" + strings.ReplaceAll(string(payload), "'", `'\''`) + "'"
+	return fmt.Sprintf("curl -X POST -H 'Content-Type: application/json' %s \\\n  -d %s", url, quoted)
+}
@@ -4,7 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
+
+	"github.com/agenticgokit/agk/internal/utils"
 )
 
 // Span represents a parsed OpenTelemetry span
@@ -47,25 +48,106 @@ type SpanNode struct {
 	Expanded   bool
 	Parent     *SpanNode
 	DurationMs int64
+	SelfTimeMs int64 // Duration minus the time covered by direct children
+	// ClockSkew is set when the span ends before it starts, from clock skew
+	// or bad instrumentation; DurationMs is clamped to zero
+	ClockSkew bool
+	// BadTimestamp is set when the start or end time is in an unrecognized
+	// format; DurationMs is zero
+	BadTimestamp bool
+	// Repeats are the identical siblings folded into this node by
+	// CollapseRepeats; they stay hidden until RepeatsExpanded is set
+	Repeats         []*SpanNode
+	RepeatsExpanded bool
+	repeatOf        *SpanNode // Group this node was folded into, if any
+	// Synthetic nodes group other nodes and have no span of their own
+	Synthetic bool
+	// Hidden by the attribute filters: neither it nor a descendant matches
+	filteredOut bool
 }
 
 // ParseSpans parses JSONL trace data into spans
 func ParseSpans(data string) []Span {
+	spans, _ := ParseSpansWithReport(data)
+	return spans
+}
+
+// ParseSpansWithReport parses JSONL trace data into spans and also returns
+// the 1-based line numbers of lines that could not be parsed
+func ParseSpansWithReport(data string) ([]Span, []int) {
 	var spans []Span
+	var malformed []int
 	lines := strings.Split(data, "\n")
 
-	for _, line := range lines {
-		if line == "" {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
 		var span Span
 		if err := json.Unmarshal([]byte(line), &span); err != nil {
+			malformed = append(malformed, i+1)
 			continue
 		}
 		spans = append(spans, span)
 	}
 
-	return spans
+	return spans, malformed
+}
+
+// ScopeName returns the name of the instrumentation scope that produced the span
+func (s Span) ScopeName() string {
+	if name, ok := s.InstrumentationScope["Name"].(string); ok {
+		return name
+	}
+	return ""
+}
+
+// FilterSpansByScope keeps spans whose instrumentation scope name contains one of
+// include (all spans when include is empty) and none of exclude. Matching is
+// case-insensitive. Kept spans whose parent was dropped are re-attached to their
+// nearest kept ancestor so the tree stays connected.
+func FilterSpansByScope(spans []Span, include, exclude []string) []Span {
+	if len(include) == 0 && len(exclude) == 0 {
+		return spans
+	}
+
+	matches := func(scope string, patterns []string) bool {
+		scope = strings.ToLower(scope)
+		for _, p := range patterns {
+			if strings.Contains(scope, strings.ToLower(p)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	byID := make(map[string]Span, len(spans))
+	kept := make(map[string]bool, len(spans))
+	for _, span := range spans {
+		byID[span.SpanContext.SpanID] = span
+		scope := span.ScopeName()
+		keep := (len(include) == 0 || matches(scope, include)) && !matches(scope, exclude)
+		kept[span.SpanContext.SpanID] = keep
+	}
+
+	filtered := make([]Span, 0, len(spans))
+	for _, span := range spans {
+		if !kept[span.SpanContext.SpanID] {
+			continue
+		}
+		// Walk up past dropped ancestors
+		parentID := span.Parent.SpanID
+		for parentID != "" && !kept[parentID] {
+			parent, ok := byID[parentID]
+			if !ok {
+				break
+			}
+			parentID = parent.Parent.SpanID
+		}
+		span.Parent.SpanID = parentID
+		filtered = append(filtered, span)
+	}
+	return filtered
 }
 
 // BuildSpanTree builds a hierarchical tree from flat span list
@@ -73,13 +155,7 @@ func BuildSpanTree(spans []Span) []*SpanNode {
 	// Create node map
 	nodeMap := make(map[string]*SpanNode)
 	for i := range spans {
-		node := &SpanNode{
-			Span:       spans[i],
-			Children:   make([]*SpanNode, 0),
-			Expanded:   true, // Start expanded
-			DurationMs: calculateDuration(spans[i].StartTime, spans[i].EndTime),
-		}
-		nodeMap[spans[i].SpanContext.SpanID] = node
+		nodeMap[spans[i].SpanContext.SpanID] = newSpanNode(spans[i])
 	}
 
 	// Build tree structure
@@ -101,9 +177,10 @@ func BuildSpanTree(spans []Span) []*SpanNode {
 		}
 	}
 
-	// Calculate depths
+	// Calculate depths and self-times
 	for _, root := range roots {
 		setDepths(root, 0)
+		setSelfTimes(root)
 	}
 
 	// Sort roots by start time
@@ -112,6 +189,18 @@ func BuildSpanTree(spans []Span) []*SpanNode {
 	return roots
 }
 
+// newSpanNode returns an expanded node for span, without relatives
+func newSpanNode(span Span) *SpanNode {
+	node := &SpanNode{
+		Span:     span,
+		Children: make([]*SpanNode, 0),
+		Expanded: true, // Start expanded
+	}
+	node.DurationMs, node.ClockSkew = calculateDuration(span.StartTime, span.EndTime)
+	node.BadTimestamp = badTimestamp(span) != ""
+	return node
+}
+
 // setDepths recursively sets node depths
 func setDepths(node *SpanNode, depth int) {
 	node.Depth = depth
@@ -121,12 +210,33 @@ func setDepths(node *SpanNode, depth int) {
 	}
 }
 
+// setSelfTimes recursively computes each node's self-time.
+// Self-time is clamped at zero since parallel children can overlap.
+func setSelfTimes(node *SpanNode) {
+	for _, child := range node.Children {
+		setSelfTimes(child)
+	}
+	setSelfTime(node)
+}
+
+// setSelfTime computes one node's self-time from its direct children
+func setSelfTime(node *SpanNode) {
+	var childTime int64
+	for _, child := range node.Children {
+		childTime += child.DurationMs
+	}
+	node.SelfTimeMs = node.DurationMs - childTime
+	if node.SelfTimeMs < 0 {
+		node.SelfTimeMs = 0
+	}
+}
+
 // sortNodesByTime sorts nodes by start time
 func sortNodesByTime(nodes []*SpanNode) {
 	for i := 0; i < len(nodes)-1; i++ {
 		for j := i + 1; j < len(nodes); j++ {
-			t1, _ := time.Parse(time.RFC3339, nodes[i].Span.StartTime)
-			t2, _ := time.Parse(time.RFC3339, nodes[j].Span.StartTime)
+			t1, _ := utils.ParseTimestamp(nodes[i].Span.StartTime)
+			t2, _ := utils.ParseTimestamp(nodes[j].Span.StartTime)
 			if t1.After(t2) {
 				nodes[i], nodes[j] = nodes[j], nodes[i]
 			}
@@ -138,6 +248,9 @@ func sortNodesByTime(nodes []*SpanNode) {
 func FlattenTree(roots []*SpanNode) []*SpanNode {
 	var result []*SpanNode
 	for _, root := range roots {
+		if root.filteredOut {
+			continue
+		}
 		flattenNode(root, &result)
 	}
 	return result
@@ -147,25 +260,76 @@ func flattenNode(node *SpanNode, result *[]*SpanNode) {
 	*result = append(*result, node)
 	if node.Expanded {
 		for _, child := range node.Children {
+			if child.hiddenRepeat() || child.filteredOut {
+				continue
+			}
 			flattenNode(child, result)
 		}
 	}
 }
 
-// calculateDuration calculates duration in milliseconds
-func calculateDuration(startTime, endTime string) int64 {
+// AllNodes returns every node in the tree, including collapsed ones
+func AllNodes(roots []*SpanNode) []*SpanNode {
+	var result []*SpanNode
+	var walk func(nodes []*SpanNode)
+	walk = func(nodes []*SpanNode) {
+		for _, node := range nodes {
+			result = append(result, node)
+			walk(node.Children)
+		}
+	}
+	walk(roots)
+	return result
+}
+
+// badTimestamp returns the span's start or end time when it is set but
+// can't be parsed, or "". A missing end time is a span still running, not an
+// error.
+func badTimestamp(span Span) string {
+	for _, value := range []string{span.StartTime, span.EndTime} {
+		if value == "" {
+			continue
+		}
+		if _, err := utils.ParseTimestamp(value); err != nil {
+			return value
+		}
+	}
+	return ""
+}
+
+// BadTimestamps counts the spans with an unparseable start or end time and
+// returns one such timestamp as an example. A run where this is non-zero
+// shows those spans as taking 0ms.
+func BadTimestamps(spans []Span) (count int, example string) {
+	for _, span := range spans {
+		if bad := badTimestamp(span); bad != "" {
+			if count == 0 {
+				example = bad
+			}
+			count++
+		}
+	}
+	return count, example
+}
+
+// calculateDuration calculates duration in milliseconds. An end before the
+// start gives zero, with skewed set.
+func calculateDuration(startTime, endTime string) (ms int64, skewed bool) {
 	if startTime == "" || endTime == "" {
-		return 0
+		return 0, false
 	}
-	start, err := time.Parse(time.RFC3339, startTime)
+	start, err := utils.ParseTimestamp(startTime)
 	if err != nil {
-		return 0
+		return 0, false
 	}
-	end, err := time.Parse(time.RFC3339, endTime)
+	end, err := utils.ParseTimestamp(endTime)
 	if err != nil {
-		return 0
+		return 0, false
 	}
-	return end.Sub(start).Milliseconds()
+	if end.Before(start) {
+		return 0, true
+	}
+	return end.Sub(start).Milliseconds(), false
 }
 
 // GetAttribute gets an attribute value by key
@@ -257,6 +421,11 @@ func (s *Span) GetSpanType() string {
 
 // GetFriendlyName returns a user-friendly display name for the span
 func (s *Span) GetFriendlyName() string {
+	// Rules from span_names.json take precedence over the built-in ones
+	if label, ok := s.customFriendlyName(); ok {
+		return label
+	}
+
 	attrs := s.GetAllAttributes()
 	name := strings.ToLower(s.Name)
 
@@ -309,6 +478,24 @@ func (s *Span) IsWorkflowStep() bool {
 	return strings.Contains(strings.ToLower(s.Name), "workflow.step")
 }
 
+// StreamingMode reports whether an LLM span streamed its response, from
+// llm.streaming or, failing that, the presence of agk.stream.tokens. known is
+// false when the span records neither.
+func (s *Span) StreamingMode() (streaming, known bool) {
+	if value, ok := s.GetAttribute("llm.streaming"); ok {
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			return strings.EqualFold(v, "true"), true
+		}
+	}
+	if _, ok := s.GetAttribute("agk.stream.tokens"); ok {
+		return true, true
+	}
+	return false, false
+}
+
 // IsInternalSpan returns true if this span should be hidden by default (detail level)
 func (s *Span) IsInternalSpan() bool {
 	name := strings.ToLower(s.Name)
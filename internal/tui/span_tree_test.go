@@ -0,0 +1,94 @@
+package tui
+
+import "testing"
+
+func TestCalculateDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		wantMs     int64
+		wantSkewed bool
+	}{
+		{"normal", "2026-01-01T10:00:00Z", "2026-01-01T10:00:01.5Z", 1500, false},
+		{"instant", "2026-01-01T10:00:00Z", "2026-01-01T10:00:00Z", 0, false},
+		{"end before start", "2026-01-01T10:00:02Z", "2026-01-01T10:00:00Z", 0, true},
+		{"missing end", "2026-01-01T10:00:00Z", "", 0, false},
+		{"no timezone", "2026-01-01T10:00:00", "2026-01-01T10:00:01.5", 1500, false},
+		{"offset without colon", "2026-01-01 10:00:00+0900", "2026-01-01T10:00:01.5+09:00", 1500, false},
+		{"unparseable", "yesterday", "today", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms, skewed := calculateDuration(tt.start, tt.end)
+			if ms != tt.wantMs || skewed != tt.wantSkewed {
+				t.Errorf("calculateDuration(%q, %q) = %d, %t; want %d, %t", tt.start, tt.end, ms, skewed, tt.wantMs, tt.wantSkewed)
+			}
+		})
+	}
+}
+
+func TestBuildSpanTreeClockSkew(t *testing.T) {
+	spans := []Span{
+		{
+			Name:        "parent",
+			StartTime:   "2026-01-01T10:00:00Z",
+			EndTime:     "2026-01-01T10:00:01Z",
+			SpanContext: SpanContext{SpanID: "aaaaaaaaaaaaaaaa"},
+		},
+		{
+			Name:        "skewed",
+			StartTime:   "2026-01-01T10:00:00.800Z",
+			EndTime:     "2026-01-01T10:00:00.300Z",
+			SpanContext: SpanContext{SpanID: "bbbbbbbbbbbbbbbb"},
+			Parent:      ParentSpan{SpanID: "aaaaaaaaaaaaaaaa"},
+		},
+	}
+
+	roots := BuildSpanTree(spans)
+	if len(roots) != 1 || len(roots[0].Children) != 1 {
+		t.Fatalf("expected one root with one child, got %d roots", len(roots))
+	}
+	parent, child := roots[0], roots[0].Children[0]
+	if !child.ClockSkew || child.DurationMs != 0 {
+		t.Errorf("skewed child: ClockSkew = %t, DurationMs = %d; want true, 0", child.ClockSkew, child.DurationMs)
+	}
+	if parent.ClockSkew || parent.DurationMs != 1000 || parent.SelfTimeMs != 1000 {
+		t.Errorf("parent: ClockSkew = %t, DurationMs = %d, SelfTimeMs = %d; want false, 1000, 1000", parent.ClockSkew, parent.DurationMs, parent.SelfTimeMs)
+	}
+}
+
+func TestCustomFriendlyName(t *testing.T) {
+	if err := SetFriendlyNameRules([]FriendlyNameRule{
+		{Match: "GET *", Label: "fetch {name}"},
+		{Match: "myapp.step-[0-9]", Label: "step"},
+		{Match: "myapp.retrieve?", Label: "retrieve from {db.name}"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetFriendlyNameRules(nil) })
+
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{name: "GET /users/{id}", want: "fetch GET /users/{id}", ok: true},
+		{name: "get /health", want: "fetch get /health", ok: true},
+		{name: "POST /users", ok: false},
+		{name: "myapp.step-3", want: "step", ok: true},
+		{name: "myapp.step-x", ok: false},
+		{name: "myapp.retrieve2", ok: false}, // Lacks db.name
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := Span{Name: tt.name}
+			if got, ok := span.customFriendlyName(); got != tt.want || ok != tt.ok {
+				t.Errorf("customFriendlyName() = %q, %v; want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	if err := SetFriendlyNameRules([]FriendlyNameRule{{Match: "myapp.[a-z", Label: "x"}}); err == nil {
+		t.Error("SetFriendlyNameRules() accepted an unclosed [")
+	}
+}
@@ -139,6 +139,28 @@ func GetSpanStyle(spanName string) lipgloss.Style {
 	}
 }
 
+// DurationThresholds are the span durations (in ms) at which the tree view
+// switches a duration from green to amber and from amber to red
+type DurationThresholds struct {
+	WarnMs int64
+	SlowMs int64
+}
+
+// DefaultDurationThresholds colors spans amber from 100ms and red from 1s
+var DefaultDurationThresholds = DurationThresholds{WarnMs: 100, SlowMs: 1000}
+
+// Style returns the severity style for a duration
+func (t DurationThresholds) Style(durationMs int64) lipgloss.Style {
+	switch {
+	case durationMs >= t.SlowMs:
+		return ErrorStyle
+	case durationMs >= t.WarnMs:
+		return WarningStyle
+	default:
+		return SuccessStyle
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
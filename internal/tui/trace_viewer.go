@@ -1,9 +1,12 @@
 package tui
 
 import (
-	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -30,6 +33,7 @@ const (
 	RunListView ViewMode = iota
 	TreeView
 	DetailView
+	RunSearchView // Spans matching a search across all runs
 )
 
 // FocusArea represents which panel is currently focused
@@ -41,6 +45,18 @@ const (
 	FocusMetadata
 )
 
+// LayoutMode controls how many panels the tree view shows
+type LayoutMode int
+
+const (
+	// LayoutAuto shows tree, details and metadata, stacking them on narrow terminals
+	LayoutAuto LayoutMode = iota
+	// LayoutCompact hides the metadata panel, giving tree and details the full width
+	LayoutCompact
+	// LayoutSingle shows only the tree; details open full-screen with 'd'
+	LayoutSingle
+)
+
 // DetailTab represents the active tab in the details panel
 type DetailTab int
 
@@ -57,17 +73,24 @@ type TraceRun struct {
 	RunID         string
 	Command       string
 	Status        string
+	StartTime     time.Time
 	Duration      float64
 	SpanCount     int
 	LLMCalls      int
 	TotalTokens   int
 	EstimatedCost float64
+	Notes         RunNotes
 }
 
 // RunData contains a run with its parsed spans
 type RunData struct {
 	Manifest TraceRun
 	Spans    []Span
+	// LoadSpans, when set, reads Spans the first time the run is opened
+	// (see LazyRunData)
+	LoadSpans func() ([]Span, error)
+
+	errorSpans []Span // Spans with an error status, set by NewTraceExplorer
 }
 
 // Model is the main bubbletea model for the trace viewer
@@ -76,6 +99,12 @@ type Model struct {
 	allRuns     []RunData
 	runCursor   int
 	selectedRun int
+	runSort     RunSort // Order of the run list
+	runErrors   bool    // Show every error of the highlighted run
+	// Search across all runs, started with / in the run list
+	runSearchHits   []runSearchHit
+	runSearchCursor int
+	runSearchQuery  string
 
 	// Current run data
 	runID            string
@@ -98,19 +127,132 @@ type Model struct {
 	errorCount    int
 	slowestSpan   *SpanNode
 	top3Slowest   []*SpanNode
+	spanTotal     int                  // Spans in the tree, including collapsed ones
+	treeDepth     int                  // Levels in the deepest branch
+	metrics       *MetricsCalculator   // Running totals behind the metrics, updated incrementally in live mode
+	nodeByID      map[string]*SpanNode // Every span's node, kept current by live updates (nil = not indexed)
+	spanBudget    SpanBudget           // Size above which a trace is flagged (zero value = defaults)
+	// Fold runs of identical sibling spans into one line
+	collapseRepeats bool
+	// Gather spans whose parent is missing under a synthetic node
+	groupOrphans bool
+	// Levels of the tree shown when it is built (0 = all)
+	showDepth int
+	// Attribute key=value filters added from the Attributes tab with F
+	attrFilters []attrFilter
+	attrCursor  int // Selected attribute in the detail view's Attributes tab
+	// Subtree the tree panel is focused on with f (empty = whole trace)
+	zoomKey string
 	// Hot reload / file watching
-	tracePath  string    // Path to trace file being watched
-	lastOffset int64     // Bytes read so far
-	isLive     bool      // Whether we're watching for updates
-	lastUpdate time.Time // Last time file was updated
+	tracePath  string         // Path to trace file being watched
+	lastOffset int64          // Bytes read so far
+	isLive     bool           // Whether we're watching an unfinished run for updates
+	lastUpdate time.Time      // Last time file was updated
+	ingest     []ingestSample // Recent span arrivals for the ingest rate
+	// Live polling: refreshInterval is the base (0 = default), pollInterval
+	// the current, backed-off interval
+	refreshInterval time.Duration
+	pollInterval    time.Duration
+	// Follow-latest mode: switch to newer runs as they appear
+	runFollower     RunFollower
+	pendingRun      *LatestRun // Newer run waiting for the user to leave the detail view
+	lastFollowCheck time.Time
+	followedRuns    int // Runs switched to so far
 	// Search state
 	searchMode    bool
 	searchQuery   string
 	searchMatches []*SpanNode
 	searchIndex   int
+	searchWrapped bool // Last n/N wrapped around the match list
+	// Content display
+	maxContentLen   int  // Truncation length for prompt/response content (0 = default)
+	contentExpanded bool // Show content fields in full
+	// Duration color thresholds (zero value = defaults)
+	durationThresholds DurationThresholds
+	// Help overlay
+	showHelp bool
+	layout   LayoutMode
+	// Instrumentation scope filters, also applied to live updates
+	includeScopes []string
+	excludeScopes []string
+	// Parent of each span the scope filters dropped, so spans that arrive
+	// before or after a dropped parent attach to their kept ancestor
+	droppedParents map[string]string
+	// Copying spans out of the viewer
+	spanExporter  SpanExporter
+	collectorURL  string
+	statusMessage string // Outcome of the last copy or save, shown in the status bar
+	// Attribute diff against the previously selected span, toggled with a
+	diffAttrs    bool
+	prevAttrs    map[string]interface{}
+	prevSpanName string
+	// Run notes and tags, edited with N
+	notesSaver NotesSaver
+	notesMode  bool
+	notesRunID string // Run whose notes are being edited
+	notesInput string
+}
+
+// ingestSample records how many spans arrived on one live-mode tick
+type ingestSample struct {
+	at    time.Time
+	count int
+}
+
+const (
+	// ingestWindow is the period the live spans/sec rate is averaged over
+	ingestWindow = 10 * time.Second
+	// stallThreshold is how long without new spans before a live run is flagged as stalled
+	stallThreshold = 5 * time.Second
+)
+
+// runFinished reports whether spans include a root span. Spans are written
+// as they end and the root ends last, so once it is in the trace the run
+// has completed and there is nothing left to watch for.
+func runFinished(spans []Span) bool {
+	for _, span := range spans {
+		if parentID := span.Parent.SpanID; parentID == "" || strings.Trim(parentID, "0") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// SpanBudget is the trace size above which the run summary warns that the
+// trace is unusually large or deep, which often points at instrumentation bugs
+type SpanBudget struct {
+	MaxSpans int
+	MaxDepth int
+}
+
+// DefaultSpanBudget flags traces with more than 1000 spans or 15 levels
+var DefaultSpanBudget = SpanBudget{MaxSpans: 1000, MaxDepth: 15}
+
+// treeSize returns the number of nodes and levels in a span tree
+func treeSize(nodes []*SpanNode) (count, depth int) {
+	for _, node := range nodes {
+		childCount, childDepth := treeSize(node.Children)
+		if node.Synthetic {
+			count += childCount
+			depth = max(depth, childDepth)
+			continue
+		}
+		count += 1 + childCount
+		depth = max(depth, 1+childDepth)
+	}
+	return count, depth
 }
 
-func calculateMetrics(nodes []*SpanNode) (totalTokens int, errorCount int, slowest *SpanNode, top3 []*SpanNode) {
+const (
+	// DefaultMaxContentLen is the default truncation length for content fields
+	DefaultMaxContentLen = 500
+	contentLenStep       = 250
+	minContentLen        = 100
+)
+
+// calculateMetrics processes every node from scratch; runs without spans have
+// no slowest span, so callers check for nil
+func calculateMetrics(nodes []*SpanNode) *MetricsCalculator {
 	calc := &MetricsCalculator{
 		Top3: make([]*SpanNode, 0, 3),
 	}
@@ -119,9 +261,11 @@ func calculateMetrics(nodes []*SpanNode) (totalTokens int, errorCount int, slowe
 		calc.ProcessNode(node)
 	}
 
-	return calc.TotalTokens, calc.ErrorCount, calc.Slowest, calc.Top3
+	return calc
 }
 
+// MetricsCalculator accumulates run metrics one node at a time, so live
+// updates only need to process the spans that arrived
 type MetricsCalculator struct {
 	TotalTokens int
 	ErrorCount  int
@@ -149,19 +293,33 @@ func (mc *MetricsCalculator) ProcessNode(node *SpanNode) {
 		mc.ErrorCount++
 	}
 
-	// Track slowest spans (only leaf nodes or LLM spans)
-	if !node.HasChildren() || strings.Contains(strings.ToLower(node.Span.Name), "llm") {
-		if mc.Slowest == nil || node.DurationMs > mc.Slowest.DurationMs {
-			mc.Slowest = node
+	// Track slowest spans by self-time so parents aren't blamed for their children
+	if mc.Slowest == nil || node.SelfTimeMs > mc.Slowest.SelfTimeMs {
+		mc.Slowest = node
+	}
+	mc.updateTop3(node)
+}
+
+// AddNodes folds newly arrived nodes into the running totals. shrunk are
+// existing nodes whose self-time the new nodes reduced; if one of them is
+// among the slowest, an untracked span may now rank above it, so AddNodes
+// returns false and the caller must recompute from scratch.
+func (mc *MetricsCalculator) AddNodes(added, shrunk []*SpanNode) bool {
+	for _, node := range shrunk {
+		if node == mc.Slowest || slices.Contains(mc.Top3, node) {
+			return false
 		}
-		mc.updateTop3(node)
 	}
+	for _, node := range added {
+		mc.ProcessNode(node)
+	}
+	return true
 }
 
 func (mc *MetricsCalculator) updateTop3(node *SpanNode) {
 	inserted := false
 	for i, s := range mc.Top3 {
-		if node.DurationMs > s.DurationMs {
+		if node.SelfTimeMs > s.SelfTimeMs {
 			// Insert at position i
 			mc.Top3 = append(mc.Top3[:i], append([]*SpanNode{node}, mc.Top3[i:]...)...)
 			inserted = true
@@ -178,24 +336,18 @@ func (mc *MetricsCalculator) updateTop3(node *SpanNode) {
 
 // NewTraceViewer creates a new trace viewer model
 func NewTraceViewer(runID string, manifest TraceRun, spans []Span) Model {
-	return NewTraceViewerWithPath(runID, manifest, spans, "")
+	return NewTraceViewerWithPath(runID, manifest, spans, "", 0)
 }
 
-// NewTraceViewerWithPath creates a trace viewer with hot reload support
-func NewTraceViewerWithPath(runID string, manifest TraceRun, spans []Span, tracePath string) Model {
+// NewTraceViewerWithPath creates a trace viewer with hot reload support that
+// reads tracePath for new spans from offset, the number of bytes spans were
+// parsed from (see CompleteLines)
+func NewTraceViewerWithPath(runID string, manifest TraceRun, spans []Span, tracePath string, offset int64) Model {
 	roots := BuildSpanTree(spans)
 	visible := FlattenTree(roots)
 
-	totalTokens, errorCount, slowest, top3 := calculateMetrics(visible)
-	estimatedCost := float64(totalTokens) * 0.000002
-
-	// Calculate initial file offset if path provided
-	var lastOffset int64
-	if tracePath != "" {
-		if info, err := os.Stat(tracePath); err == nil {
-			lastOffset = info.Size()
-		}
-	}
+	metrics := calculateMetrics(visible)
+	spanTotal, treeDepth := treeSize(roots)
 
 	return Model{
 		runID:            runID,
@@ -209,14 +361,18 @@ func NewTraceViewerWithPath(runID string, manifest TraceRun, spans []Span, trace
 		treeViewport:     viewport.New(40, 10),
 		detailViewport:   viewport.New(40, 10),
 		metadataViewport: viewport.New(30, 20),
-		totalTokens:      totalTokens,
-		estimatedCost:    estimatedCost,
-		errorCount:       errorCount,
-		slowestSpan:      slowest,
-		top3Slowest:      top3,
+		totalTokens:      metrics.TotalTokens,
+		estimatedCost:    float64(metrics.TotalTokens) * 0.000002,
+		errorCount:       metrics.ErrorCount,
+		slowestSpan:      metrics.Slowest,
+		top3Slowest:      metrics.Top3,
+		metrics:          metrics,
+		nodeByID:         indexNodes(roots),
+		spanTotal:        spanTotal,
+		treeDepth:        treeDepth,
 		tracePath:        tracePath,
-		lastOffset:       lastOffset,
-		isLive:           tracePath != "",
+		lastOffset:       offset,
+		isLive:           tracePath != "" && !runFinished(spans),
 		lastUpdate:       time.Now(),
 		searchMode:       false,
 		searchQuery:      "",
@@ -232,6 +388,8 @@ func NewTraceExplorer(runs []RunData) Model {
 		runCursor: 0,
 		viewMode:  RunListView,
 	}
+	sortRuns(m.allRuns, m.runSort)
+	collectRunErrors(m.allRuns)
 
 	// If we have runs, prepare the first one
 	if len(runs) > 0 {
@@ -247,66 +405,130 @@ func (m *Model) loadRun(index int) {
 		return
 	}
 
+	m.ensureRunSpans(index)
+
 	run := m.allRuns[index]
 	m.selectedRun = index
 	m.runID = run.Manifest.RunID
 	m.manifest = run.Manifest
-	m.roots = BuildSpanTree(run.Spans)
-	m.visibleNodes = FlattenTree(m.roots)
+	m.zoomKey = ""
+	m.droppedParents = nil
+	m.setTree(run.Spans)
+	m.visibleNodes = FlattenTree(m.treeRoots())
 	m.cursor = 0
+	m.applyRepeatCollapsing()
 
 	// Recompute metrics
 	m.computeMetrics()
 }
 
-// computeMetrics calculates metrics for the current run
+// ensureRunSpans reads the spans of a lazily loaded run; runs are parsed
+// once, when first opened or searched
+func (m *Model) ensureRunSpans(index int) {
+	if run := &m.allRuns[index]; run.LoadSpans != nil {
+		spans, err := run.LoadSpans()
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Failed to load %s: %v", run.Manifest.RunID, err)
+		}
+		run.Spans, run.LoadSpans = spans, nil
+	}
+}
+
+// computeMetrics calculates metrics for the current run from scratch
 func (m *Model) computeMetrics() {
-	m.totalTokens, m.errorCount, m.slowestSpan, m.top3Slowest = calculateMetrics(m.visibleNodes)
+	m.metrics = calculateMetrics(SpanNodes(m.roots))
+	m.applyMetrics()
+	m.spanTotal, m.treeDepth = treeSize(m.roots)
+}
+
+// updateMetrics adds the nodes that just arrived to the metrics, falling
+// back to computeMetrics when the running totals can't be updated in place
+func (m *Model) updateMetrics(added, shrunk []*SpanNode) {
+	if m.metrics == nil || !m.metrics.AddNodes(added, shrunk) {
+		m.computeMetrics()
+		return
+	}
+	m.applyMetrics()
+}
+
+// applyMetrics copies the running totals into the fields the views render
+func (m *Model) applyMetrics() {
+	m.totalTokens, m.errorCount = m.metrics.TotalTokens, m.metrics.ErrorCount
+	m.slowestSpan, m.top3Slowest = m.metrics.Slowest, m.metrics.Top3
 	m.estimatedCost = float64(m.totalTokens) * 0.000002
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	if m.isLive && m.tracePath != "" {
+	if m.watching() {
 		return m.tickCmd()
 	}
 	return nil
 }
 
-// tickCmd returns a command that sends a tick after 500ms
-func (m Model) tickCmd() tea.Cmd {
-	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
-}
-
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tickMsg:
+		if !m.watching() {
+			return m, nil
+		}
+		active := false
+		if m.runFollower != nil {
+			runID := m.runID
+			m = m.checkLatestRun(time.Time(msg))
+			active = m.runID != runID
+		}
 		// Check for file updates
 		if m.isLive && m.tracePath != "" {
 			if newSpans := m.checkFileUpdates(); len(newSpans) > 0 {
-				// Add new spans and rebuild tree
+				// Add new spans to the tree
 				m = m.addNewSpans(newSpans)
 				m.lastUpdate = time.Now()
+				m.isLive = !runFinished(newSpans)
+				active = true
 			}
-			return m, m.tickCmd()
 		}
-		return m, nil
+		m.adjustPolling(active)
+		return m, m.tickCmd()
 
 	case tea.KeyMsg:
+		m.statusMessage = ""
+		// Any key dismisses the help overlay
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+		if m.notesMode {
+			return m.updateNotesInput(msg)
+		}
+		if msg.String() == "?" && !m.searchMode {
+			m.showHelp = true
+			return m, nil
+		}
+
 		switch m.viewMode {
 		case RunListView:
+			if m.searchMode {
+				return m.updateSearchInput(msg)
+			}
 			return m.updateRunListView(msg)
+		case RunSearchView:
+			return m.updateRunSearchView(msg)
 		case TreeView:
 			// Handle search input mode
 			if m.searchMode {
 				return m.updateSearchInput(msg)
 			}
-			return m.updateTreeView(msg)
+			before := m.selectedNode()
+			next, cmd := m.updateTreeView(msg)
+			if updated, ok := next.(Model); ok {
+				updated.trackSelection(before)
+				return updated, cmd
+			}
+			return next, cmd
 		case DetailView:
 			return m.updateDetailView(msg)
 		}
@@ -360,73 +582,105 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // checkFileUpdates reads new lines from the trace file
 func (m *Model) checkFileUpdates() []Span {
-	info, err := os.Stat(m.tracePath)
+	spans, offset := ReadNewSpans(m.tracePath, m.lastOffset)
+	m.lastOffset = offset
+	return spans
+}
+
+// ReadNewSpans parses the spans appended to a trace file after offset and
+// returns them with the offset to resume from. A trailing line that is still
+// being written is left for the next call (see CompleteLines).
+func ReadNewSpans(path string, offset int64) ([]Span, int64) {
+	info, err := os.Stat(path)
 	if err != nil {
-		return nil
+		return nil, offset
 	}
 
 	// No new data
-	if info.Size() <= m.lastOffset {
-		return nil
+	if info.Size() <= offset {
+		return nil, offset
 	}
 
 	// Open file and seek to last position
-	file, err := os.Open(m.tracePath)
+	file, err := os.Open(path)
 	if err != nil {
-		return nil
+		return nil, offset
 	}
 	defer func() { _ = file.Close() }()
 
-	if _, err := file.Seek(m.lastOffset, 0); err != nil {
-		return nil
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset
 	}
 
-	// Read new lines
-	var newLines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line != "" {
-			newLines = append(newLines, line)
-		}
+	data, err := io.ReadAll(io.LimitReader(file, info.Size()-offset))
+	if err != nil {
+		return nil, offset
 	}
 
-	// Update offset
-	m.lastOffset = info.Size()
-
-	// Parse new spans
-	if len(newLines) == 0 {
-		return nil
+	data = CompleteLines(data)
+	if len(data) == 0 {
+		return nil, offset
 	}
+	return ParseSpans(string(data)), offset + int64(len(data))
+}
 
-	return ParseSpans(strings.Join(newLines, "\n"))
+// CompleteLines trims a trailing line that the writer is still in the
+// middle of: one with no newline yet that isn't valid JSON. A last line
+// that is whole but unterminated is kept, so a finished trace without a
+// final newline reads in full.
+func CompleteLines(data []byte) []byte {
+	start := bytes.LastIndexByte(data, '\n') + 1
+	last := bytes.TrimSpace(data[start:])
+	if len(last) == 0 || json.Valid(last) {
+		return data
+	}
+	return data[:start]
 }
 
-// addNewSpans adds new spans to the existing tree
+// addNewSpans adds new spans to the tree, in place when insertSpans can and
+// by rebuilding it otherwise
 func (m Model) addNewSpans(newSpans []Span) Model {
-	// Get all existing spans
-	existingSpans := m.collectAllSpans()
-
-	// Add new spans
-	allSpans := append(existingSpans, newSpans...)
+	newSpans = m.filterScopes(newSpans)
 
-	// Rebuild tree
-	m.roots = BuildSpanTree(allSpans)
-	m.visibleNodes = FlattenTree(m.roots)
-
-	// Update metrics
-	m.computeMetrics()
+	if !m.insertSpans(newSpans) {
+		allSpans := append(m.collectAllSpans(), newSpans...)
+		// Spans that arrived before a parent the filters have now dropped
+		// move up to their kept ancestor
+		for i := range allSpans {
+			allSpans[i].Parent.SpanID = m.resolveParent(allSpans[i].Parent.SpanID)
+		}
+		m.setTree(allSpans)
+		m.visibleNodes = FlattenTree(m.treeRoots())
+		m.applyRepeatCollapsing()
+		m.computeMetrics()
+		m.manifest.SpanCount = len(allSpans)
+	}
 
-	// Update manifest span count
-	m.manifest.SpanCount = len(allSpans)
+	// Record arrivals for the ingest rate, dropping samples outside the window
+	now := time.Now()
+	m.ingest = append(m.ingest, ingestSample{at: now, count: len(newSpans)})
+	for len(m.ingest) > 0 && now.Sub(m.ingest[0].at) > ingestWindow {
+		m.ingest = m.ingest[1:]
+	}
 
 	return m
 }
 
+// ingestRate returns the spans/sec averaged over the ingest window
+func (m Model) ingestRate() float64 {
+	total := 0
+	for _, sample := range m.ingest {
+		if time.Since(sample.at) <= ingestWindow {
+			total += sample.count
+		}
+	}
+	return float64(total) / ingestWindow.Seconds()
+}
+
 // collectAllSpans extracts all spans from the tree
 func (m Model) collectAllSpans() []Span {
 	var spans []Span
-	for _, node := range m.visibleNodes {
+	for _, node := range SpanNodes(m.roots) {
 		spans = append(spans, node.Span)
 	}
 	return spans
@@ -453,6 +707,24 @@ func (m Model) updateRunListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.loadRun(m.runCursor)
 			m.viewMode = TreeView
 		}
+
+	case "s":
+		m.cycleRunSort()
+
+	case "e":
+		m.runErrors = !m.runErrors
+
+	case "t":
+		if m.runCursor < len(m.allRuns) {
+			run := m.allRuns[m.runCursor].Manifest
+			m = m.startNotesEdit(run.RunID, run.Notes)
+		}
+
+	case "/":
+		// Search the spans of every run
+		m.searchMode = true
+		m.searchQuery = ""
+		m.searchMatches = nil
 	}
 
 	return m, nil
@@ -465,12 +737,22 @@ func (m Model) updateTreeView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "tab":
 		// Cycle focus forward: Tree -> Details -> Metadata -> Tree
-		m.focusArea = (m.focusArea + 1) % 3
+		panels := m.panelCount()
+		m.focusArea = (m.focusArea + 1) % panels
 		return m, nil
 
 	case "shift+tab":
 		// Cycle focus backward
-		m.focusArea = (m.focusArea + 2) % 3 // +2 mod 3 is same as -1
+		panels := m.panelCount()
+		m.focusArea = (m.focusArea + panels - 1) % panels
+		return m, nil
+
+	case "v":
+		// Cycle layout: auto -> compact -> single pane
+		m.layout = (m.layout + 1) % 3
+		if m.focusArea >= m.panelCount() {
+			m.focusArea = FocusTree
+		}
 		return m, nil
 
 	case "left":
@@ -528,6 +810,19 @@ func (m Model) updateTreeView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchIndex = -1
 			return m, nil
 		}
+		// Leave a focused subtree
+		if m.zoomedNode() != nil {
+			return m.zoomOut(), nil
+		}
+		// Drop attribute filters
+		if len(m.attrFilters) > 0 {
+			return m.clearAttrFilters(), nil
+		}
+		// Go back to the results of a search across runs
+		if len(m.runSearchHits) > 0 {
+			m.viewMode = RunSearchView
+			return m, nil
+		}
 		// Go back to run list (if we have multiple runs)
 		if len(m.allRuns) > 0 {
 			m.viewMode = RunListView
@@ -544,10 +839,26 @@ func (m Model) updateTreeView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case " ":
 		m = m.handleTreeToggle()
 
+	case "x":
+		m = m.toggleSelectedGroup()
+
+	case "X":
+		m = m.CollapseRepeatedSpans(!m.collapseRepeats)
+
+	case "o":
+		m = m.GroupOrphanedSpans(!m.groupOrphans)
+
+	case "f":
+		m = m.zoomIn()
+
+	case "a":
+		m = m.toggleAttrDiff()
+
 	case "d":
 		// Show details
 		if m.cursor < len(m.visibleNodes) {
 			m.viewMode = DetailView
+			m.attrCursor = 0
 			m.updateDetailViewport()
 		}
 
@@ -560,6 +871,7 @@ func (m Model) updateTreeView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "n":
 		// Next search match
 		if len(m.searchMatches) > 0 {
+			m.searchWrapped = m.searchIndex == len(m.searchMatches)-1
 			m.searchIndex = (m.searchIndex + 1) % len(m.searchMatches)
 			m = m.jumpToSearchMatch()
 		}
@@ -567,16 +879,22 @@ func (m Model) updateTreeView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "N":
 		// Previous search match
-		if len(m.searchMatches) > 0 {
-			if m.searchIndex <= 0 {
-				m.searchIndex = len(m.searchMatches) - 1
-			} else {
-				m.searchIndex--
-			}
-			m = m.jumpToSearchMatch()
+		if len(m.searchMatches) == 0 {
+			return m, nil
 		}
+		m.searchWrapped = m.searchIndex <= 0
+		if m.searchIndex <= 0 {
+			m.searchIndex = len(m.searchMatches) - 1
+		} else {
+			m.searchIndex--
+		}
+		m = m.jumpToSearchMatch()
 		return m, nil
 
+	case "t":
+		// Edit the run's notes and #tags
+		return m.startNotesEdit(m.runID, m.manifest.Notes), nil
+
 	case "e":
 		// Jump to next error
 		m = m.jumpToNextError()
@@ -604,9 +922,13 @@ func (m Model) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "enter":
-		// Execute search
+		// Execute search, across all runs when started from the run list
 		m.searchMode = false
-		m = m.executeSearch()
+		if m.viewMode == RunListView {
+			m = m.executeRunSearch()
+		} else {
+			m = m.executeSearch()
+		}
 		return m, nil
 
 	case "backspace":
@@ -629,6 +951,7 @@ func (m Model) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) executeSearch() Model {
 	m.searchMatches = make([]*SpanNode, 0)
 	m.searchIndex = -1
+	m.searchWrapped = false
 
 	if m.searchQuery == "" {
 		return m
@@ -703,9 +1026,12 @@ func (m Model) handleTreeNavigation(key string) Model {
 func (m Model) handleTreeSelection() Model {
 	if m.cursor < len(m.visibleNodes) {
 		node := m.visibleNodes[m.cursor]
+		if node.IsCollapsedGroup() {
+			return m.toggleSelectedGroup()
+		}
 		if node.HasChildren() {
 			node.ToggleExpanded()
-			m.visibleNodes = FlattenTree(m.roots)
+			m.visibleNodes = FlattenTree(m.treeRoots())
 		} else {
 			// Show detail view for leaf nodes
 			m.viewMode = DetailView
@@ -720,7 +1046,7 @@ func (m Model) handleTreeCollapse() Model {
 		node := m.visibleNodes[m.cursor]
 		if node.HasChildren() && node.Expanded {
 			node.Expanded = false
-			m.visibleNodes = FlattenTree(m.roots)
+			m.visibleNodes = FlattenTree(m.treeRoots())
 		} else if node.Parent != nil {
 			// Navigate to parent
 			for i, n := range m.visibleNodes {
@@ -740,7 +1066,7 @@ func (m Model) handleTreeToggle() Model {
 		node := m.visibleNodes[m.cursor]
 		if node.HasChildren() {
 			node.ToggleExpanded()
-			m.visibleNodes = FlattenTree(m.roots)
+			m.visibleNodes = FlattenTree(m.treeRoots())
 		}
 	}
 	return m
@@ -768,6 +1094,15 @@ func (m Model) handleRunSwitching(key string) Model {
 func (m Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	// The span list can empty out under us (run switch, scope filter)
+	if m.cursor >= len(m.visibleNodes) {
+		m.viewMode = TreeView
+		if msg.String() == "q" || msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
@@ -847,6 +1182,60 @@ func (m Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.detailViewport.SetContent(m.renderTimingTab(m.visibleNodes[m.cursor]))
 		return m, nil
 
+	case "+", "=":
+		m.maxContentLen = m.contentLimit() + contentLenStep
+		m.updateDetailViewport()
+		return m, nil
+
+	case "-":
+		m.maxContentLen = max(m.contentLimit()-contentLenStep, minContentLen)
+		m.updateDetailViewport()
+		return m, nil
+
+	case "f":
+		// Toggle full (untruncated) content
+		m.contentExpanded = !m.contentExpanded
+		m.updateDetailViewport()
+		return m, nil
+
+	case "a":
+		m = m.toggleAttrDiff()
+		m.updateDetailViewport()
+		return m, nil
+
+	case "y":
+		m.copySelectedSpan(false)
+		return m, nil
+
+	case "Y":
+		m.copySelectedSpan(true)
+		return m, nil
+
+	case KeyUp, "k", KeyDown, "j":
+		// The Attributes tab selects an attribute; other tabs scroll
+		if m.selectedTab != TabAttributes {
+			m.detailViewport, cmd = m.detailViewport.Update(msg)
+			return m, cmd
+		}
+		if key := msg.String(); key == KeyUp || key == "k" {
+			m = m.moveAttrCursor(-1)
+		} else {
+			m = m.moveAttrCursor(1)
+		}
+		return m, nil
+
+	case "c":
+		if m.selectedTab == TabAttributes {
+			m.copySelectedAttrKey()
+		}
+		return m, nil
+
+	case "F":
+		if m.selectedTab == TabAttributes {
+			m = m.addSelectedAttrFilter()
+		}
+		return m, nil
+
 	default:
 		// Pass all other keys to viewport for scrolling
 		m.detailViewport, cmd = m.detailViewport.Update(msg)
@@ -870,6 +1259,10 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
+	if m.showHelp {
+		return m.renderHelpOverlay()
+	}
+
 	// Use a fixed-height container to prevent scrolling
 	var lines []string
 
@@ -885,11 +1278,17 @@ func (m Model) View() string {
 		mainContent = m.renderTreeView()
 	case DetailView:
 		mainContent = m.renderDetailView()
+	case RunSearchView:
+		mainContent = m.renderRunSearchView()
 	default:
 		mainContent = m.renderRunListView()
 	}
 	lines = append(lines, mainContent)
 
+	if m.notesMode {
+		lines = append(lines, m.renderNotesBar())
+	}
+
 	// 3. Fixed Status/Help Bar at bottom
 	lines = append(lines, m.renderStatusBar())
 
@@ -924,6 +1323,18 @@ func (m Model) renderGlobalHeader() string {
 	}
 	b.WriteString(TitleStyle.Render(title))
 
+	// Ingest rate, or a stall warning when spans stop arriving
+	if m.isLive {
+		if idle := time.Since(m.lastUpdate); idle > stallThreshold {
+			b.WriteString("  " + WarningStyle.Render(fmt.Sprintf("⏸ stalled (no new spans for %ds)", int(idle.Seconds()))))
+		} else {
+			b.WriteString("  " + MutedStyle.Render(fmt.Sprintf("%.1f spans/s", m.ingestRate())))
+		}
+	}
+	if m.runFollower != nil {
+		b.WriteString("  " + m.renderFollowStatus())
+	}
+
 	// If a run is selected, show its context in the header too?
 	// Or keeps it simple. User said "fixed header".
 
@@ -948,6 +1359,8 @@ func (m Model) renderStatusBar() string {
 	switch m.viewMode {
 	case RunListView:
 		focusIndicator = "Run List"
+	case RunSearchView:
+		focusIndicator = "Search Results"
 	case TreeView:
 		switch m.focusArea {
 		case FocusTree:
@@ -967,7 +1380,13 @@ func (m Model) renderStatusBar() string {
 	// Key bindings based on current state
 	var keys []string
 
-	if m.searchMode {
+	if m.notesMode {
+		keys = []string{
+			HelpKeyStyle.Render("[Type]") + " Note, #tag",
+			HelpKeyStyle.Render("[Enter]") + " Save",
+			HelpKeyStyle.Render("[Esc]") + " Cancel",
+		}
+	} else if m.searchMode {
 		keys = []string{
 			HelpKeyStyle.Render("[Type]") + " Search",
 			HelpKeyStyle.Render("[Enter]") + " Confirm",
@@ -979,6 +1398,20 @@ func (m Model) renderStatusBar() string {
 			keys = []string{
 				HelpKeyStyle.Render("[↑↓]") + " Navigate",
 				HelpKeyStyle.Render("[Enter]") + " Open",
+				HelpKeyStyle.Render("[/]") + " Search all",
+				HelpKeyStyle.Render("[s]") + " Sort",
+				HelpKeyStyle.Render("[e]") + " Errors",
+				HelpKeyStyle.Render("[t]") + " Notes",
+				HelpKeyStyle.Render("[?]") + " Help",
+				HelpKeyStyle.Render("[q]") + " Quit",
+			}
+		case RunSearchView:
+			keys = []string{
+				HelpKeyStyle.Render("[↑↓]") + " Navigate",
+				HelpKeyStyle.Render("[Enter]") + " Open span",
+				HelpKeyStyle.Render("[/]") + " New search",
+				HelpKeyStyle.Render("[Esc]") + " Back",
+				HelpKeyStyle.Render("[?]") + " Help",
 				HelpKeyStyle.Render("[q]") + " Quit",
 			}
 		case TreeView:
@@ -990,6 +1423,9 @@ func (m Model) renderStatusBar() string {
 				HelpKeyStyle.Render("[d]") + " Detail",
 				HelpKeyStyle.Render("[/]") + " Search",
 				HelpKeyStyle.Render("[e]") + " Errors",
+				HelpKeyStyle.Render("[v]") + " Layout",
+				HelpKeyStyle.Render("[t]") + " Notes",
+				HelpKeyStyle.Render("[?]") + " Help",
 				HelpKeyStyle.Render("[q]") + " Quit",
 			}
 		case DetailView:
@@ -997,6 +1433,11 @@ func (m Model) renderStatusBar() string {
 				HelpKeyStyle.Render("[←→]") + " Tabs",
 				HelpKeyStyle.Render("[1-5]") + " Jump",
 				HelpKeyStyle.Render("[↑↓]") + " Scroll",
+				HelpKeyStyle.Render("[+/-]") + " Length",
+				HelpKeyStyle.Render("[f]") + " Full",
+				HelpKeyStyle.Render("[y/Y]") + " Copy",
+				HelpKeyStyle.Render("[c/F]") + " Key/Filter",
+				HelpKeyStyle.Render("[?]") + " Help",
 				HelpKeyStyle.Render("[Esc]") + " Back",
 				HelpKeyStyle.Render("[q]") + " Quit",
 			}
@@ -1005,7 +1446,14 @@ func (m Model) renderStatusBar() string {
 
 	// Add search status if active
 	if len(m.searchMatches) > 0 && !m.searchMode {
-		statusParts = append(statusParts, SuccessStyle.Render(fmt.Sprintf("🔍 %d matches", len(m.searchMatches))))
+		statusParts = append(statusParts, SuccessStyle.Render("🔍 "+m.searchPosition()))
+		if m.searchWrapped {
+			statusParts = append(statusParts, WarningStyle.Render("↻ wrapped"))
+		}
+	}
+
+	if m.statusMessage != "" {
+		statusParts = append(statusParts, m.statusMessage)
 	}
 
 	// Combine status and keys
@@ -1031,11 +1479,14 @@ func (m Model) renderRunListView() string {
 	} else {
 		// Calculate visible area
 		// Adjust height for header (approx 2 lines) and footer/padding
-		maxVisible := m.height - 8
+		maxVisible := m.height - 10
 		if maxVisible < 5 {
 			maxVisible = 5
 		}
 
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("Sorted by %s  [s] change", m.runSort)))
+		b.WriteString("\n\n")
+
 		// Scroll offset
 		scrollOffset := 0
 		if m.runCursor >= maxVisible {
@@ -1054,11 +1505,13 @@ func (m Model) renderRunListView() string {
 			}
 
 			// Format line
-			runLine := fmt.Sprintf("%-28s  %-12s  %6.2fs  %d LLM  %s",
+			runLine := fmt.Sprintf("%-28s  %-12s  %6.2fs  %d LLM  %7d tok  $%.4f  %s",
 				run.Manifest.RunID,
 				run.Manifest.Command,
 				run.Manifest.Duration,
 				run.Manifest.LLMCalls,
+				run.Manifest.TotalTokens,
+				run.Manifest.EstimatedCost,
 				status,
 			)
 
@@ -1069,7 +1522,11 @@ func (m Model) renderRunListView() string {
 				b.WriteString("  ")
 				b.WriteString(runLine)
 			}
+			if !run.Manifest.Notes.IsEmpty() {
+				b.WriteString("  " + renderNotes(run.Manifest.Notes))
+			}
 			b.WriteString("\n")
+			b.WriteString(renderRunErrors(run, m.runErrors && i == m.runCursor, m.width))
 		}
 
 		// Scroll indicator
@@ -1079,10 +1536,39 @@ func (m Model) renderRunListView() string {
 		}
 	}
 
+	if m.searchMode {
+		b.WriteString("\n")
+		b.WriteString(m.renderSearchBar())
+	}
+
 	b.WriteString("\n")
 	return b.String()
 }
 
+// renderEmptyRun explains why a run shows no spans
+func (m Model) renderEmptyRun() string {
+	var b strings.Builder
+	b.WriteString(WarningStyle.Render("This run has no spans"))
+	b.WriteString("\n\n")
+
+	switch {
+	case len(m.includeScopes) > 0 || len(m.excludeScopes) > 0:
+		b.WriteString(MutedStyle.Render("Every span was hidden by the --scope/--exclude-scope filters."))
+	case m.runFollower != nil && m.runID == "":
+		b.WriteString(MutedStyle.Render("Waiting for a run to start; it will open here as soon as it appears."))
+	case m.isLive:
+		b.WriteString(MutedStyle.Render("The trace file is empty or unreadable so far; spans will appear here as the run writes them."))
+	default:
+		b.WriteString(MutedStyle.Render("The trace file is empty or none of its lines could be parsed.\nCheck that the run was started with AGK_TRACE=true and that it finished writing."))
+	}
+
+	if len(m.allRuns) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(MutedStyle.Render("Press [Esc] to pick another run or [ ] to step through runs."))
+	}
+	return b.String()
+}
+
 func (m Model) renderTreeView() string {
 	var b strings.Builder
 
@@ -1117,14 +1603,35 @@ func (m Model) renderTreeView() string {
 		availableHeight = 20 // Minimum height
 	}
 
+	// Nothing to lay out panels for
+	if len(m.roots) == 0 {
+		emptyStyle := LeftPaneStyle.Width(availableWidth).Height(availableHeight)
+		b.WriteString(emptyStyle.Render(m.renderEmptyRun()))
+		return b.String()
+	}
+
+	// Single pane: the tree alone, details open full-screen with 'd'
+	if m.layout == LayoutSingle {
+		treeStyle := LeftPaneStyle.Width(availableWidth).Height(availableHeight)
+		b.WriteString(treeStyle.Render(m.renderTreePanel()))
+		if m.searchMode {
+			b.WriteString("\n")
+			b.WriteString(m.renderSearchBar())
+		}
+		return b.String()
+	}
+
 	// Responsive layout check
-	if availableWidth < 100 {
+	if availableWidth < 100 && m.layout == LayoutAuto {
 		// Stack vertically for narrow terminals
 		return m.renderStackedLayout()
 	}
 
-	// Panel widths: Left 66%, Right 34%
+	// Panel widths: Left 66%, Right 34% (compact mode drops the right column)
 	leftWidth := (availableWidth * 66) / 100
+	if m.layout == LayoutCompact {
+		leftWidth = availableWidth
+	}
 	rightWidth := availableWidth - leftWidth
 
 	// Left panel heights: Tree 40%, Details 60%
@@ -1166,12 +1673,16 @@ func (m Model) renderTreeView() string {
 	)
 
 	// Join left and right columns
-	splitView := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftColumn,
-		metadataStyle.Render(metadataContent),
-	)
-	b.WriteString(splitView)
+	if m.layout == LayoutCompact {
+		b.WriteString(leftColumn)
+	} else {
+		splitView := lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			leftColumn,
+			metadataStyle.Render(metadataContent),
+		)
+		b.WriteString(splitView)
+	}
 
 	// Search bar (if active)
 	if m.searchMode {
@@ -1191,12 +1702,23 @@ func (m Model) renderTreePanel() string {
 		title = "▶ " + title
 	}
 	b.WriteString(HeaderStyle.Render(title))
+	if label := m.zoomLabel(); label != "" {
+		b.WriteString("  " + WarningStyle.Render("🔍 "+label))
+	}
+	if label := m.attrFilterLabel(); label != "" {
+		b.WriteString("  " + WarningStyle.Render("⧩ "+label))
+	}
 	b.WriteString("\n")
+	if len(m.visibleNodes) == 0 && len(m.attrFilters) > 0 {
+		b.WriteString(MutedStyle.Render("No spans match the filter"))
+		return b.String()
+	}
 
-	// Build full content for viewport
+	// Build full content for viewport, indenting a focused subtree as a root
 	var content strings.Builder
+	baseDepth := m.zoomDepth()
 	for i, node := range m.visibleNodes {
-		line := m.renderSpanLine(node, i == m.cursor)
+		line := m.renderSpanLine(node, i == m.cursor, baseDepth)
 		content.WriteString(line)
 		content.WriteString("\n")
 	}
@@ -1243,16 +1765,24 @@ func (m Model) renderDetailPanel() string {
 	}
 	b.WriteString(tabBar.String())
 	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", 60))
-	b.WriteString("\n")
 
 	if m.cursor >= len(m.visibleNodes) {
+		b.WriteString(strings.Repeat("─", 60))
+		b.WriteString("\n")
 		b.WriteString(MutedStyle.Render("No span selected"))
 		return b.String()
 	}
 
 	node := m.visibleNodes[m.cursor]
 
+	// Ancestor path, only worth the space for nested spans
+	if node.Parent != nil {
+		b.WriteString(renderBreadcrumb(node, m.detailViewport.Width))
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat("─", 60))
+	b.WriteString("\n")
+
 	// Render content based on selected tab
 	var content string
 	switch m.selectedTab {
@@ -1284,7 +1814,23 @@ func (m Model) renderOverviewTab(node *SpanNode) string {
 
 	b.WriteString(fmt.Sprintf("%-12s %s\n", "Name:", node.Span.GetFriendlyName()))
 	b.WriteString(fmt.Sprintf("%-12s %s\n", "Type:", node.Span.GetSpanType()))
+	if streaming, known := node.Span.StreamingMode(); known {
+		mode := "buffered"
+		if streaming {
+			mode = WarningStyle.Render("⚡ streaming")
+		}
+		b.WriteString(fmt.Sprintf("%-12s %s\n", "Mode:", mode))
+	}
 	b.WriteString(fmt.Sprintf("%-12s %dms\n", "Duration:", node.DurationMs))
+	if node.Synthetic {
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render("Not a real span: it groups spans whose parent is missing from the trace,\nusually because sampling (--trace-sample) dropped it. Press o to ungroup."))
+		b.WriteString("\n")
+		return b.String()
+	}
+	if node.IsOrphan() {
+		b.WriteString(fmt.Sprintf("%-12s %s\n", "Parent:", WarningStyle.Render(node.Span.Parent.SpanID+" (missing from trace)")))
+	}
 
 	// Status
 	statusText := "OK"
@@ -1399,31 +1945,44 @@ func (m Model) renderAttributesTab(node *SpanNode) string {
 	attrs := node.Span.GetAllAttributes()
 
 	b.WriteString(SectionHeaderStyle.Render("All Attributes"))
+	if m.diffingAttrs() {
+		b.WriteString("  " + MutedStyle.Render(m.attrDiffSummary(attrs)))
+	}
 	b.WriteString("\n\n")
 
-	if len(attrs) == 0 {
+	if len(attrs) == 0 && !m.diffingAttrs() {
 		b.WriteString(MutedStyle.Render("No attributes available"))
 		return b.String()
 	}
 
 	// Sort keys for consistent display
-	keys := make([]string, 0, len(attrs))
-	for k := range attrs {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
+	keys := sortedAttrKeys(attrs)
 
-	// Display as key-value table
-	for _, k := range keys {
+	// Display as key-value table; the detail view marks the attribute the
+	// c and F actions apply to
+	selectable := m.viewMode == DetailView
+	for i, k := range keys {
 		v := attrs[k]
 		// Clean up key for display
-		displayKey := k
-		displayKey = strings.TrimPrefix(displayKey, "agk.")
-		displayKey = strings.TrimPrefix(displayKey, "llm.")
-		displayKey = strings.TrimPrefix(displayKey, "workflow.")
+		displayKey := shortAttrKey(k)
+
+		if selectable && i == min(m.attrCursor, len(keys)-1) {
+			b.WriteString(CursorStyle.Render("→ ") + SelectedStyle.Render(fmt.Sprintf("%-30s %v", k+":", v)) + "\n")
+			continue
+		}
+		if selectable {
+			b.WriteString("  ")
+		}
 
+		if m.diffingAttrs() {
+			b.WriteString(m.renderAttrLine(k, v, fmt.Sprintf("%-30s %v", displayKey+":", v)) + "\n")
+			continue
+		}
 		b.WriteString(fmt.Sprintf("%-30s %v\n", AttributeKeyStyle.Render(displayKey+":"), v))
 	}
+	if m.diffingAttrs() {
+		b.WriteString(m.renderRemovedAttrs(attrs, 30))
+	}
 
 	return b.String()
 }
@@ -1439,8 +1998,30 @@ func (m Model) renderTimingTab(node *SpanNode) string {
 	b.WriteString(fmt.Sprintf("%-15s %dms\n", "Duration:", node.DurationMs))
 	b.WriteString(fmt.Sprintf("%-15s %s\n", "Start Time:", node.Span.StartTime))
 	b.WriteString(fmt.Sprintf("%-15s %s\n", "End Time:", node.Span.EndTime))
+	if node.ClockSkew {
+		b.WriteString(WarningStyle.Render("⚠ clock skew: the span ends before it starts, so its duration is shown as 0ms"))
+		b.WriteString("\n")
+	}
+	if node.BadTimestamp {
+		b.WriteString(WarningStyle.Render("⚠ unrecognized timestamp format, so the duration is shown as 0ms"))
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
 
+	// For streamed calls the first token is what the user waits for, so lead with it
+	streaming, _ := node.Span.StreamingMode()
+	ttftMs, hasTTFTMs := floatAttr(attrs, "llm.time_to_first_token")
+	if streaming && hasTTFTMs {
+		b.WriteString(SectionHeaderStyle.Render("⚡ Streamed Response"))
+		b.WriteString("\n\n")
+		ttftLine := fmt.Sprintf("%-25s %.0fms", "Time to First Token:", ttftMs)
+		if node.DurationMs > 0 {
+			ttftLine += fmt.Sprintf(" (%.0f%% of %dms total)", ttftMs/float64(node.DurationMs)*100, node.DurationMs)
+		}
+		b.WriteString(WarningStyle.Bold(true).Render(ttftLine))
+		b.WriteString("\n\n")
+	}
+
 	// Timing breakdown if child spans exist
 	if len(node.Children) > 0 {
 		b.WriteString(SectionHeaderStyle.Render("Child Spans"))
@@ -1449,7 +2030,10 @@ func (m Model) renderTimingTab(node *SpanNode) string {
 		var totalChildTime int64
 		for _, child := range node.Children {
 			totalChildTime += child.DurationMs
-			percentage := float64(child.DurationMs) / float64(node.DurationMs) * 100
+			var percentage float64
+			if node.DurationMs > 0 {
+				percentage = float64(child.DurationMs) / float64(node.DurationMs) * 100
+			}
 
 			bar := ""
 			barWidth := int(percentage / 2) // 50 chars max
@@ -1467,23 +2051,61 @@ func (m Model) renderTimingTab(node *SpanNode) string {
 		b.WriteString("\n")
 		b.WriteString(fmt.Sprintf("%-30s %5dms\n", "Total Child Time:", totalChildTime))
 
-		selfTime := node.DurationMs - totalChildTime
-		if selfTime > 0 {
-			b.WriteString(fmt.Sprintf("%-30s %5dms\n", "Self Time:", selfTime))
+		if node.SelfTimeMs > 0 {
+			b.WriteString(fmt.Sprintf("%-30s %5dms\n", "Self Time:", node.SelfTimeMs))
 		}
 	}
 
 	// Performance markers if available
-	if ttft, ok := attrs["llm.time_to_first_token"]; ok {
+	ttft, hasTTFT := attrs["llm.time_to_first_token"]
+	completionTokens, hasTokens := floatAttr(attrs, "llm.usage.completion_tokens")
+	if !hasTokens {
+		completionTokens, hasTokens = floatAttr(attrs, "agk.stream.tokens")
+	}
+	hasThroughput := hasTokens && completionTokens > 0 && node.DurationMs > 0
+
+	if streaming && hasTTFTMs {
+		hasTTFT = false // Already shown above
+	}
+
+	if hasTTFT || hasThroughput {
 		b.WriteString("\n")
 		b.WriteString(SectionHeaderStyle.Render("Performance Metrics"))
 		b.WriteString("\n\n")
+	}
+	if hasTTFT {
 		b.WriteString(fmt.Sprintf("%-25s %v\n", "Time to First Token:", ttft))
 	}
+	if hasThroughput {
+		tokensPerSec := completionTokens / (float64(node.DurationMs) / 1000)
+		b.WriteString(fmt.Sprintf("%-25s %.1f tok/s (%d tokens)\n", "Throughput:", tokensPerSec, int(completionTokens)))
+	}
+	if hasTTFTMs && node.DurationMs > 0 {
+		if genMs := float64(node.DurationMs) - ttftMs; genMs > 0 {
+			b.WriteString(fmt.Sprintf("%-25s %.0fms\n", "Generation Time:", genMs))
+			if hasTokens && completionTokens > 0 {
+				b.WriteString(fmt.Sprintf("%-25s %.1f tok/s\n", "Generation Throughput:", completionTokens/(genMs/1000)))
+			}
+		}
+	}
 
 	return b.String()
 }
 
+// floatAttr returns a numeric attribute value, as decoded from the JSON trace
+func floatAttr(attrs map[string]interface{}, key string) (float64, bool) {
+	switch v := attrs[key].(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
 // renderMetadataPanel renders the metadata/diagnostics panel
 func (m Model) renderMetadataPanel() string {
 	var b strings.Builder
@@ -1535,6 +2157,15 @@ func (m Model) renderMetadataPanel() string {
 	content.WriteString("\n")
 	content.WriteString(fmt.Sprintf("%-12s %dms\n", "Duration:", node.DurationMs))
 	content.WriteString(fmt.Sprintf("%-12s %s\n", "Start:", MutedStyle.Render(node.Span.StartTime)))
+	if node.ClockSkew {
+		content.WriteString(fmt.Sprintf("%-12s %s\n", "End:", MutedStyle.Render(node.Span.EndTime)))
+		content.WriteString(WarningStyle.Render("⚠ clock skew: ends before it starts"))
+		content.WriteString("\n")
+	}
+	if node.BadTimestamp {
+		content.WriteString(WarningStyle.Render("⚠ unrecognized timestamp format"))
+		content.WriteString("\n")
+	}
 	content.WriteString("\n")
 
 	// === SCROLLABLE SECTIONS ===
@@ -1561,11 +2192,24 @@ func (m Model) renderMetadataPanel() string {
 	// Tags (all attributes)
 	content.WriteString(SectionHeaderStyle.Render("All Attributes"))
 	content.WriteString("\n")
-	for k, v := range attrs {
-		shortKey := strings.TrimPrefix(k, "agk.")
-		shortKey = strings.TrimPrefix(shortKey, "llm.")
-		shortKey = strings.TrimPrefix(shortKey, "workflow.")
-		content.WriteString(fmt.Sprintf("%-20s %v\n", shortKey+":", v))
+	if m.diffingAttrs() {
+		content.WriteString(MutedStyle.Render(m.attrDiffSummary(attrs)))
+		content.WriteString("\n")
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		line := fmt.Sprintf("%-20s %v", shortAttrKey(k)+":", attrs[k])
+		if m.diffingAttrs() {
+			line = m.renderAttrLine(k, attrs[k], line)
+		}
+		content.WriteString(line + "\n")
+	}
+	if m.diffingAttrs() {
+		content.WriteString(m.renderRemovedAttrs(attrs, 20))
 	}
 
 	// Set viewport content
@@ -1607,11 +2251,19 @@ func (m Model) renderStackedLayout() string {
 func (m Model) renderSearchBar() string {
 	prompt := "Search: " + m.searchQuery + "█"
 	if len(m.searchMatches) > 0 {
-		prompt += fmt.Sprintf(" (%d matches)", len(m.searchMatches))
+		prompt += " (" + m.searchPosition() + ")"
 	}
 	return BoxStyle.Render(prompt)
 }
 
+// searchPosition describes the current match position, e.g. "match 2/7"
+func (m Model) searchPosition() string {
+	if m.searchIndex < 0 {
+		return fmt.Sprintf("%d matches", len(m.searchMatches))
+	}
+	return fmt.Sprintf("match %d/%d", m.searchIndex+1, len(m.searchMatches))
+}
+
 func (m Model) renderRunSummary() string { // Previously renderHeader
 	var lines []string
 
@@ -1641,11 +2293,20 @@ func (m Model) renderRunSummary() string { // Previously renderHeader
 		statParts = append(statParts, ErrorStyle.Render(fmt.Sprintf("Errors: %d", m.errorCount)))
 	}
 
-	statsLine := strings.Join(statParts, "  |  ")
-	lines = append(lines, MutedStyle.Render(statsLine))
+	statsLine := MutedStyle.Render(strings.Join(statParts, "  |  "))
+
+	// Budget badge for unusually large or deep traces
+	if budget := m.budget(); m.spanTotal > budget.MaxSpans || m.treeDepth > budget.MaxDepth {
+		statsLine += "  " + WarningStyle.Render(fmt.Sprintf("⚠ %d spans, depth %d", m.spanTotal, m.treeDepth))
+	}
+	lines = append(lines, statsLine)
+
+	if !m.manifest.Notes.IsEmpty() {
+		lines = append(lines, "Notes: "+renderNotes(m.manifest.Notes))
+	}
 
 	// Slowest span on separate line (only if meaningful)
-	if m.slowestSpan != nil && m.slowestSpan.DurationMs > 100 {
+	if m.slowestSpan != nil && m.slowestSpan.SelfTimeMs > 100 {
 		slowestName := m.slowestSpan.Span.Name
 		attrs := m.slowestSpan.Span.GetAllAttributes()
 		if stepName, ok := attrs["agk.workflow.step_name"]; ok {
@@ -1656,7 +2317,7 @@ func (m Model) renderRunSummary() string { // Previously renderHeader
 		slowestLine := fmt.Sprintf(
 			"Bottleneck: %s %s",
 			MutedStyle.Render(slowestName),
-			DurationStyle.Render(fmt.Sprintf("(%dms)", m.slowestSpan.DurationMs)),
+			DurationStyle.Render(fmt.Sprintf("(%dms self / %dms total)", m.slowestSpan.SelfTimeMs, m.slowestSpan.DurationMs)),
 		)
 		lines = append(lines, slowestLine)
 	}
@@ -1664,9 +2325,9 @@ func (m Model) renderRunSummary() string { // Previously renderHeader
 	return strings.Join(lines, "\n") + "\n" + strings.Repeat("─", m.width-6)
 }
 
-func (m Model) renderSpanLine(node *SpanNode, selected bool) string {
+func (m Model) renderSpanLine(node *SpanNode, selected bool, baseDepth int) string {
 	// Indentation
-	indent := strings.Repeat("  ", node.Depth)
+	indent := strings.Repeat("  ", max(node.Depth-baseDepth, 0))
 
 	// Tree connector
 	var prefix string
@@ -1702,17 +2363,41 @@ func (m Model) renderSpanLine(node *SpanNode, selected bool) string {
 		errorIndicator = ErrorStyle.Render(" [ERR]")
 	}
 
+	// Streaming indicator, since streamed calls show output before they finish
+	streamIndicator := ""
+	if streaming, _ := node.Span.StreamingMode(); streaming {
+		streamIndicator = WarningStyle.Render(" ⚡")
+	}
+
 	// Search match indicator
 	searchIndicator := ""
 	if m.isSearchMatch(node) {
 		searchIndicator = " 🔍"
 	}
 
-	// Duration
-	duration := DurationStyle.Render(fmt.Sprintf("(%dms)", node.DurationMs))
+	// Duration, colored by severity
+	duration := m.thresholds().Style(node.DurationMs).Render(fmt.Sprintf("(%dms)", node.DurationMs))
+
+	// The orphan group is marked as not being a real span
+	if node.Synthetic {
+		name = WarningStyle.Render(node.Span.Name) + MutedStyle.Render(node.orphanGroupLabel())
+		duration = MutedStyle.Render(fmt.Sprintf("(%dms)", node.DurationMs))
+	}
+
+	// A collapsed group of repeated spans shows its size and total duration
+	if node.IsCollapsedGroup() {
+		name += MutedStyle.Render(node.groupLabel())
+		total := node.GroupDurationMs()
+		duration = m.thresholds().Style(total).Render(fmt.Sprintf("(%dms total)", total))
+	}
+
+	// Spans folded by the depth limit say how much is hidden below them
+	if label := m.foldedLevelsLabel(node); label != "" {
+		name += MutedStyle.Render(label)
+	}
 
 	// Build line
-	line := fmt.Sprintf("%s%s%s%s%s%s %s", indent, prefix, name, context, errorIndicator, searchIndicator, duration)
+	line := fmt.Sprintf("%s%s%s%s%s%s%s %s", indent, prefix, name, context, streamIndicator, errorIndicator, searchIndicator, duration)
 
 	// Apply selection styling
 	if selected {
@@ -1747,6 +2432,8 @@ func (m Model) renderDetailView() string {
 	title := fmt.Sprintf("📋 Span: %s", node.Span.Name)
 	b.WriteString(HeaderStyle.Render(title))
 	b.WriteString("\n")
+	b.WriteString(renderBreadcrumb(node, m.width-4))
+	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", m.width-4))
 	b.WriteString("\n")
 
@@ -1774,6 +2461,35 @@ func (m Model) renderDetailView() string {
 	return b.String()
 }
 
+// renderBreadcrumb renders the chain of ancestors from the root down to node
+// using friendly span names, eliding the outermost ancestors to fit width
+func renderBreadcrumb(node *SpanNode, width int) string {
+	var names []string
+	for n := node; n != nil; n = n.Parent {
+		names = append([]string{n.Span.GetFriendlyName()}, names...)
+	}
+
+	const sep = " › "
+	current := names[len(names)-1]
+	ancestors := names[:len(names)-1]
+
+	// Drop ancestors from the root end until the path fits
+	elided := false
+	for len(ancestors) > 0 && width > 0 &&
+		lipgloss.Width(strings.Join(append(ancestors, current), sep))+2 > width {
+		ancestors = ancestors[1:]
+		elided = true
+	}
+	if elided {
+		ancestors = append([]string{"…"}, ancestors...)
+	}
+
+	if len(ancestors) == 0 {
+		return SelectedStyle.Render(current)
+	}
+	return MutedStyle.Render(strings.Join(ancestors, sep)+sep) + SelectedStyle.Render(current)
+}
+
 func (m Model) renderDetailContent(node *SpanNode) string {
 	var b strings.Builder
 
@@ -1868,13 +2584,13 @@ func (m Model) renderContentSection(node *SpanNode) string {
 			b.WriteString(MutedStyle.Render(strings.Repeat("─", 40)))
 			b.WriteString("\n")
 
-			// Content (truncate if too long)
-			maxLen := 500
-			if len(content) > maxLen {
+			// Content (truncate if too long, unless expanded)
+			maxLen := m.contentLimit()
+			if fullLen := len(content); !m.contentExpanded && fullLen > maxLen {
 				content = content[:maxLen-3] + "..."
 				b.WriteString(content)
 				b.WriteString("\n")
-				b.WriteString(MutedStyle.Render("[truncated]"))
+				b.WriteString(MutedStyle.Render(fmt.Sprintf("[truncated %d/%d chars - f: show full, +/-: adjust]", maxLen, fullLen)))
 			} else {
 				b.WriteString(content)
 			}
@@ -2049,19 +2765,185 @@ func (m Model) isErrorNode(node *SpanNode) bool {
 
 // ensureNodeVisible expands parent nodes to make a node visible
 func (m Model) ensureNodeVisible(node *SpanNode) Model {
-	// Walk up the tree and expand all parents
-	current := node.Parent
-	for current != nil {
-		if !current.Expanded {
+	// Walk up the tree and expand all parents, and any group hiding them
+	for current := node; current != nil; current = current.Parent {
+		if current != node && !current.Expanded {
 			current.Expanded = true
 		}
-		current = current.Parent
+		if current.repeatOf != nil {
+			current.repeatOf.RepeatsExpanded = true
+		}
 	}
 	// Rebuild visible list
-	m.visibleNodes = FlattenTree(m.roots)
+	m.visibleNodes = FlattenTree(m.treeRoots())
 	return m
 }
 
+// FocusSpan returns a copy with the cursor on the span with the given ID,
+// expanding its ancestors. With openDetail the span's detail view is shown.
+func (m Model) FocusSpan(spanID string, openDetail bool) (Model, error) {
+	node := findSpanNode(m.roots, spanID)
+	if node == nil {
+		return m, fmt.Errorf("span not found: %s", spanID)
+	}
+	if zoom := m.zoomedNode(); zoom != nil && findSpanNode([]*SpanNode{zoom}, spanID) == nil {
+		m.zoomKey = ""
+	}
+
+	m = m.ensureNodeVisible(node)
+	for i, visible := range m.visibleNodes {
+		if visible == node {
+			m.cursor = i
+			break
+		}
+	}
+	m.focusArea = FocusTree
+
+	if openDetail {
+		m.viewMode = DetailView
+		m.updateDetailViewport()
+	}
+	return m, nil
+}
+
+// findSpanNode searches the tree for a span ID
+func findSpanNode(nodes []*SpanNode, spanID string) *SpanNode {
+	for _, node := range nodes {
+		if node.Span.SpanContext.SpanID == spanID {
+			return node
+		}
+		if found := findSpanNode(node.Children, spanID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// RunID returns the ID of the run currently loaded in the viewer
+func (m Model) RunID() string {
+	return m.runID
+}
+
+// MaxContentLen returns a copy with the content truncation length set
+func (m Model) MaxContentLen(n int) Model {
+	m.maxContentLen = n
+	return m
+}
+
+// DurationThresholds returns a copy with the duration color thresholds set
+func (m Model) DurationThresholds(t DurationThresholds) Model {
+	m.durationThresholds = t
+	return m
+}
+
+// thresholds returns the effective duration color thresholds
+func (m Model) thresholds() DurationThresholds {
+	if m.durationThresholds == (DurationThresholds{}) {
+		return DefaultDurationThresholds
+	}
+	return m.durationThresholds
+}
+
+// Layout returns a copy using the given panel layout
+func (m Model) Layout(layout LayoutMode) Model {
+	m.layout = layout
+	if m.focusArea >= m.panelCount() {
+		m.focusArea = FocusTree
+	}
+	return m
+}
+
+// panelCount returns the number of focusable panels in the current layout
+func (m Model) panelCount() FocusArea {
+	switch m.layout {
+	case LayoutCompact:
+		return 2
+	case LayoutSingle:
+		return 1
+	default:
+		return 3
+	}
+}
+
+// SpanBudget returns a copy with the span count/depth warning thresholds set
+func (m Model) SpanBudget(budget SpanBudget) Model {
+	m.spanBudget = budget
+	return m
+}
+
+// budget returns the effective span budget
+func (m Model) budget() SpanBudget {
+	if m.spanBudget == (SpanBudget{}) {
+		return DefaultSpanBudget
+	}
+	return m.spanBudget
+}
+
+// ScopeFilter returns a copy showing only spans whose instrumentation scope
+// matches include and not exclude (see FilterSpansByScope)
+func (m Model) ScopeFilter(include, exclude []string) Model {
+	if len(include) == 0 && len(exclude) == 0 {
+		return m
+	}
+	m.includeScopes = include
+	m.excludeScopes = exclude
+	m.droppedParents = nil
+	m.setTree(m.filterScopes(m.collectAllSpans()))
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	m.cursor = 0
+	m.applyRepeatCollapsing()
+	m.computeMetrics()
+	return m
+}
+
+// filterScopes applies the scope filters to spans, recording the parent of
+// each span they drop. Spans are written when they end, so in a live trace
+// a kept span can arrive in an earlier batch than the dropped parent it has
+// to be lifted past.
+func (m *Model) filterScopes(spans []Span) []Span {
+	if len(m.includeScopes) == 0 && len(m.excludeScopes) == 0 {
+		return spans
+	}
+	kept := FilterSpansByScope(spans, m.includeScopes, m.excludeScopes)
+	keptIDs := make(map[string]bool, len(kept))
+	for _, span := range kept {
+		keptIDs[span.SpanContext.SpanID] = true
+	}
+	if m.droppedParents == nil {
+		m.droppedParents = make(map[string]string)
+	}
+	for _, span := range spans {
+		if !keptIDs[span.SpanContext.SpanID] {
+			m.droppedParents[span.SpanContext.SpanID] = span.Parent.SpanID
+		}
+	}
+	for i := range kept {
+		kept[i].Parent.SpanID = m.resolveParent(kept[i].Parent.SpanID)
+	}
+	return kept
+}
+
+// resolveParent follows parentID up past spans dropped by the scope filters
+func (m Model) resolveParent(parentID string) string {
+	// At most one step per dropped span, so a malformed cycle ends
+	for range m.droppedParents {
+		next, ok := m.droppedParents[parentID]
+		if !ok {
+			break
+		}
+		parentID = next
+	}
+	return parentID
+}
+
+// contentLimit returns the effective content truncation length
+func (m Model) contentLimit() int {
+	if m.maxContentLen <= 0 {
+		return DefaultMaxContentLen
+	}
+	return max(m.maxContentLen, minContentLen)
+}
+
 // Width returns a copy with updated width
 func (m Model) Width(w int) Model {
 	m.width = w
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLiveBadgeOnlyForUnfinishedRuns(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	if err := os.WriteFile(tracePath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	child := Span{
+		Name:        "llm.call",
+		StartTime:   "2026-01-01T10:00:00Z",
+		EndTime:     "2026-01-01T10:00:01Z",
+		SpanContext: SpanContext{SpanID: "bbbbbbbbbbbbbbbb"},
+		Parent:      ParentSpan{SpanID: "aaaaaaaaaaaaaaaa"},
+	}
+	root := Span{
+		Name:        "agent.run",
+		StartTime:   "2026-01-01T10:00:00Z",
+		EndTime:     "2026-01-01T10:00:02Z",
+		SpanContext: SpanContext{SpanID: "aaaaaaaaaaaaaaaa"},
+		Parent:      ParentSpan{SpanID: "0000000000000000"},
+	}
+
+	running := NewTraceViewerWithPath("run-1", TraceRun{}, []Span{child}, tracePath, 0)
+	running.width = 120
+	if header := running.renderGlobalHeader(); !strings.Contains(header, "LIVE") {
+		t.Errorf("run without its root span: header %q, want a LIVE badge", header)
+	}
+
+	finished := NewTraceViewerWithPath("run-1", TraceRun{}, []Span{child, root}, tracePath, 0)
+	finished.width = 120
+	if header := finished.renderGlobalHeader(); strings.Contains(header, "LIVE") || strings.Contains(header, "stalled") {
+		t.Errorf("finished run: header %q, want no LIVE or stalled badge", header)
+	}
+	if finished.watching() {
+		t.Error("finished run is still being watched")
+	}
+}
+
+func TestReadNewSpansWaitsForPartialLine(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	first := `{"Name":"llm.call","SpanContext":{"SpanID":"aaaaaaaaaaaaaaaa"}}`
+	second := `{"Name":"tool.call","SpanContext":{"SpanID":"bbbbbbbbbbbbbbbb"}}`
+	write := func(data string) {
+		f, err := os.OpenFile(tracePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		if _, err := f.WriteString(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	steps := []struct {
+		write string
+		want  []string
+	}{
+		// The second span is cut off mid-write
+		{write: first + "\n" + second[:20], want: []string{"llm.call"}},
+		{write: second[20:], want: []string{"tool.call"}},
+		{write: "\n", want: nil},
+	}
+	var offset int64
+	for i, step := range steps {
+		write(step.write)
+		var spans []Span
+		spans, offset = ReadNewSpans(tracePath, offset)
+		var got []string
+		for _, span := range spans {
+			got = append(got, span.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(step.want, ",") {
+			t.Errorf("read %d: got spans %v, want %v", i+1, got, step.want)
+		}
+	}
+}
+
+func TestCompleteLines(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"terminated", "{\"a\":1}\n", "{\"a\":1}\n"},
+		{"final line without newline", "{\"a\":1}\n{\"b\":2}", "{\"a\":1}\n{\"b\":2}"},
+		{"final line being written", "{\"a\":1}\n{\"b\":", "{\"a\":1}\n"},
+		{"only a partial line", "{\"a\"", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(CompleteLines([]byte(tt.data))); got != tt.want {
+				t.Errorf("CompleteLines(%q) = %q, want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotesKeyLeavesSearchKeysAlone(t *testing.T) {
+	m := NewTraceViewer("run-1", TraceRun{}, []Span{
+		liveSpan("root", "", 0, 1000),
+		liveSpan("plan", "root", 0, 500),
+	}).EditableNotes(func(string, RunNotes) error { return nil })
+	key := func(m Model, k string) Model {
+		updated, _ := m.updateTreeView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return updated.(Model)
+	}
+
+	// Without a search, N does nothing rather than open the notes
+	if m = key(m, "N"); m.notesMode {
+		t.Fatal("N opened the notes input")
+	}
+
+	m.searchMatches = m.visibleNodes
+	m.searchIndex = 1
+	if m = key(m, "N"); m.notesMode || m.searchIndex != 0 {
+		t.Errorf("N = notes %v, match %d; want the previous match, 0", m.notesMode, m.searchIndex)
+	}
+
+	if m = key(m, "t"); !m.notesMode || m.notesRunID != "run-1" {
+		t.Errorf("t = notes %v for %q, want the notes input for run-1", m.notesMode, m.notesRunID)
+	}
+}
@@ -0,0 +1,93 @@
+package tui
+
+import "fmt"
+
+// zoomKey identifies a node across tree rebuilds: its span ID, or the name of
+// a synthetic grouping node, which has no span ID
+func (n *SpanNode) zoomKey() string {
+	if n.Synthetic {
+		return "synthetic:" + n.Span.Name
+	}
+	return n.Span.SpanContext.SpanID
+}
+
+// zoomedNode returns the node the tree is focused on, or nil when showing the
+// whole trace or when the node has left the tree (e.g. filtered out)
+func (m Model) zoomedNode() *SpanNode {
+	if m.zoomKey == "" {
+		return nil
+	}
+	for _, node := range AllNodes(m.roots) {
+		if node.zoomKey() == m.zoomKey {
+			return node
+		}
+	}
+	return nil
+}
+
+// treeRoots returns the roots the tree panel shows: the focused node while
+// zoomed in, otherwise every root. m.roots always keeps the whole trace so
+// metrics and live updates aren't affected by zooming.
+func (m Model) treeRoots() []*SpanNode {
+	if node := m.zoomedNode(); node != nil {
+		return []*SpanNode{node}
+	}
+	return m.roots
+}
+
+// zoomIn focuses the tree on the selected span and its descendants, hiding
+// everything else until zoomOut
+func (m Model) zoomIn() Model {
+	if m.cursor >= len(m.visibleNodes) {
+		return m
+	}
+	node := m.visibleNodes[m.cursor]
+	if !node.HasChildren() {
+		m.statusMessage = "Nothing to focus on: span has no children"
+		return m
+	}
+
+	m.zoomKey = node.zoomKey()
+	node.Expanded = true
+	m.visibleNodes = FlattenTree(m.treeRoots())
+	m.cursor = 0
+	m.treeViewport.GotoTop()
+	if m.searchQuery != "" {
+		m = m.executeSearch()
+	}
+	return m
+}
+
+// zoomOut shows the whole trace again, keeping the cursor on the same span
+func (m Model) zoomOut() Model {
+	var selected *SpanNode
+	if m.cursor < len(m.visibleNodes) {
+		selected = m.visibleNodes[m.cursor]
+	}
+
+	m.zoomKey = ""
+	m.visibleNodes = FlattenTree(m.roots)
+	m.restoreCursor(selected)
+	if m.searchQuery != "" {
+		m = m.executeSearch()
+	}
+	return m
+}
+
+// zoomDepth is how far the focused node is nested, so the tree panel can
+// indent it as a root
+func (m Model) zoomDepth() int {
+	if node := m.zoomedNode(); node != nil {
+		return node.Depth
+	}
+	return 0
+}
+
+// zoomLabel describes the focused node for the tree panel title
+func (m Model) zoomLabel() string {
+	node := m.zoomedNode()
+	if node == nil {
+		return ""
+	}
+	return fmt.Sprintf("focused on: %s (esc to zoom out)", node.Span.GetFriendlyName())
+}
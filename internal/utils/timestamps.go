@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are tried in order by ParseTimestamp. Traces should use
+// RFC 3339, but exporters and hand-written spans also produce these.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",      // Offset without a colon
+	"2006-01-02 15:04:05.999999999Z07:00",     // Space instead of T
+	"2006-01-02 15:04:05.999999999Z0700",      // Both of the above
+	"2006-01-02 15:04:05.999999999 -0700 MST", // Go's time.Time.String()
+	"2006-01-02T15:04:05.999999999",           // No timezone
+	"2006-01-02 15:04:05.999999999",           // No timezone, space instead of T
+}
+
+// ParseTimestamp parses a span timestamp, accepting RFC 3339 and a few
+// common variants. Timestamps without a timezone are read as UTC, which
+// keeps durations right as long as a trace is consistent.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	// Drop the monotonic clock reading time.Time.String() appends
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp format: %q", s)
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	utc := time.Date(2026, 1, 19, 9, 36, 38, 897000000, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		{"rfc3339", "2026-01-19T09:36:38.897Z", utc},
+		{"rfc3339 offset", "2026-01-19T18:36:38.897+09:00", utc},
+		{"offset without colon", "2026-01-19T18:36:38.897+0900", utc},
+		{"space separator", "2026-01-19 09:36:38.897Z", utc},
+		{"no timezone", "2026-01-19T09:36:38.897", utc},
+		{"no timezone space separator", "2026-01-19 09:36:38.897", utc},
+		{"go time string", "2026-01-19 18:36:38.897 +0900 JST m=+0.000123", utc},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.value)
+			if err != nil {
+				t.Fatalf("ParseTimestamp(%q) error = %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimestamp(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "yesterday", "19/01/2026 09:36"} {
+		if _, err := ParseTimestamp(value); err == nil {
+			t.Errorf("ParseTimestamp(%q) succeeded, want an error", value)
+		}
+	}
+}
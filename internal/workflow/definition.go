@@ -0,0 +1,255 @@
+// Package workflow loads workflow definitions and builds them into
+// AgenticGoKit workflows for 'agk workflow run'.
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+	"github.com/agenticgokit/agk/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Modes lists the supported execution modes
+var Modes = []string{string(agk.Sequential), string(agk.Parallel), string(agk.DAG), string(agk.Loop)}
+
+// Definition is a workflow definition file. TOML files are AgenticGoKit's
+// own format and are built by agk.LoadWorkflowFromTOML; YAML files use the
+// same keys and are translated into its [workflow] table.
+type Definition struct {
+	Path   string
+	Config agk.WorkflowConfig
+	yaml   bool
+}
+
+// yamlDefinition is the YAML form of AgenticGoKit's [workflow] table
+type yamlDefinition struct {
+	Name          string         `yaml:"name"`
+	Mode          string         `yaml:"mode"` // Defaults to sequential
+	Timeout       string         `yaml:"timeout"`
+	MaxIterations int            `yaml:"max_iterations"` // Loop mode only
+	LLM           *yamlLLM       `yaml:"llm"`            // Shared by every agent
+	Agents        []yamlAgentDef `yaml:"agent_defs"`
+	Steps         []yamlStepDef  `yaml:"step_defs"`
+}
+
+type yamlLLM struct {
+	Provider    string  `yaml:"provider"`
+	Model       string  `yaml:"model"`
+	Temperature float32 `yaml:"temperature"`
+	MaxTokens   int     `yaml:"max_tokens"`
+	BaseURL     string  `yaml:"base_url"` // May reference ${ENV_VARS}
+	HTTPTimeout string  `yaml:"http_timeout"`
+}
+
+type yamlAgentDef struct {
+	Name         string  `yaml:"name"`
+	SystemPrompt string  `yaml:"system_prompt"`
+	Temperature  float64 `yaml:"temperature"`
+	MaxTokens    int     `yaml:"max_tokens"`
+}
+
+type yamlStepDef struct {
+	Name      string   `yaml:"name"`
+	Agent     string   `yaml:"agent"`
+	DependsOn []string `yaml:"depends_on"` // DAG mode only
+}
+
+// Load reads a definition from a YAML file, or from the [workflow] table of
+// a TOML file, and validates it
+func Load(path string) (*Definition, error) {
+	def := &Definition{Path: path}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workflow: %w", err)
+		}
+		var file yamlDefinition
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if def.Config, err = file.translate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		def.yaml = true
+	case ".toml":
+		// Decoded the way agk.LoadWorkflowFromTOML does, to validate it
+		// and report on the run
+		var file agk.ProjectConfig
+		if _, err := toml.DecodeFile(path, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		def.Config = file.Workflow
+	default:
+		return nil, fmt.Errorf("%s: workflow definitions must be .yaml, .yml or .toml files", path)
+	}
+
+	if def.Config.Name == "" {
+		def.Config.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := validate(&def.Config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return def, nil
+}
+
+// translate converts the YAML definition to AgenticGoKit's [workflow] table
+func (y *yamlDefinition) translate() (agk.WorkflowConfig, error) {
+	cfg := agk.WorkflowConfig{
+		Name:          y.Name,
+		Mode:          agk.WorkflowMode(y.Mode),
+		MaxIterations: y.MaxIterations,
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = agk.Sequential
+	}
+	var err error
+	if cfg.Timeout, err = parseTimeout(y.Timeout); err != nil {
+		return cfg, fmt.Errorf("invalid timeout %q: %w", y.Timeout, err)
+	}
+	if y.LLM != nil {
+		cfg.LLM = &agk.LLMConfig{
+			Provider:    y.LLM.Provider,
+			Model:       y.LLM.Model,
+			Temperature: y.LLM.Temperature,
+			MaxTokens:   y.LLM.MaxTokens,
+			BaseURL:     os.ExpandEnv(y.LLM.BaseURL),
+		}
+		if cfg.LLM.HTTPTimeout, err = parseTimeout(y.LLM.HTTPTimeout); err != nil {
+			return cfg, fmt.Errorf("invalid llm http_timeout %q: %w", y.LLM.HTTPTimeout, err)
+		}
+	}
+	for _, a := range y.Agents {
+		cfg.AgentDefs = append(cfg.AgentDefs, agk.WorkflowAgentDef(a))
+	}
+	for _, s := range y.Steps {
+		cfg.StepDefs = append(cfg.StepDefs, agk.WorkflowStepDef(s))
+	}
+	return cfg, nil
+}
+
+// validate checks the mode, and that steps reference defined agents and
+// steps
+func validate(cfg *agk.WorkflowConfig) error {
+	validMode := false
+	for _, m := range Modes {
+		validMode = validMode || string(cfg.Mode) == m
+	}
+	if !validMode {
+		return fmt.Errorf("unknown mode %q (supported: %s)", cfg.Mode, strings.Join(Modes, ", "))
+	}
+
+	agents := make(map[string]bool)
+	for _, a := range cfg.AgentDefs {
+		if a.Name == "" {
+			return fmt.Errorf("agent_defs entries need a name")
+		}
+		if agents[a.Name] {
+			return fmt.Errorf("agent %s is defined twice", a.Name)
+		}
+		agents[a.Name] = true
+	}
+
+	if len(cfg.StepDefs) == 0 {
+		return fmt.Errorf("workflow has no step_defs")
+	}
+	steps := make(map[string]bool)
+	for _, s := range cfg.StepDefs {
+		if s.Name == "" {
+			return fmt.Errorf("step_defs entries need a name")
+		}
+		if steps[s.Name] {
+			return fmt.Errorf("step %s is defined twice", s.Name)
+		}
+		if !agents[s.Agent] {
+			return fmt.Errorf("step %s references undefined agent %q", s.Name, s.Agent)
+		}
+		steps[s.Name] = true
+	}
+	for _, s := range cfg.StepDefs {
+		for _, dep := range s.DependsOn {
+			if !steps[dep] {
+				return fmt.Errorf("step %s depends on undefined step %q", s.Name, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// parseTimeout parses an optional duration
+func parseTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// Build creates the workflow and its agents with agk.LoadWorkflowFromTOML.
+// For YAML definitions, fallback supplies the LLM settings the definition
+// leaves out, usually the project's [llm] table; TOML files are built
+// exactly as AgenticGoKit builds them.
+func (d *Definition) Build(fallback agk.LLMConfig) (agk.Workflow, error) {
+	if !d.yaml {
+		return agk.LoadWorkflowFromTOML(d.Path)
+	}
+
+	cfg := d.Config
+	llm := fallback
+	if cfg.LLM != nil {
+		llm = over(*cfg.LLM, fallback)
+	}
+	if llm.Provider == "" {
+		return nil, fmt.Errorf("workflow %s has no llm provider; set one in the workflow or agk.toml's [llm] table", cfg.Name)
+	}
+	if llm.Model == "" {
+		llm.Model = config.DefaultModel(llm.Provider)
+	}
+	cfg.LLM = &llm
+
+	// AgenticGoKit only builds workflows from TOML files
+	file, err := os.CreateTemp("", "agk-workflow-*.toml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate workflow: %w", err)
+	}
+	defer os.Remove(file.Name())
+	err = toml.NewEncoder(file).Encode(struct {
+		Workflow agk.WorkflowConfig `toml:"workflow"`
+	}{cfg})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate workflow: %w", err)
+	}
+	return agk.LoadWorkflowFromTOML(file.Name())
+}
+
+// over returns l with its empty fields taken from base
+func over(l, base agk.LLMConfig) agk.LLMConfig {
+	// The base model only applies when the provider is inherited too
+	if l.Provider == "" {
+		l.Provider = base.Provider
+		if l.Model == "" {
+			l.Model = base.Model
+		}
+	}
+	if l.Temperature == 0 {
+		l.Temperature = base.Temperature
+	}
+	if l.MaxTokens == 0 {
+		l.MaxTokens = base.MaxTokens
+	}
+	if l.BaseURL == "" && l.Provider == base.Provider {
+		l.BaseURL = base.BaseURL
+	}
+	if l.HTTPTimeout == 0 {
+		l.HTTPTimeout = base.HTTPTimeout
+	}
+	return l
+}
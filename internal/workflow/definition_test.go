@@ -0,0 +1,160 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+)
+
+func writeDefinition(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const researchYAML = `
+mode: dag
+timeout: 2m
+llm:
+  provider: ollama
+  http_timeout: 30s
+agent_defs:
+  - name: researcher
+    system_prompt: Research the topic.
+    temperature: 0.7
+  - name: writer
+    system_prompt: Write it up.
+step_defs:
+  - name: research
+    agent: researcher
+  - name: write
+    agent: writer
+    depends_on: [research]
+`
+
+func TestLoadYAML(t *testing.T) {
+	def, err := Load(writeDefinition(t, "research.yaml", researchYAML))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := agk.WorkflowConfig{
+		Name:    "research",
+		Mode:    agk.DAG,
+		Timeout: 2 * time.Minute,
+		LLM:     &agk.LLMConfig{Provider: "ollama", HTTPTimeout: 30 * time.Second},
+		AgentDefs: []agk.WorkflowAgentDef{
+			{Name: "researcher", SystemPrompt: "Research the topic.", Temperature: 0.7},
+			{Name: "writer", SystemPrompt: "Write it up."},
+		},
+		StepDefs: []agk.WorkflowStepDef{
+			{Name: "research", Agent: "researcher"},
+			{Name: "write", Agent: "writer", DependsOn: []string{"research"}},
+		},
+	}
+	if !reflect.DeepEqual(def.Config, want) {
+		t.Errorf("Load() config = %+v, want %+v", def.Config, want)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeDefinition(t, "agk.toml", `
+[workflow]
+name = "story"
+mode = "sequential"
+
+[[workflow.agent_defs]]
+name = "writer"
+system_prompt = "Write a story."
+
+[[workflow.step_defs]]
+name = "write"
+agent = "writer"
+`)
+	def, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if def.Config.Name != "story" || def.Config.Mode != agk.Sequential || len(def.Config.StepDefs) != 1 {
+		t.Errorf("Load() config = %+v, want the story workflow with one step", def.Config)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown mode",
+			content: "mode: swarm\nagent_defs: [{name: a}]\nstep_defs: [{name: s, agent: a}]\n",
+			wantErr: `unknown mode "swarm"`,
+		},
+		{
+			name:    "no steps",
+			content: "agent_defs: [{name: a}]\n",
+			wantErr: "no step_defs",
+		},
+		{
+			name:    "undefined agent",
+			content: "step_defs: [{name: s, agent: missing}]\n",
+			wantErr: `undefined agent "missing"`,
+		},
+		{
+			name:    "undefined dependency",
+			content: "mode: dag\nagent_defs: [{name: a}]\nstep_defs: [{name: s, agent: a, depends_on: [missing]}]\n",
+			wantErr: `undefined step "missing"`,
+		},
+		{
+			name:    "bad timeout",
+			content: "timeout: soon\nagent_defs: [{name: a}]\nstep_defs: [{name: s, agent: a}]\n",
+			wantErr: `invalid timeout "soon"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeDefinition(t, "workflow.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildYAMLFallsBackToProjectLLM(t *testing.T) {
+	def, err := Load(writeDefinition(t, "research.yaml", researchYAML))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	wf, err := def.Build(agk.LLMConfig{Provider: "ollama", Model: "llama3.2", MaxTokens: 500, HTTPTimeout: time.Minute})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	cfg := wf.GetConfig()
+	if cfg.Mode != agk.DAG || cfg.Timeout != 2*time.Minute {
+		t.Errorf("built mode %q timeout %s, want dag and 2m", cfg.Mode, cfg.Timeout)
+	}
+	want := agk.LLMConfig{Provider: "ollama", Model: "llama3.2", MaxTokens: 500, HTTPTimeout: 30 * time.Second}
+	if cfg.LLM == nil || !reflect.DeepEqual(*cfg.LLM, want) {
+		t.Errorf("built llm = %+v, want %+v", cfg.LLM, want)
+	}
+}
+
+func TestBuildYAMLNeedsProvider(t *testing.T) {
+	def, err := Load(writeDefinition(t, "plain.yaml", "agent_defs: [{name: a}]\nstep_defs: [{name: s, agent: a}]\n"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, err := def.Build(agk.LLMConfig{}); err == nil || !strings.Contains(err.Error(), "no llm provider") {
+		t.Errorf("Build() error = %v, want a missing provider error", err)
+	}
+}
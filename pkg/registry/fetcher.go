@@ -19,7 +19,10 @@ type Fetcher interface {
 }
 
 // GitFetcher downloads templates from Git repositories.
-type GitFetcher struct{}
+type GitFetcher struct {
+	// Progress receives git's clone progress; nil discards it.
+	Progress io.Writer
+}
 
 // Fetch implements Fetcher for Git repositories.
 // It supports cloning specific tags or the latest default branch.
@@ -38,8 +41,8 @@ func (f *GitFetcher) Fetch(ctx context.Context, source, version, dest string) er
 
 	cloneOpts := &git.CloneOptions{
 		URL:      url,
-		Progress: os.Stdout, // Should ideally be controlled by logger/context
-		Depth:    1,         // Default to shallow clone
+		Progress: f.Progress,
+		Depth:    1, // Default to shallow clone
 		Tags:     git.NoTags,
 	}
 
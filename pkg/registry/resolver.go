@@ -2,9 +2,13 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -12,11 +16,19 @@ const (
 	FetcherTypeLocal = "local"
 )
 
+// DefaultFetchTimeout bounds how long fetching a template may take.
+const DefaultFetchTimeout = 2 * time.Minute
+
+// ErrFetchTimeout is returned by Resolve when fetching a template takes
+// longer than the resolver's fetch timeout.
+var ErrFetchTimeout = errors.New("template fetch timed out")
+
 // Resolver handles resolving template references to cached templates.
 // It orchestrates fetching and caching.
 type Resolver struct {
-	cache    *CacheManager
-	fetchers map[string]Fetcher // "git", "local"
+	cache        *CacheManager
+	fetchers     map[string]Fetcher // "git", "local"
+	fetchTimeout time.Duration
 }
 
 // NewResolver creates a new template resolver.
@@ -24,17 +36,28 @@ func NewResolver(cache *CacheManager) *Resolver {
 	return &Resolver{
 		cache: cache,
 		fetchers: map[string]Fetcher{
-			FetcherTypeGit:   &GitFetcher{},
+			FetcherTypeGit:   &GitFetcher{Progress: os.Stdout},
 			FetcherTypeLocal: &LocalFetcher{},
 		},
+		fetchTimeout: DefaultFetchTimeout,
 	}
 }
 
-// Resolve locates a template, fetching it if necessary, and returns the cached template.
-// Source can be:
-// - GitHub URL: github.com/user/repo or https://github.com/user/repo
-// - Versioned: github.com/user/repo@v1.0.0
-// - Local path: ./my-template or /abs/path/to/template
+// FetchTimeout sets how long Resolve may spend fetching a template that
+// isn't cached. Zero or less means no limit.
+func (r *Resolver) FetchTimeout(timeout time.Duration) *Resolver {
+	r.fetchTimeout = timeout
+	return r
+}
+
+// Progress sets where git clone progress is written; nil silences it.
+func (r *Resolver) Progress(w io.Writer) *Resolver {
+	if fetcher, ok := r.fetchers[FetcherTypeGit].(*GitFetcher); ok {
+		fetcher.Progress = w
+	}
+	return r
+}
+
 // Resolve locates a template, fetching it if necessary, and returns the cached template.
 // Source can be:
 // - GitHub URL: github.com/user/repo or https://github.com/user/repo
@@ -69,7 +92,16 @@ func (r *Resolver) Resolve(ctx context.Context, sourceRef string) (*CachedTempla
 		return nil, fmt.Errorf("no fetcher for type %s", fetcherType)
 	}
 
-	if err := fetcher.Fetch(ctx, source, version, destPath); err != nil {
+	fetchCtx := ctx
+	if r.fetchTimeout > 0 {
+		var cancel context.CancelFunc
+		fetchCtx, cancel = context.WithTimeout(ctx, r.fetchTimeout)
+		defer cancel()
+	}
+	if err := fetcher.Fetch(fetchCtx, source, version, destPath); err != nil {
+		if errors.Is(fetchCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, fmt.Errorf("%w after %s: %s", ErrFetchTimeout, r.fetchTimeout, source)
+		}
 		return nil, fmt.Errorf("failed to fetch template: %w", err)
 	}
 
@@ -43,7 +43,7 @@ func (g *ExternalGenerator) Generate(ctx context.Context, opts GenerateOptions)
 	// Prepare template data
 	data := TemplateData{
 		ProjectName: opts.ProjectName,
-		LLMModel:    getLLMModel(opts.LLMProvider),
+		LLMModel:    resolveLLMModel(opts),
 		LLMProvider: opts.LLMProvider,
 		Description: opts.Description,
 		AgentType:   opts.AgentType,
@@ -105,10 +105,10 @@ func (g *ExternalGenerator) Generate(ctx context.Context, opts GenerateOptions)
 		if err != nil {
 			// If render fails (e.g. binary file), just copy original
 			// Ideally check for binary before rendering
-			return os.WriteFile(destPath, content, info.Mode())
+			return writeProjectFile(opts, destPath, content, info.Mode())
 		}
 
-		return os.WriteFile(destPath, []byte(rendered), info.Mode())
+		return writeProjectFile(opts, destPath, []byte(rendered), info.Mode())
 	})
 
 	return err
@@ -20,7 +20,11 @@ type GenerateOptions struct {
 	Force       bool
 	Description string
 	LLMProvider string
+	LLMModel    string
 	AgentType   string
+	// Record, when set, collects the files generation writes; backup notices
+	// are then left to the caller instead of being printed
+	Record *GeneratedFiles
 }
 
 // Service handles project scaffolding and generation
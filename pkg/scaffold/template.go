@@ -54,14 +54,14 @@ func GetAllTemplates() []TemplateMetadata {
 			Name:        "Quickstart",
 			Description: "Minimal setup - perfect for learning",
 			Complexity:  "⭐",
-			FileCount:   2,
+			FileCount:   3,
 			Features:    []string{"Agent", "Hardcoded Config"},
 		},
 		{
 			Name:        "Workflow",
 			Description: "Multi-step streaming workflow pipeline",
 			Complexity:  "⭐⭐⭐",
-			FileCount:   3,
+			FileCount:   5,
 			Features:    []string{"Workflow", "Multi-Agent", "Streaming", "Step Tracking"},
 		},
 	}
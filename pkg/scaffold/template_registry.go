@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/agenticgokit/agk/internal/config"
 )
 
 const (
@@ -69,7 +71,7 @@ func (g *QuickstartGenerator) GetMetadata() TemplateMetadata {
 		Name:        "Quickstart",
 		Description: "Minimal setup - perfect for learning",
 		Complexity:  "⭐",
-		FileCount:   2,
+		FileCount:   3,
 		Features:    []string{"Agent", "Hardcoded Config"},
 	}
 }
@@ -83,7 +85,7 @@ func (g *QuickstartGenerator) Generate(ctx context.Context, opts GenerateOptions
 	// Prepare template data
 	data := TemplateData{
 		ProjectName: opts.ProjectName,
-		LLMModel:    getLLMModel(opts.LLMProvider), // Dynamic model selection
+		LLMModel:    resolveLLMModel(opts), // Dynamic model selection
 		LLMProvider: opts.LLMProvider,
 		Description: opts.Description,
 		AgentType:   opts.AgentType,
@@ -96,7 +98,7 @@ func (g *QuickstartGenerator) Generate(ctx context.Context, opts GenerateOptions
 	}
 
 	goModPath := filepath.Join(opts.ProjectPath, "go.mod")
-	if err := os.WriteFile(goModPath, []byte(goModContent), 0600); err != nil {
+	if err := writeProjectFile(opts, goModPath, []byte(goModContent), 0600); err != nil {
 		return fmt.Errorf("failed to create go.mod: %w", err)
 	}
 
@@ -107,11 +109,11 @@ func (g *QuickstartGenerator) Generate(ctx context.Context, opts GenerateOptions
 	}
 
 	mainGoPath := filepath.Join(opts.ProjectPath, "main.go")
-	if err := os.WriteFile(mainGoPath, []byte(mainGoContent), 0600); err != nil {
+	if err := writeProjectFile(opts, mainGoPath, []byte(mainGoContent), 0600); err != nil {
 		return fmt.Errorf("failed to create main.go: %w", err)
 	}
 
-	return nil
+	return writeProjectConfig(opts, TemplateQuickstart, "")
 }
 
 // ===== GENERATORS =====
@@ -128,7 +130,7 @@ func (g *WorkflowGenerator) GetMetadata() TemplateMetadata {
 		Name:        "Workflow",
 		Description: "Multi-step streaming workflow pipeline",
 		Complexity:  "⭐⭐⭐",
-		FileCount:   3,
+		FileCount:   5,
 		Features:    []string{"Workflow", "Multi-Agent", "Streaming", "Step Tracking"},
 	}
 }
@@ -138,8 +140,13 @@ func (g *WorkflowGenerator) Generate(ctx context.Context, opts GenerateOptions)
 		"go.mod":    "templates/workflow/go.mod.tmpl",
 		"main.go":   "templates/workflow/main.go.tmpl",
 		"README.md": "templates/workflow/README.md.tmpl",
+		// The same pipeline for 'agk workflow run'
+		"workflow/main.yaml": "templates/workflow/main.yaml.tmpl",
 	}
-	return generateTemplateFiles(opts, files)
+	if err := generateTemplateFiles(opts, files); err != nil {
+		return err
+	}
+	return writeProjectConfig(opts, TemplateWorkflow, "workflow/main.yaml")
 }
 
 func generateTemplateFiles(opts GenerateOptions, files map[string]string) error {
@@ -149,7 +156,7 @@ func generateTemplateFiles(opts GenerateOptions, files map[string]string) error
 
 	data := TemplateData{
 		ProjectName: opts.ProjectName,
-		LLMModel:    getLLMModel(opts.LLMProvider),
+		LLMModel:    resolveLLMModel(opts),
 		LLMProvider: opts.LLMProvider,
 		Description: opts.Description,
 		AgentType:   opts.AgentType,
@@ -163,7 +170,10 @@ func generateTemplateFiles(opts GenerateOptions, files map[string]string) error
 		}
 
 		filePath := filepath.Join(opts.ProjectPath, fileName)
-		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(fileName), err)
+		}
+		if err := writeProjectFile(opts, filePath, []byte(content), 0600); err != nil {
 			return fmt.Errorf("failed to create %s: %w", fileName, err)
 		}
 	}
@@ -171,32 +181,46 @@ func generateTemplateFiles(opts GenerateOptions, files map[string]string) error
 	return nil
 }
 
+// writeProjectConfig writes the project's agk.toml, which 'agk serve',
+// 'agk workflow run' and 'agk init --from-config' read. An existing agk.toml
+// is the project's own and is kept.
+func writeProjectConfig(opts GenerateOptions, templateType TemplateType, defaultWorkflow string) error {
+	path := filepath.Join(opts.ProjectPath, "agk.toml")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	cfg := &config.ProjectConfig{
+		Name:            opts.ProjectName,
+		Description:     opts.Description,
+		Template:        string(templateType),
+		LLMProvider:     opts.LLMProvider,
+		Model:           resolveLLMModel(opts),
+		AgentType:       opts.AgentType,
+		DefaultWorkflow: defaultWorkflow,
+	}
+	if err := config.NewGenerator().GenerateConfig(cfg, path); err != nil {
+		return err
+	}
+	if opts.Record != nil {
+		opts.Record.Created = append(opts.Record.Created, "agk.toml")
+	}
+	return nil
+}
+
 // Helper to get default model for provider
 func getLLMModel(provider string) string {
-	switch provider {
-	case ProviderAnthropic:
-		return "claude-sonnet-4-20250514"
-	case ProviderOllama:
-		return "llama3.2"
-	case ProviderOpenAI:
-		return "gpt-4o"
-	default:
-		return "gpt-4o"
+	return config.DefaultModel(provider)
+}
+
+// resolveLLMModel returns the explicitly requested model or the provider default
+func resolveLLMModel(opts GenerateOptions) string {
+	if opts.LLMModel != "" {
+		return opts.LLMModel
 	}
+	return getLLMModel(opts.LLMProvider)
 }
 
 // Helper to get the API key environment variable name for provider
 func getAPIKeyEnv(provider string) string {
-	switch provider {
-	case ProviderAnthropic:
-		return "ANTHROPIC_API_KEY"
-	case ProviderOllama:
-		return "OLLAMA_HOST" // Ollama doesn't need API key, but uses host
-	case ProviderOpenAI:
-		return "OPENAI_API_KEY"
-	case ProviderAzure:
-		return "AZURE_OPENAI_API_KEY"
-	default:
-		return "OPENAI_API_KEY"
-	}
+	return config.APIKeyEnv(provider)
 }
@@ -0,0 +1,135 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// skeletonFiles maps file names to the content of a starter template.
+// The placeholder __TEMPLATE_NAME__ is replaced with the template name.
+var skeletonFiles = map[string]string{
+	"agk-template.toml": `[template]
+name = "__TEMPLATE_NAME__"
+version = "0.1.0"
+description = "Describe what projects generated from this template do"
+author = "Your Name <your.email@example.com>"
+license = "MIT"
+min_agk_version = "0.1.0"
+
+# Variables users can customize during 'agk init'
+[template.variables.llm_provider]
+type = "choice"
+description = "LLM provider to use"
+default = "openai"
+options = ["openai", "anthropic", "ollama"]
+
+[template.variables.enable_tracing]
+type = "bool"
+description = "Enable tracing in the generated project"
+default = true
+
+# Files copied from the template (glob patterns)
+[template.files]
+exclude = ["README.md"]
+
+# Commands run after the project is generated
+[template.hooks]
+post_create = ["go mod tidy"]
+`,
+	"go.mod.tmpl": `module github.com/example/{{ .ProjectName }}
+
+go 1.21
+
+require (
+	github.com/agenticgokit/agenticgokit v0.5.4
+)
+`,
+	"main.go.tmpl": `package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	agk "github.com/agenticgokit/agenticgokit/v1beta"
+	_ "github.com/agenticgokit/agenticgokit/plugins/llm/{{ .LLMProvider | default "openai" }}"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Generated from the __TEMPLATE_NAME__ template
+	agent, err := agk.NewBuilder("{{ .ProjectName }}").
+		WithLLM("{{ .LLMProvider | default "openai" }}", "{{ .LLMModel }}").
+		Build()
+	if err != nil {
+		log.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.Cleanup(ctx)
+
+	result, err := agent.Run(ctx, "Hello from {{ .ProjectName }}!")
+	if err != nil {
+		log.Fatalf("Agent run failed: %v", err)
+	}
+
+	fmt.Println(result.Content)
+}
+`,
+	"README.md": `# __TEMPLATE_NAME__
+
+An AGK project template.
+
+## Using this template
+
+    agk init my-project --template ./__TEMPLATE_NAME__
+
+## Authoring
+
+Every file except ` + "`agk-template.toml`" + ` and the patterns listed in
+` + "`[template.files] exclude`" + ` is rendered with Go's text/template (plus
+Sprig functions) and copied into the new project. A ` + "`.tmpl`" + ` suffix is
+stripped from the output file name. This README is excluded, so add a
+` + "`README.md.tmpl`" + ` if generated projects should have their own README.
+
+### Available variables
+
+| Variable           | Description                                   |
+|--------------------|-----------------------------------------------|
+| ` + "`.ProjectName`" + `     | Project name passed to ` + "`agk init`" + `             |
+| ` + "`.Description`" + `     | Value of ` + "`--description`" + `                      |
+| ` + "`.LLMProvider`" + `     | Value of ` + "`--llm`" + ` (openai, anthropic, ollama)  |
+| ` + "`.LLMModel`" + `        | Value of ` + "`--model`" + ` or the provider default    |
+| ` + "`.APIKeyEnv`" + `       | Environment variable holding the API key      |
+| ` + "`.AgentType`" + `       | Value of ` + "`--agent-type`" + `                       |
+| ` + "`.WorkflowName`" + `    | Workflow name (built-in templates only)       |
+
+## Publishing
+
+Push this directory to a Git repository and share it with:
+
+    agk init my-project --template github.com/<user>/<repo>
+`,
+}
+
+// GenerateTemplateSkeleton writes a starter template directory for template authors
+func GenerateTemplateSkeleton(dir, name string, force bool) error {
+	if _, err := os.Stat(dir); err == nil && !force {
+		return fmt.Errorf("directory already exists: %s", dir)
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create template directory: %w", err)
+	}
+
+	for fileName, content := range skeletonFiles {
+		content = strings.ReplaceAll(content, "__TEMPLATE_NAME__", name)
+		filePath := filepath.Join(dir, fileName)
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+			return fmt.Errorf("failed to create %s: %w", fileName, err)
+		}
+	}
+
+	return nil
+}
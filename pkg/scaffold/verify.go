@@ -0,0 +1,38 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrGoNotFound is returned by VerifyProject when the go toolchain isn't on PATH
+var ErrGoNotFound = errors.New("go toolchain not found on PATH")
+
+// VerifyProject checks that a generated project compiles.
+// It resolves dependencies with `go mod tidy` and then runs `go build ./...`
+// in the project directory, returning the compiler output on failure.
+func VerifyProject(ctx context.Context, projectPath string) error {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return ErrGoNotFound
+	}
+
+	steps := [][]string{
+		{"mod", "tidy"},
+		{"build", "./..."},
+	}
+
+	for _, args := range steps {
+		cmd := exec.CommandContext(ctx, goBin, args...)
+		cmd.Dir = projectPath
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("go %s failed: %w\n%s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// BackupSuffix is appended to existing files that generation overwrites.
+// When a backup already exists the new one also gets a timestamp, so an
+// earlier backup is never lost.
+const BackupSuffix = ".bak"
+
+// GeneratedFiles lists the files a generator wrote, relative to the project
+// directory
+type GeneratedFiles struct {
+	Created  []string
+	BackedUp []string // Existing files moved aside to a backup before being overwritten
+}
+
+// Sort orders the recorded paths so reports are stable across runs
+func (f *GeneratedFiles) Sort() {
+	sort.Strings(f.Created)
+	sort.Strings(f.BackedUp)
+}
+
+// writeProjectFile writes a generated file, first moving any existing file
+// at path aside to a backup (see backupPath) so a forced init never loses data
+func writeProjectFile(opts GenerateOptions, path string, content []byte, perm os.FileMode) error {
+	relPath, err := filepath.Rel(opts.ProjectPath, path)
+	if err != nil {
+		relPath = path
+	}
+
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		backupPath := backupPath(path)
+		if err := os.Rename(path, backupPath); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		if opts.Record != nil {
+			opts.Record.BackedUp = append(opts.Record.BackedUp, relPath)
+		} else {
+			fmt.Println(color.YellowString("  ↺ Backed up existing %s to %s", path, backupPath))
+		}
+	}
+
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return err
+	}
+	if opts.Record != nil {
+		opts.Record.Created = append(opts.Record.Created, relPath)
+	}
+	return nil
+}
+
+// backupPath returns where to move the existing file at path: path plus
+// BackupSuffix, or when that is taken a name that also carries the time,
+// e.g. agk.toml.bak.20260102-150405
+func backupPath(path string) string {
+	backup := path + BackupSuffix
+	if _, err := os.Lstat(backup); os.IsNotExist(err) {
+		return backup
+	}
+	stamped := backup + "." + time.Now().Format("20060102-150405")
+	backup = stamped
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(backup); os.IsNotExist(err) {
+			return backup
+		}
+		backup = fmt.Sprintf("%s-%d", stamped, i)
+	}
+}